@@ -0,0 +1,184 @@
+// Command cli runs maintenance tasks against the personal library, such as
+// bulk imports, without going through the HTTP API.
+//
+// Usage:
+//
+//	go run ./cmd/cli <command> [arguments]
+package main
+
+import (
+	"context"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"os"
+
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/barcode"
+	"github.com/rfulgencio3/go-personal-library/internal/export"
+	"github.com/rfulgencio3/go-personal-library/internal/metadata"
+	"github.com/rfulgencio3/go-personal-library/internal/repository/mongodb"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+)
+
+const usage = `Usage: cli <command> [arguments]
+
+Commands:
+  import-calibre <library-dir>   Import books from a Calibre library folder
+  import-marc <file>             Import books from an ISO 2709 or MARCXML file
+  export-marcxml <file|->        Export every book as a MARCXML collection
+  enrich                         Suggest missing subtitles, publishers and pages
+  scan <image>...                Decode the EAN-13 barcode of each photo
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	// A leitura de códigos de barras não precisa do banco de dados.
+	if os.Args[1] == "scan" {
+		if !scanImages(os.Args[2:]) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Carregar a configuração do arquivo .env
+	config, err := configs.LoadConfig()
+	if err != nil {
+		log.Fatalf("Erro ao carregar a configuração: %v", err)
+	}
+
+	// Conectar ao MongoDB
+	client, err := mongodb.NewMongoClient(config)
+	if err != nil {
+		log.Fatalf("Erro ao conectar ao MongoDB: %v", err)
+	}
+
+	bookRepo, err := mongodb.NewBookRepository(client, config)
+	if err != nil {
+		log.Fatalf("Erro ao criar os índices da coleção de livros: %v", err)
+	}
+	// As leituras antigas precisam sair da coleção de livros antes das obras.
+	readBookRepo := mongodb.NewReadBookRepository(client, config)
+	authorRepo := mongodb.NewAuthorRepository(client, config)
+	seriesRepo := mongodb.NewSeriesRepository(client, config)
+	tagRepo := mongodb.NewTagRepository(client, config)
+	workRepo := mongodb.NewWorkRepository(client, config)
+	copyRepo := mongodb.NewCopyRepository(client, config)
+	importUseCase := usecase.NewImportUseCase(bookRepo, authorRepo, seriesRepo, tagRepo, workRepo, copyRepo)
+	exportUseCase := usecase.NewExportUseCase(bookRepo, readBookRepo)
+
+	command, args := os.Args[1], os.Args[2:]
+	switch command {
+	case "import-calibre":
+		if len(args) != 1 {
+			log.Fatal("Uso: cli import-calibre <library-dir>")
+		}
+		result, err := importUseCase.ImportCalibreLibrary(args[0])
+		if err != nil {
+			log.Fatalf("Erro ao importar a biblioteca do Calibre: %v", err)
+		}
+		printImportResult(result)
+	case "import-marc":
+		if len(args) != 1 {
+			log.Fatal("Uso: cli import-marc <file>")
+		}
+		f, err := os.Open(args[0])
+		if err != nil {
+			log.Fatalf("Erro ao abrir o arquivo: %v", err)
+		}
+		defer f.Close()
+		result, err := importUseCase.ImportMARC(f)
+		if err != nil {
+			log.Fatalf("Erro ao importar os registros MARC: %v", err)
+		}
+		printImportResult(result)
+	case "export-marcxml":
+		if len(args) != 1 {
+			log.Fatal("Uso: cli export-marcxml <file|->")
+		}
+		out, closeOut := createOutput(args[0])
+		defer closeOut()
+		if err := exportUseCase.Export(export.FormatMARCXML, out); err != nil {
+			log.Fatalf("Erro ao exportar os registros MARCXML: %v", err)
+		}
+	case "enrich":
+		metadataProvider, err := metadata.NewFromConfig(config)
+		if err != nil {
+			log.Fatalf("Erro ao configurar o provedor de metadados: %v", err)
+		}
+		suggestionRepo := mongodb.NewSuggestionRepository(client, config)
+		// A CLI só gera sugestões; elas são aprovadas pela API.
+		enrichmentUseCase := usecase.NewEnrichmentUseCase(bookRepo, nil, suggestionRepo, suggestionRepo, metadataProvider, config.EnrichmentRatePerMinute)
+		progress, err := enrichmentUseCase.Run(context.Background())
+		if err != nil {
+			log.Fatalf("Erro no job de enriquecimento: %v", err)
+		}
+		fmt.Printf("Analisados: %d, sugestões: %d\n", progress.Processed, progress.Suggested)
+	default:
+		fmt.Fprintf(os.Stderr, "Comando desconhecido: %s\n\n%s", command, usage)
+		os.Exit(2)
+	}
+}
+
+// scanImages prints the barcode read from each photo, which makes it easy to
+// check the decoder against a folder of sample images. It reports whether
+// every photo was read.
+func scanImages(paths []string) bool {
+	if len(paths) == 0 {
+		log.Fatal("Uso: cli scan <image>...")
+	}
+	ok := true
+	for _, path := range paths {
+		code, err := scanImage(path)
+		if err != nil {
+			fmt.Printf("%s: %v\n", path, err)
+			ok = false
+			continue
+		}
+		fmt.Printf("%s: %s\n", path, code)
+	}
+	return ok
+}
+
+func scanImage(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return "", err
+	}
+	return barcode.Decode(img)
+}
+
+func printImportResult(result *usecase.ImportResult) {
+	fmt.Printf("Criados: %d, atualizados: %d, falhas: %d\n", result.Created, result.Updated, len(result.Failures))
+	for _, failure := range result.Failures {
+		fmt.Printf("  %s: %s\n", failure.Source, failure.Error)
+	}
+}
+
+// createOutput opens the destination file, or standard output when path is "-".
+func createOutput(path string) (io.Writer, func()) {
+	if path == "-" {
+		return os.Stdout, func() {}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("Erro ao criar o arquivo: %v", err)
+	}
+	return f, func() {
+		if err := f.Close(); err != nil {
+			log.Fatalf("Erro ao gravar o arquivo: %v", err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/barcode"
+)
+
+func TestScanImage(t *testing.T) {
+	// As fotos de exemplo do decodificador servem também aqui.
+	fixtures := filepath.Join("..", "..", "internal", "barcode", "testdata")
+	notImage := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(notImage, []byte("not a photo"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path    string
+		want    string
+		wantErr error
+	}{
+		{path: filepath.Join(fixtures, "clean.png"), want: "9788535902778"},
+		// Fotos de celular costumam vir em JPEG.
+		{path: filepath.Join(fixtures, "rotated.jpg"), want: "9788535902778"},
+		{path: filepath.Join(fixtures, "no-barcode.png"), wantErr: barcode.ErrNotFound},
+		{path: filepath.Join(fixtures, "missing.png"), wantErr: fs.ErrNotExist},
+	}
+	for _, tt := range tests {
+		t.Run(filepath.Base(tt.path), func(t *testing.T) {
+			got, err := scanImage(tt.path)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("scanImage = %q, %v, want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := scanImage(notImage); err == nil {
+		t.Error("scanImage read a text file as a photo")
+	}
+}
+
+func TestCreateOutput(t *testing.T) {
+	if w, done := createOutput("-"); w != os.Stdout {
+		t.Errorf("createOutput(-) = %v, want standard output", w)
+	} else {
+		done()
+	}
+
+	path := filepath.Join(t.TempDir(), "library.xml")
+	w, done := createOutput(path)
+	fmt.Fprint(w, "<collection/>")
+	done()
+	if data, err := os.ReadFile(path); err != nil || string(data) != "<collection/>" {
+		t.Errorf("output file = %q, %v, want the written collection", data, err)
+	}
+}
@@ -1,13 +1,17 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/rfulgencio3/go-personal-library/configs"
 	"github.com/rfulgencio3/go-personal-library/internal/handler"
+	"github.com/rfulgencio3/go-personal-library/internal/metadata"
 	"github.com/rfulgencio3/go-personal-library/internal/repository/mongodb"
+	"github.com/rfulgencio3/go-personal-library/internal/storage"
 	"github.com/rfulgencio3/go-personal-library/internal/usecase"
 
 	_ "github.com/rfulgencio3/go-personal-library/docs"
@@ -44,20 +48,130 @@ func main() {
 		log.Fatalf("Erro ao conectar ao MongoDB: %v", err)
 	}
 
+	// Configurar o provedor de metadados usado para preencher livros pelo ISBN
+	metadataProvider, err := metadata.NewFromConfig(config)
+	if err != nil {
+		log.Fatalf("Erro ao configurar o provedor de metadados: %v", err)
+	}
+
+	// Configurar o armazenamento de arquivos (capas e anexos)
+	blobStore, err := storage.NewFromConfig(config)
+	if err != nil {
+		log.Fatalf("Erro ao configurar o armazenamento de arquivos: %v", err)
+	}
+
 	// Inicializar Repositório, UseCase e Handler
-	bookRepo := mongodb.NewBookRepository(client, config)
-	bookUseCase := usecase.NewBookUseCase(bookRepo)
+	bookRepo, err := mongodb.NewBookRepository(client, config)
+	if err != nil {
+		log.Fatalf("Erro ao criar os índices da coleção de livros: %v", err)
+	}
+	// As leituras antigas ficam na coleção de livros e precisam sair dela
+	// antes que os livros sejam agrupados em obras.
+	readBookRepo := mongodb.NewReadBookRepository(client, config)
+	authorRepo := mongodb.NewAuthorRepository(client, config)
+	seriesRepo := mongodb.NewSeriesRepository(client, config)
+	tagRepo := mongodb.NewTagRepository(client, config)
+	workRepo := mongodb.NewWorkRepository(client, config)
+	copyRepo := mongodb.NewCopyRepository(client, config)
+	loanRepo := mongodb.NewLoanRepository(client, config)
+	shelfRepo := mongodb.NewShelfRepository(client, config)
+	bookUseCase := usecase.NewBookUseCase(bookRepo, authorRepo, seriesRepo, tagRepo, workRepo, copyRepo, loanRepo, shelfRepo, blobStore, metadataProvider)
 	bookHandler := handler.NewBookHandler(bookUseCase)
 
-	readBookRepo := mongodb.NewReadBookRepository(client, config)
-	readBookUC := usecase.NewReadBookUseCase(readBookRepo)
+	copyUseCase := usecase.NewCopyUseCase(copyRepo, bookRepo)
+	copyHandler := handler.NewCopyHandler(copyUseCase)
+
+	loanUseCase := usecase.NewLoanUseCase(loanRepo, bookRepo, copyRepo)
+	loanHandler := handler.NewLoanHandler(loanUseCase)
+
+	rateRepo := mongodb.NewExchangeRateRepository(client, config)
+	reportUseCase := usecase.NewReportUseCase(copyRepo, rateRepo, bookRepo, config.BaseCurrency)
+	reportHandler := handler.NewReportHandler(reportUseCase)
+
+	wishlistRepo := mongodb.NewWishlistRepository(client, config)
+	shareRepo := mongodb.NewWishlistShareRepository(client, config)
+	wishlistUseCase := usecase.NewWishlistUseCase(wishlistRepo, shareRepo, bookUseCase, config.PublicURL)
+	wishlistHandler := handler.NewWishlistHandler(wishlistUseCase)
+
+	authorUseCase := usecase.NewAuthorUseCase(authorRepo, bookRepo)
+	authorHandler := handler.NewAuthorHandler(authorUseCase)
+
+	tagUseCase := usecase.NewTagUseCase(tagRepo, tagRepo, bookRepo)
+	tagHandler := handler.NewTagHandler(tagUseCase)
+
+	shelfUseCase := usecase.NewShelfUseCase(shelfRepo, bookRepo)
+	shelfHandler := handler.NewShelfHandler(shelfUseCase)
+
+	locationRepo := mongodb.NewLocationRepository(client, config)
+	locationUseCase := usecase.NewLocationUseCase(locationRepo, copyRepo, bookRepo)
+	locationHandler := handler.NewLocationHandler(locationUseCase)
+
+	auditRepo := mongodb.NewAuditRepository(client, config)
+	auditUseCase := usecase.NewAuditUseCase(auditRepo, locationRepo, copyRepo, bookRepo)
+	auditHandler := handler.NewAuditHandler(auditUseCase)
+
+	readBookUC := usecase.NewReadBookUseCase(readBookRepo, bookRepo, workRepo)
 	readBookHandler := handler.NewReadBookHandler(readBookUC)
 
+	workUseCase := usecase.NewWorkUseCase(workRepo, bookRepo, copyRepo, readBookRepo)
+	workHandler := handler.NewWorkHandler(workUseCase)
+
+	seriesUseCase := usecase.NewSeriesUseCase(seriesRepo, bookRepo, readBookRepo)
+	seriesHandler := handler.NewSeriesHandler(seriesUseCase)
+
+	exportUseCase := usecase.NewExportUseCase(bookRepo, readBookRepo)
+	exportHandler := handler.NewExportHandler(exportUseCase)
+
+	importUseCase := usecase.NewImportUseCase(bookRepo, authorRepo, seriesRepo, tagRepo, workRepo, copyRepo)
+	importHandler := handler.NewImportHandler(importUseCase)
+
+	citationUseCase := usecase.NewCitationUseCase(bookRepo)
+	citationHandler := handler.NewCitationHandler(citationUseCase)
+
+	suggestionRepo := mongodb.NewSuggestionRepository(client, config)
+	enrichmentUseCase := usecase.NewEnrichmentUseCase(bookRepo, bookUseCase, suggestionRepo, suggestionRepo, metadataProvider, config.EnrichmentRatePerMinute)
+	suggestionHandler := handler.NewSuggestionHandler(enrichmentUseCase)
+
+	coverUseCase := usecase.NewCoverUseCase(bookRepo, blobStore)
+	coverHandler := handler.NewCoverHandler(coverUseCase)
+	blobHandler := handler.NewBlobHandler(blobStore)
+
+	attachmentUseCase := usecase.NewAttachmentUseCase(bookRepo, blobStore)
+	bookFileUseCase := usecase.NewBookFileUseCase(bookRepo, bookUseCase, coverUseCase, attachmentUseCase)
+	bookFileHandler := handler.NewBookFileHandler(bookFileUseCase, attachmentUseCase)
+
+	scanUseCase := usecase.NewScanUseCase(bookRepo, metadataProvider)
+	scanHandler := handler.NewScanHandler(scanUseCase)
+
+	// Executar o job de enriquecimento periodicamente, se configurado
+	if config.EnrichmentInterval > 0 && metadataProvider != nil {
+		go runEnrichment(enrichmentUseCase, config.EnrichmentInterval)
+	}
+
 	// Configurar Rotas
 	router := mux.NewRouter()
 	router.Use(middleware.LoggingMiddleware)
+	bookFileHandler.RegisterRoutes(router)
 	bookHandler.RegisterRoutes(router)
+	workHandler.RegisterRoutes(router)
+	copyHandler.RegisterRoutes(router)
+	loanHandler.RegisterRoutes(router)
+	reportHandler.RegisterRoutes(router)
+	wishlistHandler.RegisterRoutes(router)
+	authorHandler.RegisterRoutes(router)
+	seriesHandler.RegisterRoutes(router)
+	tagHandler.RegisterRoutes(router)
+	shelfHandler.RegisterRoutes(router)
+	locationHandler.RegisterRoutes(router)
+	auditHandler.RegisterRoutes(router)
 	readBookHandler.RegisterRoutes(router)
+	exportHandler.RegisterRoutes(router)
+	importHandler.RegisterRoutes(router)
+	citationHandler.RegisterRoutes(router)
+	suggestionHandler.RegisterRoutes(router)
+	coverHandler.RegisterRoutes(router)
+	blobHandler.RegisterRoutes(router)
+	scanHandler.RegisterRoutes(router)
 
 	// Registrar o handler do Swagger
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
@@ -68,3 +182,17 @@ func main() {
 		log.Fatalf("Erro ao iniciar o servidor: %v", err)
 	}
 }
+
+// runEnrichment runs the enrichment job every interval. Each run resumes
+// from the progress saved by the previous one.
+func runEnrichment(uc usecase.EnrichmentUseCase, interval time.Duration) {
+	for {
+		progress, err := uc.Run(context.Background())
+		if err != nil {
+			log.Printf("Erro no job de enriquecimento: %v", err)
+		} else {
+			log.Printf("Job de enriquecimento concluído: %d livros analisados, %d sugestões", progress.Processed, progress.Suggested)
+		}
+		time.Sleep(interval)
+	}
+}
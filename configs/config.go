@@ -1,16 +1,62 @@
 package configs
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	ServerPort      string
-	MongoURI        string
-	MongoDatabase   string
-	MongoCollection string
+	ServerPort                string
+	MongoURI                  string
+	MongoDatabase             string
+	MongoCollection           string
+	MongoReadBookCollection   string
+	MongoSuggestionCollection string
+	MongoJobCollection        string
+	MongoAuthorCollection     string
+	MongoSeriesCollection     string
+	MongoTagCollection        string
+	MongoTagHistoryCollection string
+	MongoShelfCollection      string
+	MongoLocationCollection   string
+	MongoAuditCollection      string
+	MongoWorkCollection       string
+	MongoCopyCollection       string
+	MongoLoanCollection       string
+	MongoRateCollection       string
+	MongoWishlistCollection   string
+	MongoShareCollection      string
+	MetadataProvider          string
+	MetadataFixtureDir        string
+	MetadataCacheDir          string
+	OpenLibraryURL            string
+	// PublicURL is the address clients use to reach the API, used to build
+	// signed links to blobs kept in the local store.
+	PublicURL string
+	// BlobStore selects the blob storage backend: "fs" or "s3".
+	BlobStore string
+	// BlobStoreDir is where the "fs" backend keeps its files.
+	BlobStoreDir string
+	// BlobSigningKey signs the links to local blobs; without it the "fs"
+	// backend cannot create signed URLs.
+	BlobSigningKey string
+	S3Endpoint     string
+	S3Region       string
+	S3Bucket       string
+	S3AccessKey    string
+	S3SecretKey    string
+	S3PathStyle    bool
+	// EnrichmentInterval is how often the enrichment job runs in the API
+	// process. Zero disables the schedule; the job can still run from the CLI.
+	EnrichmentInterval time.Duration
+	// EnrichmentRatePerMinute limits the metadata lookups made by the job.
+	EnrichmentRatePerMinute int
+	// BaseCurrency is the currency the collection value report converts to.
+	BaseCurrency string
 }
 
 func LoadConfig() (*Config, error) {
@@ -19,12 +65,106 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	enrichmentInterval, err := getDurationEnv("ENRICHMENT_INTERVAL", 0)
+	if err != nil {
+		return nil, err
+	}
+	enrichmentRate, err := getIntEnv("ENRICHMENT_RATE_PER_MINUTE", 30)
+	if err != nil {
+		return nil, err
+	}
+
+	s3PathStyle, err := getBoolEnv("S3_PATH_STYLE", true)
+	if err != nil {
+		return nil, err
+	}
+
 	config := &Config{
-		ServerPort:      os.Getenv("SERVER_PORT"),
-		MongoURI:        os.Getenv("MONGO_URI"),
-		MongoDatabase:   os.Getenv("MONGO_DATABASE"),
-		MongoCollection: os.Getenv("MONGO_COLLECTION"),
+		ServerPort:                os.Getenv("SERVER_PORT"),
+		MongoURI:                  os.Getenv("MONGO_URI"),
+		MongoDatabase:             os.Getenv("MONGO_DATABASE"),
+		MongoCollection:           os.Getenv("MONGO_COLLECTION"),
+		MongoReadBookCollection:   getEnv("MONGO_READ_BOOK_COLLECTION", "read_books"),
+		MongoSuggestionCollection: getEnv("MONGO_SUGGESTION_COLLECTION", "suggestions"),
+		MongoJobCollection:        getEnv("MONGO_JOB_COLLECTION", "jobs"),
+		MongoAuthorCollection:     getEnv("MONGO_AUTHOR_COLLECTION", "authors"),
+		MongoSeriesCollection:     getEnv("MONGO_SERIES_COLLECTION", "series"),
+		MongoTagCollection:        getEnv("MONGO_TAG_COLLECTION", "tags"),
+		MongoTagHistoryCollection: getEnv("MONGO_TAG_HISTORY_COLLECTION", "tag_history"),
+		MongoShelfCollection:      getEnv("MONGO_SHELF_COLLECTION", "shelves"),
+		MongoLocationCollection:   getEnv("MONGO_LOCATION_COLLECTION", "locations"),
+		MongoAuditCollection:      getEnv("MONGO_AUDIT_COLLECTION", "audits"),
+		MongoWorkCollection:       getEnv("MONGO_WORK_COLLECTION", "works"),
+		MongoCopyCollection:       getEnv("MONGO_COPY_COLLECTION", "copies"),
+		MongoLoanCollection:       getEnv("MONGO_LOAN_COLLECTION", "loans"),
+		MongoRateCollection:       getEnv("MONGO_RATE_COLLECTION", "exchange_rates"),
+		MongoWishlistCollection:   getEnv("MONGO_WISHLIST_COLLECTION", "wishlist"),
+		MongoShareCollection:      getEnv("MONGO_SHARE_COLLECTION", "wishlist_shares"),
+		MetadataProvider:          getEnv("METADATA_PROVIDER", "openlibrary"),
+		MetadataFixtureDir:        getEnv("METADATA_FIXTURE_DIR", "fixtures/metadata"),
+		MetadataCacheDir:          getEnv("METADATA_CACHE_DIR", ".cache/metadata"),
+		OpenLibraryURL:            getEnv("OPENLIBRARY_URL", "https://openlibrary.org"),
+		PublicURL:                 getEnv("PUBLIC_URL", "http://localhost:"+getEnv("SERVER_PORT", "8080")),
+		BlobStore:                 getEnv("BLOB_STORE", "fs"),
+		BlobStoreDir:              getEnv("BLOB_STORE_DIR", "data/blobs"),
+		BlobSigningKey:            os.Getenv("BLOB_SIGNING_KEY"),
+		S3Endpoint:                os.Getenv("S3_ENDPOINT"),
+		S3Region:                  getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:                  os.Getenv("S3_BUCKET"),
+		S3AccessKey:               os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:               os.Getenv("S3_SECRET_KEY"),
+		S3PathStyle:               s3PathStyle,
+		EnrichmentInterval:        enrichmentInterval,
+		EnrichmentRatePerMinute:   enrichmentRate,
+		BaseCurrency:              getEnv("BASE_CURRENCY", "BRL"),
 	}
 
 	return config, nil
 }
+
+// getEnv retorna o valor da variável de ambiente ou o valor padrão informado.
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getDurationEnv lê uma duração no formato do Go, como "24h" ou "30m".
+func getDurationEnv(key string, fallback time.Duration) (time.Duration, error) {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	return d, nil
+}
+
+// getIntEnv lê um número inteiro da variável de ambiente.
+func getIntEnv(key string, fallback int) (int, error) {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	return n, nil
+}
+
+// getBoolEnv lê um valor booleano, como "true" ou "false", da variável de ambiente.
+func getBoolEnv(key string, fallback bool) (bool, error) {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", key, err)
+	}
+	return b, nil
+}
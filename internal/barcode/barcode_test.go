@@ -0,0 +1,122 @@
+package barcode
+
+import (
+	"errors"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecodeFixtures(t *testing.T) {
+	tests := []struct {
+		file    string
+		want    string
+		wantErr error
+	}{
+		{file: "clean.png", want: "9788535902778"},
+		{file: "rotated.jpg", want: "9788535902778"},
+		{file: "upside-down.png", want: "9780306406157"},
+		{file: "low-contrast.jpg", want: "9780306406157"},
+		// O símbolo é legível, mas o dígito verificador não confere.
+		{file: "bad-checksum.png", wantErr: ErrNotFound},
+		{file: "no-barcode.png", wantErr: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			img, _, err := image.Decode(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Decode(img)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Decode = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// idealRuns returns the bar and space widths of a perfectly printed symbol,
+// module units times scale, starting with the first bar.
+func idealRuns(code string, scale float64) []float64 {
+	parity := 0
+	for p, first := range firstDigitParity {
+		if first == code[0]-'0' {
+			parity = p
+		}
+	}
+	runs := []float64{1, 1, 1}
+	for k := 0; k < 6; k++ {
+		w := lWidths[code[1+k]-'0']
+		if parity>>(5-k)&1 == 1 {
+			w = [4]float64{w[3], w[2], w[1], w[0]}
+		}
+		runs = append(runs, w[:]...)
+	}
+	runs = append(runs, 1, 1, 1, 1, 1)
+	for k := 0; k < 6; k++ {
+		w := lWidths[code[7+k]-'0']
+		runs = append(runs, w[:]...)
+	}
+	runs = append(runs, 1, 1, 1)
+	for i := range runs {
+		runs[i] *= scale
+	}
+	return runs
+}
+
+func TestDecodeRuns(t *testing.T) {
+	tests := []struct {
+		name   string
+		runs   []float64
+		want   string
+		wantOK bool
+	}{
+		{name: "ISBN", runs: idealRuns("9788535902778", 2), want: "9788535902778", wantOK: true},
+		{name: "first digit zero", runs: idealRuns("0012345678905", 3), want: "0012345678905", wantOK: true},
+		{name: "wrong check digit", runs: idealRuns("9788535902779", 2)},
+		{name: "too few runs", runs: idealRuns("9788535902778", 2)[:40]},
+		{name: "damaged guard", runs: append([]float64{4}, idealRuns("9788535902778", 2)[1:]...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := decodeRuns(tt.runs)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("decodeRuns = %q, %v; want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValidChecksum(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"9788535902778", true},
+		{"9780306406157", true},
+		{"0012345678905", true},
+		{"9788535902779", false},
+		{"9780306406150", false},
+	}
+	for _, tt := range tests {
+		digits := make([]byte, len(tt.code))
+		for i := range tt.code {
+			digits[i] = tt.code[i] - '0'
+		}
+		if got := validChecksum(digits); got != tt.want {
+			t.Errorf("validChecksum(%s) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Package barcode finds and decodes EAN-13 barcodes, such as the ISBN
+// barcode on the back cover of a book, in photos. It uses only the
+// standard library.
+package barcode
+
+import "math"
+
+// Module widths of the L-code digits, from the leading space to the final
+// bar. G-codes are the same widths reversed, and R-codes use the L widths
+// with bars and spaces swapped.
+var lWidths = [10][4]float64{
+	{3, 2, 1, 1},
+	{2, 2, 2, 1},
+	{2, 1, 2, 2},
+	{1, 4, 1, 1},
+	{1, 1, 3, 2},
+	{1, 2, 3, 1},
+	{1, 1, 1, 4},
+	{1, 3, 1, 2},
+	{1, 2, 1, 3},
+	{3, 1, 1, 2},
+}
+
+// firstDigitParity maps the L/G pattern of the left half, one bit per
+// digit with G as 1, to the implicit first digit.
+var firstDigitParity = map[int]byte{
+	0b000000: 0, 0b001011: 1, 0b001101: 2, 0b001110: 3, 0b010011: 4,
+	0b011001: 5, 0b011100: 6, 0b010101: 7, 0b010110: 8, 0b011010: 9,
+}
+
+// eanRuns is the number of bars and spaces in an EAN-13 symbol: three guards
+// of 3, 5 and 3 runs and twelve digits of 4 runs.
+const eanRuns = 59
+
+// Tolerances, in modules, for matching measured widths to the patterns.
+const (
+	maxGuardVariance   = 0.7
+	maxElementVariance = 0.8
+	maxDigitVariance   = 1.5
+)
+
+// decodeRuns decodes the 59 runs starting at runs[0], which must be a bar.
+// It returns the 13 digits when the guards, digit patterns and check
+// digit are all valid.
+func decodeRuns(runs []float64) (string, bool) {
+	if len(runs) < eanRuns {
+		return "", false
+	}
+	runs = runs[:eanRuns]
+
+	total := 0.0
+	for _, w := range runs {
+		total += w
+	}
+	module := total / 95
+
+	// Borrão e exposição engrossam as barras e afinam os espaços por igual;
+	// o desvio é estimado nas guardas, que só têm elementos de um módulo.
+	guards := [][]float64{runs[0:3], runs[27:32], runs[56:59]}
+	var barSum, spaceSum float64
+	var bars, spaces int
+	for g, guard := range guards {
+		for i, w := range guard {
+			// Nas guardas laterais o primeiro elemento é barra; na central, espaço.
+			isBar := (i%2 == 0) != (g == 1)
+			if isBar {
+				barSum += w
+				bars++
+			} else {
+				spaceSum += w
+				spaces++
+			}
+		}
+	}
+	bias := (barSum/float64(bars) - spaceSum/float64(spaces)) / 2
+
+	widths := make([]float64, eanRuns)
+	for i, w := range runs {
+		isBar := i < 27 && i%2 == 0 || i >= 27 && i < 32 && i%2 == 1 || i >= 32 && i%2 == 0
+		if isBar {
+			widths[i] = (w - bias) / module
+		} else {
+			widths[i] = (w + bias) / module
+		}
+	}
+	for _, guard := range [][]float64{widths[0:3], widths[27:32], widths[56:59]} {
+		for _, w := range guard {
+			if math.Abs(w-1) > maxGuardVariance {
+				return "", false
+			}
+		}
+	}
+
+	digits := make([]byte, 13)
+	parity := 0
+	for k := 0; k < 6; k++ {
+		d, g, ok := matchDigit(widths[3+4*k:7+4*k], true)
+		if !ok {
+			return "", false
+		}
+		digits[1+k] = d
+		parity <<= 1
+		if g {
+			parity |= 1
+		}
+	}
+	first, ok := firstDigitParity[parity]
+	if !ok {
+		return "", false
+	}
+	digits[0] = first
+	for k := 0; k < 6; k++ {
+		d, _, ok := matchDigit(widths[32+4*k:36+4*k], false)
+		if !ok {
+			return "", false
+		}
+		digits[7+k] = d
+	}
+
+	if !validChecksum(digits) {
+		return "", false
+	}
+	code := make([]byte, 13)
+	for i, d := range digits {
+		code[i] = '0' + d
+	}
+	return string(code), true
+}
+
+// matchDigit finds the digit whose pattern is closest to the four widths,
+// normalized to the seven modules of a digit. Left-half digits may also be
+// G-codes, reported through the second result.
+func matchDigit(widths []float64, left bool) (byte, bool, bool) {
+	sum := widths[0] + widths[1] + widths[2] + widths[3]
+	if sum <= 0 {
+		return 0, false, false
+	}
+	var norm [4]float64
+	for i, w := range widths {
+		norm[i] = w * 7 / sum
+	}
+
+	best, bestG, bestVariance := -1, false, math.MaxFloat64
+	for d, pattern := range lWidths {
+		for _, g := range []bool{false, true} {
+			if g && !left {
+				continue
+			}
+			variance, ok := 0.0, true
+			for i := range norm {
+				p := pattern[i]
+				if g {
+					p = pattern[3-i]
+				}
+				diff := math.Abs(norm[i] - p)
+				if diff > maxElementVariance {
+					ok = false
+					break
+				}
+				variance += diff
+			}
+			if ok && variance < bestVariance {
+				best, bestG, bestVariance = d, g, variance
+			}
+		}
+	}
+	if best < 0 || bestVariance > maxDigitVariance {
+		return 0, false, false
+	}
+	return byte(best), bestG, true
+}
+
+func validChecksum(digits []byte) bool {
+	sum := 0
+	for i, d := range digits[:12] {
+		if i%2 == 0 {
+			sum += int(d)
+		} else {
+			sum += 3 * int(d)
+		}
+	}
+	return (10-sum%10)%10 == int(digits[12])
+}
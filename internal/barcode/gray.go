@@ -0,0 +1,92 @@
+package barcode
+
+import (
+	"image"
+	"math"
+)
+
+// gray is a grayscale copy of the photo, with values from 0 to 255.
+type gray struct {
+	width, height int
+	pix           []float64
+}
+
+// newGray converts img to grayscale, averaging blocks of pixels when the
+// image is larger than maxDim so every side fits.
+func newGray(img image.Image, maxDim int) *gray {
+	b := img.Bounds()
+	scale := max(1, (max(b.Dx(), b.Dy())+maxDim-1)/maxDim)
+	g := &gray{width: b.Dx() / scale, height: b.Dy() / scale}
+	g.pix = make([]float64, g.width*g.height)
+
+	// Fotos JPEG já trazem a luminância pronta no plano Y.
+	ycc, isYCbCr := img.(*image.YCbCr)
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			sum := 0.0
+			if isYCbCr {
+				for sy := 0; sy < scale; sy++ {
+					for sx := 0; sx < scale; sx++ {
+						sum += float64(ycc.Y[ycc.YOffset(b.Min.X+x*scale+sx, b.Min.Y+y*scale+sy)])
+					}
+				}
+				g.pix[y*g.width+x] = sum / float64(scale*scale)
+				continue
+			}
+			for sy := 0; sy < scale; sy++ {
+				for sx := 0; sx < scale; sx++ {
+					r, gr, bl, _ := img.At(b.Min.X+x*scale+sx, b.Min.Y+y*scale+sy).RGBA()
+					sum += (0.299*float64(r) + 0.587*float64(gr) + 0.114*float64(bl)) / 257
+				}
+			}
+			g.pix[y*g.width+x] = sum / float64(scale*scale)
+		}
+	}
+	return g
+}
+
+// at samples the image at a fractional position with bilinear interpolation.
+func (g *gray) at(x, y float64) float64 {
+	x0, y0 := int(math.Floor(x)), int(math.Floor(y))
+	fx, fy := x-float64(x0), y-float64(y0)
+	x1, y1 := min(x0+1, g.width-1), min(y0+1, g.height-1)
+	p := func(x, y int) float64 { return g.pix[y*g.width+x] }
+	top := p(x0, y0)*(1-fx) + p(x1, y0)*fx
+	bottom := p(x0, y1)*(1-fx) + p(x1, y1)*fx
+	return top*(1-fy) + bottom*fy
+}
+
+// sampleStep is the distance between samples along a scan line, in pixels.
+// Sampling twice per pixel keeps the edges of narrow bars apart.
+const sampleStep = 0.5
+
+// scanLines samples count parallel lines crossing the image in direction
+// theta, keeping only the part inside the image.
+func (g *gray) scanLines(theta float64, count int) [][]float64 {
+	dx, dy := math.Cos(theta), math.Sin(theta)
+	nx, ny := -dy, dx
+	cx, cy := float64(g.width-1)/2, float64(g.height-1)/2
+	half := math.Hypot(float64(g.width), float64(g.height)) / 2
+
+	var lines [][]float64
+	for i := 0; i < count; i++ {
+		offset := (float64(i)+0.5)/float64(count)*2*half - half
+		ox, oy := cx+nx*offset, cy+ny*offset
+
+		var profile []float64
+		for t := -half; t <= half; t += sampleStep {
+			x, y := ox+dx*t, oy+dy*t
+			if x < 0 || y < 0 || x > float64(g.width-1) || y > float64(g.height-1) {
+				if len(profile) > 0 {
+					break
+				}
+				continue
+			}
+			profile = append(profile, g.at(x, y))
+		}
+		if len(profile) >= eanRuns*2 {
+			lines = append(lines, profile)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,234 @@
+package barcode
+
+import (
+	"errors"
+	"image"
+	"math"
+)
+
+var ErrNotFound = errors.New("no EAN-13 barcode found")
+
+const (
+	// maxDimension bounds the working image; larger photos are scaled down.
+	maxDimension = 1600
+	// angleStep is the rotation between scan directions, in degrees. A
+	// scan line still crosses every bar when tilted up to about 35°, so
+	// the steps overlap comfortably.
+	angleStep = 15
+	// linesPerAngle is how many parallel lines are scanned per direction.
+	linesPerAngle = 80
+	// minContrast is the smallest difference between dark and light, on a
+	// 0-255 scale, for a part of a line to be read.
+	minContrast = 24
+	// votesToAccept stops the scan early once a code was read this often.
+	votesToAccept = 3
+)
+
+// Decode finds an EAN-13 barcode in the image, which may be rotated to any
+// angle and moderately blurred. The image is read along parallel lines in
+// several directions; the code read most often wins.
+func Decode(img image.Image) (string, error) {
+	g := newGray(img, maxDimension)
+	votes := make(map[string]int)
+	best := ""
+
+	for angle := 0; angle < 180; angle += angleStep {
+		theta := float64(angle) * math.Pi / 180
+		for _, profile := range g.scanLines(theta, linesPerAngle) {
+			// Limiarização funciona melhor em imagens nítidas; bordas pelo
+			// gradiente resistem melhor ao borrão, que apaga os módulos finos.
+			for _, split := range []func([]float64) ([]float64, bool){binarize, edgeRuns} {
+				runs, firstDark := split(profile)
+				for _, code := range findCodes(runs, firstDark) {
+					votes[code]++
+					if votes[code] > votes[best] {
+						best = code
+					}
+					if votes[best] >= votesToAccept {
+						return best, nil
+					}
+				}
+			}
+		}
+	}
+	if best == "" {
+		return "", ErrNotFound
+	}
+	return best, nil
+}
+
+// findCodes looks for barcodes in the runs of a line, read in both directions.
+func findCodes(runs []float64, firstDark bool) []string {
+	var codes []string
+	for _, reverse := range []bool{false, true} {
+		r := runs
+		dark := firstDark
+		if reverse {
+			r = make([]float64, len(runs))
+			for i, w := range runs {
+				r[len(runs)-1-i] = w
+			}
+			// Com número par de faixas, a última tem a cor oposta à primeira.
+			dark = firstDark == (len(runs)%2 == 1)
+		}
+		start := 0
+		if !dark {
+			start = 1
+		}
+		for i := start; i+eanRuns <= len(r); i += 2 {
+			if !hasQuietZone(r, i) {
+				continue
+			}
+			if code, ok := decodeRuns(r[i:]); ok {
+				codes = append(codes, code)
+				break
+			}
+		}
+	}
+	return codes
+}
+
+// hasQuietZone checks for a light margin before the barcode starting at
+// runs[i], at least three modules wide.
+func hasQuietZone(runs []float64, i int) bool {
+	if i == 0 {
+		return true
+	}
+	width := 0.0
+	for _, w := range runs[i : i+eanRuns] {
+		width += w
+	}
+	return runs[i-1] >= 3*width/95
+}
+
+// binarize splits a brightness profile into alternating dark and light runs,
+// using the midpoint between the local minimum and maximum as threshold.
+// Edges are placed with sub-sample precision, which keeps narrow bars
+// measurable in blurred images. It reports whether the first run is dark.
+func binarize(profile []float64) ([]float64, bool) {
+	n := len(profile)
+	if n < 2 {
+		return nil, false
+	}
+	window := max(16, n/12)
+	lo, hi := slidingExtremes(profile, window)
+
+	dark := make([]bool, n)
+	threshold := make([]float64, n)
+	for i, v := range profile {
+		if hi[i]-lo[i] < minContrast {
+			threshold[i] = lo[i] - 1
+		} else {
+			threshold[i] = (lo[i] + hi[i]) / 2
+		}
+		dark[i] = v < threshold[i]
+	}
+
+	var runs []float64
+	last := 0.0
+	for i := 1; i < n; i++ {
+		if dark[i] == dark[i-1] {
+			continue
+		}
+		t := (threshold[i] + threshold[i-1]) / 2
+		edge := float64(i - 1)
+		if d := profile[i] - profile[i-1]; d != 0 {
+			edge += math.Min(1, math.Max(0, (t-profile[i-1])/d))
+		}
+		runs = append(runs, edge-last)
+		last = edge
+	}
+	runs = append(runs, float64(n-1)-last)
+	return runs, dark[0]
+}
+
+// edgeRuns splits a profile at the steepest points of each transition,
+// found as extremes of the brightness gradient. Blur lowers the contrast of
+// one-module bars and spaces, so a fixed threshold can miss them, but their
+// edges still show as gradient peaks. It reports whether the first run is dark.
+func edgeRuns(profile []float64) ([]float64, bool) {
+	n := len(profile)
+	if n < 3 {
+		return nil, false
+	}
+	grad := make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		grad[i] = (profile[i+1] - profile[i-1]) / 2
+	}
+	lo, hi := slidingExtremes(profile, max(16, n/12))
+
+	type edge struct {
+		pos      float64
+		strength float64
+		falling  bool
+	}
+	var edges []edge
+	for i := 2; i < n-2; i++ {
+		g := grad[i]
+		contrast := hi[i] - lo[i]
+		if contrast < minContrast || math.Abs(g) < contrast/10 {
+			continue
+		}
+		isPeak := g < 0 && g <= grad[i-1] && g < grad[i+1] || g > 0 && g >= grad[i-1] && g > grad[i+1]
+		if !isPeak {
+			continue
+		}
+		// Ajuste parabólico para localizar o pico entre as amostras.
+		pos := float64(i)
+		if denom := grad[i-1] - 2*g + grad[i+1]; denom != 0 {
+			pos += math.Max(-0.5, math.Min(0.5, (grad[i-1]-grad[i+1])/(2*denom)))
+		}
+		e := edge{pos: pos, strength: math.Abs(g), falling: g < 0}
+		// Duas bordas seguidas no mesmo sentido: fica a mais forte.
+		if len(edges) > 0 && edges[len(edges)-1].falling == e.falling {
+			if e.strength > edges[len(edges)-1].strength {
+				edges[len(edges)-1] = e
+			}
+			continue
+		}
+		edges = append(edges, e)
+	}
+	if len(edges) == 0 {
+		return nil, false
+	}
+
+	runs := make([]float64, 0, len(edges)+1)
+	last := 0.0
+	for _, e := range edges {
+		runs = append(runs, e.pos-last)
+		last = e.pos
+	}
+	runs = append(runs, float64(n-1)-last)
+	// Uma borda descendente (claro para escuro) indica que o trecho inicial é claro.
+	return runs, !edges[0].falling
+}
+
+// slidingExtremes returns the minimum and maximum of the values within
+// window/2 samples of each position.
+func slidingExtremes(values []float64, window int) ([]float64, []float64) {
+	n := len(values)
+	lo, hi := make([]float64, n), make([]float64, n)
+	half := window / 2
+	var minQ, maxQ []int
+	next := 0
+	for i := 0; i < n; i++ {
+		for ; next < n && next <= i+half; next++ {
+			for len(minQ) > 0 && values[minQ[len(minQ)-1]] >= values[next] {
+				minQ = minQ[:len(minQ)-1]
+			}
+			minQ = append(minQ, next)
+			for len(maxQ) > 0 && values[maxQ[len(maxQ)-1]] <= values[next] {
+				maxQ = maxQ[:len(maxQ)-1]
+			}
+			maxQ = append(maxQ, next)
+		}
+		for minQ[0] < i-half {
+			minQ = minQ[1:]
+		}
+		for maxQ[0] < i-half {
+			maxQ = maxQ[1:]
+		}
+		lo[i], hi[i] = values[minQ[0]], values[maxQ[0]]
+	}
+	return lo, hi
+}
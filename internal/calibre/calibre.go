@@ -0,0 +1,125 @@
+// Package calibre reads book metadata from a Calibre library folder.
+package calibre
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/isbn"
+	"github.com/rfulgencio3/go-personal-library/internal/opf"
+)
+
+// MetadataFile is the name of the sidecar file Calibre keeps next to every book.
+const MetadataFile = "metadata.opf"
+
+// pagesColumn is the custom column filled by the popular "Count Pages" plugin.
+const pagesColumn = "calibre:user_metadata:#pages"
+
+// Entry is a book found in the library together with the file it came from.
+type Entry struct {
+	Path string
+	Book *domain.Book
+	Err  error
+}
+
+// ReadLibrary walks the library directory and parses every metadata.opf file.
+// Files that cannot be parsed are returned with Err set instead of aborting the walk.
+func ReadLibrary(dir string) ([]Entry, error) {
+	var entries []Entry
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != MetadataFile {
+			return nil
+		}
+		book, err := ReadMetadataFile(path)
+		entries = append(entries, Entry{Path: path, Book: book, Err: err})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ReadMetadataFile parses a single metadata.opf file into a book.
+func ReadMetadataFile(path string) (*domain.Book, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pkg, err := opf.Parse(f)
+	if err != nil {
+		return nil, err
+	}
+	return BookFromPackage(pkg), nil
+}
+
+// BookFromPackage maps the Dublin Core metadata written by Calibre to a book.
+// The Calibre UUID is kept in the book identifiers so re-imports can find it.
+func BookFromPackage(pkg *opf.Package) *domain.Book {
+	book := &domain.Book{
+		Title:        pkg.Title(),
+		Subtitle:     pkg.Subtitle(),
+		Author:       domain.JoinAuthorNames(pkg.Authors()),
+		Contributors: pkg.Contributors(),
+		Publisher:    pkg.Publisher(),
+		Language:     pkg.Language(),
+		Description:  pkg.Description(),
+		Series:       pkg.Meta("calibre:series"),
+		Tags:         pkg.Tags(),
+	}
+
+	if index, err := strconv.ParseFloat(pkg.Meta("calibre:series_index"), 64); err == nil && book.Series != "" {
+		book.SeriesPosition = index
+	}
+	book.Pages = customColumnInt(pkg.Meta(pagesColumn))
+	book.Year, _ = strconv.Atoi(pkg.Year())
+
+	identifiers := make(map[string]string)
+	for scheme, value := range pkg.Identifiers() {
+		switch scheme {
+		case "calibre":
+			// O id numérico só é válido dentro da biblioteca de origem.
+		case "uuid":
+			identifiers[domain.IdentifierCalibreUUID] = value
+		case "isbn":
+			if isbn10, isbn13, err := isbn.Parse(value); err == nil {
+				book.ISBN10, book.ISBN13 = isbn10, isbn13
+			}
+		default:
+			identifiers[scheme] = value
+		}
+	}
+	if len(identifiers) > 0 {
+		book.Identifiers = identifiers
+	}
+
+	return book
+}
+
+// customColumnInt extracts the integer value of a Calibre custom column, which
+// is serialised as a JSON object with the value under the "#value#" key.
+func customColumnInt(raw string) int {
+	if raw == "" {
+		return 0
+	}
+	var column struct {
+		Value json.Number `json:"#value#"`
+	}
+	if err := json.Unmarshal([]byte(raw), &column); err != nil {
+		return 0
+	}
+	n, err := column.Value.Float64()
+	if err != nil {
+		return 0
+	}
+	return int(n)
+}
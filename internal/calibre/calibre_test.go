@@ -0,0 +1,104 @@
+package calibre
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+func TestReadLibrary(t *testing.T) {
+	entries, err := ReadLibrary(filepath.Join("testdata", "library"))
+	if err != nil {
+		t.Fatalf("ReadLibrary: %v", err)
+	}
+	byDir := make(map[string]Entry)
+	for _, e := range entries {
+		byDir[filepath.Base(filepath.Dir(e.Path))] = e
+	}
+	if len(byDir) != 3 {
+		t.Fatalf("found %d metadata files, want 3", len(byDir))
+	}
+
+	tests := []struct {
+		dir     string
+		wantErr bool
+		want    *domain.Book
+	}{
+		{
+			dir: "Dom Casmurro (1)",
+			want: &domain.Book{
+				Title:          "Dom Casmurro",
+				Author:         "Machado de Assis",
+				Contributors:   []domain.Contributor{{Name: "Machado de Assis", Role: domain.RoleAuthor, Order: 1}},
+				Publisher:      "Garnier",
+				Language:       "por",
+				Description:    "Bentinho e Capitu.\nUm romance & um enigma.",
+				Series:         "Trilogia do Romance",
+				SeriesPosition: 2.5,
+				Tags:           []string{"Romance", "Literatura brasileira"},
+				Pages:          256,
+				Year:           1899,
+				ISBN10:         "8535902775",
+				ISBN13:         "9788535902778",
+				Identifiers: map[string]string{
+					domain.IdentifierCalibreUUID: "5b0f7d8e-3c1a-4e7b-9d2f-1a2b3c4d5e6f",
+					"goodreads":                  "1234567",
+				},
+			},
+		},
+		{
+			// Sem série o índice é ignorado; o ano 0101 é o "desconhecido" do
+			// Calibre e um ISBN inválido é descartado.
+			dir: "A Mao Esquerda da Escuridao (2)",
+			want: &domain.Book{
+				Title:  "A Mão Esquerda da Escuridão",
+				Author: "Ursula K. Le Guin",
+				Contributors: []domain.Contributor{
+					{Name: "Ursula K. Le Guin", Role: domain.RoleAuthor, Order: 1},
+					{Name: "Susana L. de Alexandria", Role: domain.RoleTranslator, Order: 2},
+				},
+				Language:    "por",
+				Identifiers: map[string]string{domain.IdentifierCalibreUUID: "9c8b7a6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"},
+			},
+		},
+		{dir: "Broken (3)", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			e, ok := byDir[tt.dir]
+			if !ok {
+				t.Fatalf("%s not found", tt.dir)
+			}
+			if (e.Err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", e.Err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(e.Book, tt.want) {
+				t.Errorf("book =\n%+v\nwant\n%+v", e.Book, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomColumnInt(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want int
+	}{
+		{`{"#value#": 256, "datatype": "int"}`, 256},
+		{`{"#value#": 312.0}`, 312},
+		{`{"#value#": null}`, 0},
+		{`not json`, 0},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := customColumnInt(tt.raw); got != tt.want {
+			t.Errorf("customColumnInt(%s) = %d, want %d", strings.TrimSpace(tt.raw), got, tt.want)
+		}
+	}
+}
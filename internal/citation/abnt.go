@@ -0,0 +1,71 @@
+package citation
+
+import (
+	"strconv"
+	"strings"
+)
+
+// abnt follows ABNT NBR 6023:2018, the reference standard for Brazilian
+// academic work: "SOBRENOME, Nome. Título: subtítulo. 2. ed. Local: Editora, ano."
+type abnt struct{}
+
+func (abnt) Name() string { return "abnt" }
+
+func (abnt) Format(ref Reference, m Markup) string {
+	var parts []string
+
+	title := m.Italic(ref.Title)
+	if authors := abntAuthors(ref.Authors); authors != "" {
+		parts = append(parts, m.Text(terminate(authors)))
+	} else {
+		// Sem autoria, a entrada é feita pelo título, com a primeira palavra em
+		// maiúsculas e sem destaque.
+		first, rest, _ := strings.Cut(ref.Title, " ")
+		title = m.Text(strings.TrimSpace(strings.ToUpper(first) + " " + rest))
+	}
+	if ref.Subtitle != "" {
+		title += m.Text(": " + ref.Subtitle)
+	}
+	parts = append(parts, title+".")
+
+	if ref.Edition != "" {
+		if n := editionNumber(ref.Edition); n > 1 {
+			parts = append(parts, m.Text(strconv.Itoa(n)+". ed."))
+		} else if n == 0 {
+			parts = append(parts, m.Text(withEditionWord(ref.Edition)))
+		}
+	}
+
+	publisher := ref.Publisher
+	if publisher == "" {
+		publisher = "[s. n.]"
+	}
+	year := "[s. d.]"
+	if ref.Year > 0 {
+		year = strconv.Itoa(ref.Year)
+	}
+	// O local de publicação não é registrado, por isso usa-se a expressão "sine loco".
+	parts = append(parts, m.Text("[S. l.]: "+publisher+", "+year+"."))
+
+	return strings.Join(parts, " ")
+}
+
+// abntAuthors lists up to three authors as "SOBRENOME, Nome" separated by
+// semicolons; with more than three, only the first is given, followed by "et al.".
+func abntAuthors(names []Name) string {
+	if len(names) > 3 {
+		return abntName(names[0]) + " et al"
+	}
+	var formatted []string
+	for _, n := range names {
+		formatted = append(formatted, abntName(n))
+	}
+	return strings.Join(formatted, "; ")
+}
+
+func abntName(n Name) string {
+	if n.Given == "" {
+		return strings.ToUpper(n.Family)
+	}
+	return strings.ToUpper(n.Family) + ", " + n.Given
+}
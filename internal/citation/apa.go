@@ -0,0 +1,94 @@
+package citation
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// apa follows the 7th edition of the APA style:
+// "Family, G. G. (Year). Title: Subtitle (2nd ed.). Publisher."
+type apa struct{}
+
+func (apa) Name() string { return "apa" }
+
+func (apa) Format(ref Reference, m Markup) string {
+	title := ref.Title
+	if ref.Subtitle != "" {
+		title += ": " + capitalizeFirst(ref.Subtitle)
+	}
+	titlePart := m.Italic(title)
+	if edition := englishEdition(ref.Edition); edition != "" {
+		titlePart += m.Text(" (" + edition + ")")
+	}
+
+	year := "n.d."
+	if ref.Year > 0 {
+		year = strconv.Itoa(ref.Year)
+	}
+	yearPart := m.Text("(" + year + ").")
+
+	// Without authors, the title moves to the author position.
+	var parts []string
+	if authors := apaAuthors(ref.Authors); authors != "" {
+		parts = append(parts, m.Text(terminate(authors)), yearPart, titlePart+".")
+	} else {
+		parts = append(parts, titlePart+".", yearPart)
+	}
+
+	if ref.Publisher != "" {
+		parts = append(parts, m.Text(terminate(ref.Publisher)))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// apaAuthors lists authors as "Family, G." joined with commas and an
+// ampersand before the last one. APA lists up to twenty authors.
+func apaAuthors(names []Name) string {
+	var formatted []string
+	for _, n := range names {
+		formatted = append(formatted, apaName(n))
+	}
+	switch len(formatted) {
+	case 0:
+		return ""
+	case 1:
+		return formatted[0]
+	case 2:
+		return formatted[0] + ", & " + formatted[1]
+	}
+	if len(formatted) > 20 {
+		formatted = append(formatted[:19], "... "+formatted[len(formatted)-1])
+		return strings.Join(formatted, ", ")
+	}
+	return strings.Join(formatted[:len(formatted)-1], ", ") + ", & " + formatted[len(formatted)-1]
+}
+
+func apaName(n Name) string {
+	initials := initials(n.Given)
+	if initials == "" {
+		return n.Family
+	}
+	return n.Family + ", " + initials
+}
+
+// initials abbreviates given names, skipping lowercase particles such as
+// "de" and keeping hyphenated names together ("Jean-Paul" becomes "J.-P.").
+func initials(given string) string {
+	var out []string
+	for _, word := range strings.Fields(given) {
+		first := []rune(word)[0]
+		if !unicode.IsUpper(first) {
+			continue
+		}
+		var pieces []string
+		for _, piece := range strings.Split(word, "-") {
+			if piece != "" {
+				pieces = append(pieces, string([]rune(piece)[0])+".")
+			}
+		}
+		out = append(out, strings.Join(pieces, "-"))
+	}
+	return strings.Join(out, " ")
+}
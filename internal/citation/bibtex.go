@@ -0,0 +1,188 @@
+package citation
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+// latexReplacer escapes the characters that have a special meaning in LaTeX.
+var latexReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+	`<`, `\textless{}`,
+	`>`, `\textgreater{}`,
+)
+
+// latexAccents maps accented letters to LaTeX commands, so the output also
+// works with classic BibTeX, which does not understand UTF-8.
+var latexAccents = map[rune]string{
+	'á': `{\'a}`, 'à': "{\\`a}", 'â': `{\^a}`, 'ã': `{\~a}`, 'ä': `{\"a}`, 'å': `{\aa}`,
+	'Á': `{\'A}`, 'À': "{\\`A}", 'Â': `{\^A}`, 'Ã': `{\~A}`, 'Ä': `{\"A}`, 'Å': `{\AA}`,
+	'é': `{\'e}`, 'è': "{\\`e}", 'ê': `{\^e}`, 'ë': `{\"e}`,
+	'É': `{\'E}`, 'È': "{\\`E}", 'Ê': `{\^E}`, 'Ë': `{\"E}`,
+	'í': `{\'i}`, 'ì': "{\\`i}", 'î': `{\^i}`, 'ï': `{\"i}`,
+	'Í': `{\'I}`, 'Ì': "{\\`I}", 'Î': `{\^I}`, 'Ï': `{\"I}`,
+	'ó': `{\'o}`, 'ò': "{\\`o}", 'ô': `{\^o}`, 'õ': `{\~o}`, 'ö': `{\"o}`, 'ø': `{\o}`,
+	'Ó': `{\'O}`, 'Ò': "{\\`O}", 'Ô': `{\^O}`, 'Õ': `{\~O}`, 'Ö': `{\"O}`, 'Ø': `{\O}`,
+	'ú': `{\'u}`, 'ù': "{\\`u}", 'û': `{\^u}`, 'ü': `{\"u}`,
+	'Ú': `{\'U}`, 'Ù': "{\\`U}", 'Û': `{\^U}`, 'Ü': `{\"U}`,
+	'ç': `{\c{c}}`, 'Ç': `{\c{C}}`, 'ñ': `{\~n}`, 'Ñ': `{\~N}`,
+	'ß': `{\ss}`, 'æ': `{\ae}`, 'Æ': `{\AE}`, 'œ': `{\oe}`, 'Œ': `{\OE}`,
+}
+
+// EscapeLaTeX escapes special characters and accented letters for BibTeX.
+func EscapeLaTeX(s string) string {
+	s = latexReplacer.Replace(s)
+	var b strings.Builder
+	for _, r := range s {
+		if cmd, ok := latexAccents[r]; ok {
+			b.WriteString(cmd)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func writeBibTeX(w io.Writer, books []*domain.Book) error {
+	keys := make(map[string]int)
+	for i, book := range books {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, bibTeXEntry(book, uniqueKey(keys, citationKey(book)))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bibTeXEntry(book *domain.Book, key string) string {
+	var fields [][2]string
+	add := func(name, value string) {
+		if value != "" {
+			fields = append(fields, [2]string{name, value})
+		}
+	}
+
+	var authors []string
+	for _, name := range Authors(book) {
+		if name.Given == "" {
+			authors = append(authors, EscapeLaTeX(name.Family))
+			continue
+		}
+		authors = append(authors, EscapeLaTeX(name.Family)+", "+EscapeLaTeX(name.Given))
+	}
+	add("author", strings.Join(authors, " and "))
+	// As chaves duplas preservam a capitalização do título.
+	if book.Title != "" {
+		add("title", "{"+EscapeLaTeX(book.Title)+"}")
+	}
+	add("subtitle", EscapeLaTeX(book.Subtitle))
+	add("edition", EscapeLaTeX(book.Edition))
+	add("publisher", EscapeLaTeX(book.Publisher))
+	if book.Year > 0 {
+		add("year", strconv.Itoa(book.Year))
+	}
+	if book.Pages > 0 {
+		add("pagetotal", strconv.Itoa(book.Pages))
+	}
+	add("series", EscapeLaTeX(book.Series))
+	if book.Series != "" && book.SeriesPosition > 0 {
+		add("number", strconv.FormatFloat(book.SeriesPosition, 'f', -1, 64))
+	}
+	add("isbn", book.ISBN13)
+	add("keywords", EscapeLaTeX(strings.Join(book.Tags, ", ")))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "@book{%s,\n", key)
+	for i, f := range fields {
+		sep := ","
+		if i == len(fields)-1 {
+			sep = ""
+		}
+		fmt.Fprintf(&b, "  %-9s = {%s}%s\n", f[0], f[1], sep)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// citationKey builds a key such as "assis1899_casmurro" from the first
+// author's family name, the year and the first significant word of the title.
+func citationKey(book *domain.Book) string {
+	var parts []string
+	if authors := Authors(book); len(authors) > 0 {
+		family := keyWord(authors[0].Family)
+		if book.Year > 0 {
+			family += strconv.Itoa(book.Year)
+		}
+		parts = append(parts, family)
+	}
+	for _, word := range strings.Fields(book.Title) {
+		if w := keyWord(word); len(w) > 3 {
+			parts = append(parts, w)
+			break
+		}
+	}
+	key := strings.Join(parts, "_")
+	if key == "" || key == "_" {
+		key = "book_" + keyWord(book.ID)
+	}
+	return key
+}
+
+// keyWord lowercases a word and keeps only ASCII letters and digits,
+// dropping accents from Latin letters.
+func keyWord(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if cmd, ok := latexAccents[r]; ok {
+			r = rune(unaccented(cmd))
+		}
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// unaccented returns the base letter of a LaTeX accent command such as {\'a}.
+func unaccented(cmd string) byte {
+	trimmed := strings.TrimRight(cmd, "}")
+	return trimmed[len(trimmed)-1]
+}
+
+// uniqueKey appends a, b, c... to keys that were already used, going on
+// with aa, ab... after z.
+func uniqueKey(used map[string]int, key string) string {
+	n := used[key]
+	used[key] = n + 1
+	if n == 0 {
+		return key
+	}
+	return key + keySuffix(n)
+}
+
+// keySuffix returns the n-th suffix, counting from 1: a to z, then aa, ab...
+func keySuffix(n int) string {
+	var suffix []byte
+	for ; n > 0; n = (n - 1) / 26 {
+		suffix = append([]byte{byte('a' + (n-1)%26)}, suffix...)
+	}
+	return string(suffix)
+}
@@ -0,0 +1,76 @@
+// Package citation renders books in machine-readable citation formats.
+package citation
+
+import (
+	"errors"
+	"io"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+// Format identifies a citation format.
+type Format string
+
+const (
+	FormatBibTeX  Format = "bibtex"
+	FormatRIS     Format = "ris"
+	FormatCSLJSON Format = "csljson"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported citation format")
+
+// Write renders the books in the given format to w.
+func Write(format Format, w io.Writer, books []*domain.Book) error {
+	switch format {
+	case FormatBibTeX:
+		return writeBibTeX(w, books)
+	case FormatRIS:
+		return writeRIS(w, books)
+	case FormatCSLJSON:
+		return writeCSLJSON(w, books)
+	}
+	return ErrUnsupportedFormat
+}
+
+// IsSupported reports whether format is a known citation format.
+func IsSupported(format Format) bool {
+	switch format {
+	case FormatBibTeX, FormatRIS, FormatCSLJSON:
+		return true
+	}
+	return false
+}
+
+// ContentType returns the MIME type of the given format.
+func ContentType(format Format) string {
+	switch format {
+	case FormatBibTeX:
+		return "application/x-bibtex; charset=utf-8"
+	case FormatRIS:
+		return "application/x-research-info-systems; charset=utf-8"
+	case FormatCSLJSON:
+		return "application/vnd.citationstyles.csl+json"
+	}
+	return "text/plain; charset=utf-8"
+}
+
+// FileExtension returns the file extension of the given format.
+func FileExtension(format Format) string {
+	switch format {
+	case FormatBibTeX:
+		return "bib"
+	case FormatRIS:
+		return "ris"
+	case FormatCSLJSON:
+		return "json"
+	}
+	return "txt"
+}
+
+// fullTitle joins title and subtitle the way most citation styles expect.
+func fullTitle(book *domain.Book) string {
+	if book.Subtitle == "" {
+		return book.Title
+	}
+	return book.Title + ": " + book.Subtitle
+}
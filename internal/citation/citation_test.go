@@ -0,0 +1,269 @@
+package citation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+func casmurro() *domain.Book {
+	return &domain.Book{
+		ID:             "b1",
+		Title:          "Dom Casmurro",
+		Subtitle:       "romance",
+		Author:         "Machado de Assis",
+		Edition:        "2",
+		Publisher:      "Garnier & Cia",
+		Year:           1899,
+		Pages:          256,
+		Series:         "Clássicos",
+		SeriesPosition: 3,
+		ISBN13:         "9788535902778",
+		Description:    "Bentinho e\nCapitu.",
+		Tags:           []string{"romance", "realismo"},
+	}
+}
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Name
+	}{
+		{input: "Machado de Assis", want: Name{Given: "Machado de", Family: "Assis"}},
+		{input: "Assis, Machado de", want: Name{Given: "Machado de", Family: "Assis"}},
+		{input: "  Clarice   Lispector ", want: Name{Given: "Clarice", Family: "Lispector"}},
+		{input: "João Guimarães Rosa Filho", want: Name{Given: "João Guimarães", Family: "Rosa Filho"}},
+		{input: "Martin Luther King Jr.", want: Name{Given: "Martin Luther", Family: "King Jr."}},
+		// Com só duas palavras, o sufixo não tem nome a que se juntar.
+		{input: "Paulo Neto", want: Name{Given: "Paulo", Family: "Neto"}},
+		{input: "Platão", want: Name{Family: "Platão"}},
+		{input: "", want: Name{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := ParseName(tt.input); got != tt.want {
+				t.Errorf("ParseName(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeLaTeX(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "Ação & 100%", want: `A{\c{c}}{\~a}o \& 100\%`},
+		{input: "a_b #1 $2", want: `a\_b \#1 \$2`},
+		{input: `{x}\~`, want: `\{x\}\textbackslash{}\textasciitilde{}`},
+		{input: "Über Øl", want: `{\"U}ber {\O}l`},
+		{input: "plain", want: "plain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := EscapeLaTeX(tt.input); got != tt.want {
+				t.Errorf("EscapeLaTeX(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCitationKey(t *testing.T) {
+	tests := []struct {
+		name string
+		book domain.Book
+		want string
+	}{
+		{name: "author year and title", book: domain.Book{Author: "Machado de Assis", Year: 1899, Title: "Dom Casmurro"}, want: "assis1899_casmurro"},
+		{name: "accents dropped", book: domain.Book{Author: "José Saramago", Year: 1995, Title: "Ensaio sobre a cegueira"}, want: "saramago1995_ensaio"},
+		{name: "no year", book: domain.Book{Author: "Clarice Lispector", Title: "A hora da estrela"}, want: "lispector_hora"},
+		{name: "no author", book: domain.Book{Title: "Os Lusíadas"}, want: "lusiadas"},
+		{name: "no short title words", book: domain.Book{Author: "Italo Calvino", Year: 1972, Title: "As"}, want: "calvino1972"},
+		{name: "nothing to go on", book: domain.Book{ID: "a-1"}, want: "book_a1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := citationKey(&tt.book); got != tt.want {
+				t.Errorf("citationKey = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUniqueKey(t *testing.T) {
+	used := make(map[string]int)
+	var got []string
+	for _, key := range []string{"k", "k", "other", "k"} {
+		got = append(got, uniqueKey(used, key))
+	}
+	want := []string{"k", "ka", "other", "kb"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("uniqueKey = %v, want %v", got, want)
+	}
+}
+
+func TestKeySuffix(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{n: 1, want: "a"},
+		{n: 26, want: "z"},
+		// Depois do z a contagem continua, sem repetir chaves.
+		{n: 27, want: "aa"},
+		{n: 28, want: "ab"},
+		{n: 52, want: "az"},
+		{n: 53, want: "ba"},
+		{n: 702, want: "zz"},
+		{n: 703, want: "aaa"},
+	}
+	for _, tt := range tests {
+		if got := keySuffix(tt.n); got != tt.want {
+			t.Errorf("keySuffix(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+
+	used := make(map[string]int)
+	seen := make(map[string]bool)
+	for i := 0; i < 800; i++ {
+		key := uniqueKey(used, "k")
+		if seen[key] {
+			t.Fatalf("uniqueKey repeated %q after %d keys", key, i)
+		}
+		seen[key] = true
+	}
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		format Format
+		books  []*domain.Book
+		want   string
+	}{
+		{
+			format: FormatBibTeX,
+			books:  []*domain.Book{casmurro()},
+			want: `@book{assis1899_casmurro,
+  author    = {Assis, Machado de},
+  title     = {{Dom Casmurro}},
+  subtitle  = {romance},
+  edition   = {2},
+  publisher = {Garnier \& Cia},
+  year      = {1899},
+  pagetotal = {256},
+  series    = {Cl{\'a}ssicos},
+  number    = {3},
+  isbn      = {9788535902778},
+  keywords  = {romance, realismo}
+}
+`,
+		},
+		{
+			format: FormatBibTeX,
+			books:  []*domain.Book{{ID: "b2", Title: "Memórias", Author: "Machado de Assis", Year: 1899}, {ID: "b3", Title: "Memórias", Author: "Machado de Assis", Year: 1899}},
+			want: `@book{assis1899_memorias,
+  author    = {Assis, Machado de},
+  title     = {{Mem{\'o}rias}},
+  year      = {1899}
+}
+
+@book{assis1899_memoriasa,
+  author    = {Assis, Machado de},
+  title     = {{Mem{\'o}rias}},
+  year      = {1899}
+}
+`,
+		},
+		{
+			format: FormatRIS,
+			books:  []*domain.Book{casmurro()},
+			want: "TY  - BOOK\r\nID  - b1\r\nAU  - Assis, Machado de\r\nTI  - Dom Casmurro: romance\r\nET  - 2\r\n" +
+				"PB  - Garnier & Cia\r\nPY  - 1899\r\nSP  - 256\r\nT3  - Clássicos\r\nVL  - 3\r\nSN  - 9788535902778\r\n" +
+				"AB  - Bentinho e Capitu.\r\nKW  - romance\r\nKW  - realismo\r\nER  - \r\n",
+		},
+		{
+			format: FormatRIS,
+			books:  []*domain.Book{{ID: "b4", Title: "Apologia", Author: "Platão"}},
+			want:   "TY  - BOOK\r\nID  - b4\r\nAU  - Platão\r\nTI  - Apologia\r\nER  - \r\n",
+		},
+		{
+			format: FormatCSLJSON,
+			books:  []*domain.Book{},
+			want:   "[]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(tt.format, &buf, tt.books); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Write =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteCSLJSON(t *testing.T) {
+	platao := &domain.Book{ID: "b4", Title: "Apologia", Author: "Platão"}
+	var buf bytes.Buffer
+	if err := Write(FormatCSLJSON, &buf, []*domain.Book{casmurro(), platao}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var items []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &items); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+
+	want := map[string]interface{}{
+		"id":                "b1",
+		"type":              "book",
+		"title":             "Dom Casmurro: romance",
+		"author":            []interface{}{map[string]interface{}{"family": "Assis", "given": "Machado de"}},
+		"edition":           "2",
+		"publisher":         "Garnier & Cia",
+		"issued":            map[string]interface{}{"date-parts": []interface{}{[]interface{}{1899.0}}},
+		"number-of-pages":   "256",
+		"collection-title":  "Clássicos",
+		"collection-number": "3",
+		"ISBN":              "9788535902778",
+		"abstract":          "Bentinho e\nCapitu.",
+		"keyword":           "romance, realismo",
+	}
+	got, _ := json.Marshal(items[0])
+	wantJSON, _ := json.Marshal(want)
+	if string(got) != string(wantJSON) {
+		t.Errorf("item = %s, want %s", got, wantJSON)
+	}
+
+	// Autores sem prenome vão como nome literal.
+	got, _ = json.Marshal(items[1])
+	if wantJSON := `{"author":[{"literal":"Platão"}],"id":"b4","title":"Apologia","type":"book"}`; string(got) != wantJSON {
+		t.Errorf("item = %s, want %s", got, wantJSON)
+	}
+	if strings.Contains(buf.String(), `\u0026`) {
+		t.Errorf("output escapes HTML characters:\n%s", buf.String())
+	}
+}
+
+func TestWriteUnsupported(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write("endnote", &buf, []*domain.Book{casmurro()}); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Write error = %v, want %v", err, ErrUnsupportedFormat)
+	}
+	if IsSupported("endnote") || !IsSupported(FormatRIS) {
+		t.Error("IsSupported does not match the formats Write accepts")
+	}
+	if FileExtension(FormatBibTeX) != "bib" || FileExtension("endnote") != "txt" {
+		t.Error("unexpected file extensions")
+	}
+}
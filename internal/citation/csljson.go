@@ -0,0 +1,83 @@
+package citation
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+// cslItem is a CSL-JSON item as consumed by citeproc processors, Zotero and Pandoc.
+type cslItem struct {
+	ID               string    `json:"id"`
+	Type             string    `json:"type"`
+	Title            string    `json:"title,omitempty"`
+	Author           []cslName `json:"author,omitempty"`
+	Edition          string    `json:"edition,omitempty"`
+	Publisher        string    `json:"publisher,omitempty"`
+	Issued           *cslDate  `json:"issued,omitempty"`
+	NumberOfPages    string    `json:"number-of-pages,omitempty"`
+	CollectionTitle  string    `json:"collection-title,omitempty"`
+	CollectionNumber string    `json:"collection-number,omitempty"`
+	ISBN             string    `json:"ISBN,omitempty"`
+	Abstract         string    `json:"abstract,omitempty"`
+	Keyword          string    `json:"keyword,omitempty"`
+}
+
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+type cslName struct {
+	Family  string `json:"family,omitempty"`
+	Given   string `json:"given,omitempty"`
+	Literal string `json:"literal,omitempty"`
+}
+
+func writeCSLJSON(w io.Writer, books []*domain.Book) error {
+	items := make([]cslItem, 0, len(books))
+	for _, book := range books {
+		items = append(items, cslItemFromBook(book))
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(items)
+}
+
+func cslItemFromBook(book *domain.Book) cslItem {
+	item := cslItem{
+		ID:              book.ID,
+		Type:            "book",
+		Title:           fullTitle(book),
+		Edition:         book.Edition,
+		Publisher:       book.Publisher,
+		CollectionTitle: book.Series,
+		ISBN:            book.ISBN13,
+		Abstract:        book.Description,
+	}
+	for _, name := range Authors(book) {
+		if name.Given == "" {
+			item.Author = append(item.Author, cslName{Literal: name.Family})
+			continue
+		}
+		item.Author = append(item.Author, cslName{Family: name.Family, Given: name.Given})
+	}
+	if book.Year > 0 {
+		item.Issued = &cslDate{DateParts: [][]int{{book.Year}}}
+	}
+	if book.Pages > 0 {
+		item.NumberOfPages = strconv.Itoa(book.Pages)
+	}
+	if book.Series != "" && book.SeriesPosition > 0 {
+		item.CollectionNumber = strconv.FormatFloat(book.SeriesPosition, 'f', -1, 64)
+	}
+	for i, tag := range book.Tags {
+		if i > 0 {
+			item.Keyword += ", "
+		}
+		item.Keyword += tag
+	}
+	return item
+}
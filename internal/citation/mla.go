@@ -0,0 +1,67 @@
+package citation
+
+import (
+	"strconv"
+	"strings"
+)
+
+// mla follows the 9th edition of the MLA Handbook:
+// "Family, Given. Title: Subtitle. 2nd ed., Publisher, Year."
+type mla struct{}
+
+func (mla) Name() string { return "mla" }
+
+func (mla) Format(ref Reference, m Markup) string {
+	var parts []string
+
+	if authors := mlaAuthors(ref.Authors); authors != "" {
+		parts = append(parts, m.Text(terminate(authors)))
+	}
+
+	title := ref.Title
+	if ref.Subtitle != "" {
+		title += ": " + capitalizeFirst(ref.Subtitle)
+	}
+	parts = append(parts, m.Italic(title)+".")
+
+	var publication []string
+	if edition := englishEdition(ref.Edition); edition != "" {
+		publication = append(publication, edition)
+	}
+	if ref.Publisher != "" {
+		publication = append(publication, ref.Publisher)
+	}
+	if ref.Year > 0 {
+		publication = append(publication, strconv.Itoa(ref.Year))
+	}
+	if len(publication) > 0 {
+		parts = append(parts, m.Text(terminate(strings.Join(publication, ", "))))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// mlaAuthors inverts only the first author's name; two authors are joined
+// with "and", and three or more are shortened to "et al.".
+func mlaAuthors(names []Name) string {
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return mlaInverted(names[0])
+	case 2:
+		return mlaInverted(names[0]) + ", and " + mlaDirect(names[1])
+	}
+	return mlaInverted(names[0]) + ", et al."
+}
+
+func mlaInverted(n Name) string {
+	if n.Given == "" {
+		return n.Family
+	}
+	return n.Family + ", " + n.Given
+}
+
+func mlaDirect(n Name) string {
+	return strings.TrimSpace(n.Given + " " + n.Family)
+}
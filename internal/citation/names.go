@@ -0,0 +1,54 @@
+package citation
+
+import (
+	"strings"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+// Name is a personal name split into its given and family parts.
+type Name struct {
+	Given  string
+	Family string
+}
+
+// generationalSuffixes are kept with the family name, as in "ASSIS FILHO".
+var generationalSuffixes = map[string]bool{
+	"filho": true, "filha": true, "neto": true, "neta": true, "sobrinho": true,
+	"júnior": true, "junior": true, "jr": true, "jr.": true,
+}
+
+// ParseName splits a name written either as "Given Family" or as
+// "Family, Given". Generational suffixes stay with the family name.
+func ParseName(name string) Name {
+	name = strings.Join(strings.Fields(name), " ")
+	if family, given, ok := strings.Cut(name, ","); ok {
+		return Name{Given: strings.TrimSpace(given), Family: strings.TrimSpace(family)}
+	}
+
+	words := strings.Fields(name)
+	switch {
+	case len(words) == 0:
+		return Name{}
+	case len(words) == 1:
+		return Name{Family: words[0]}
+	case len(words) > 2 && generationalSuffixes[strings.ToLower(words[len(words)-1])]:
+		return Name{
+			Given:  strings.Join(words[:len(words)-2], " "),
+			Family: strings.Join(words[len(words)-2:], " "),
+		}
+	}
+	return Name{
+		Given:  strings.Join(words[:len(words)-1], " "),
+		Family: words[len(words)-1],
+	}
+}
+
+// Authors returns the parsed author names of a book, in display order.
+func Authors(book *domain.Book) []Name {
+	var names []Name
+	for _, a := range book.AuthorNames() {
+		names = append(names, ParseName(a))
+	}
+	return names
+}
@@ -0,0 +1,64 @@
+package citation
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+func writeRIS(w io.Writer, books []*domain.Book) error {
+	for _, book := range books {
+		if _, err := io.WriteString(w, risEntry(book)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func risEntry(book *domain.Book) string {
+	var b strings.Builder
+	tag := func(name, value string) {
+		if value = risValue(value); value != "" {
+			fmt.Fprintf(&b, "%s  - %s\r\n", name, value)
+		}
+	}
+
+	tag("TY", "BOOK")
+	tag("ID", book.ID)
+	for _, name := range Authors(book) {
+		if name.Given == "" {
+			tag("AU", name.Family)
+			continue
+		}
+		tag("AU", name.Family+", "+name.Given)
+	}
+	tag("TI", fullTitle(book))
+	tag("ET", book.Edition)
+	tag("PB", book.Publisher)
+	if book.Year > 0 {
+		tag("PY", strconv.Itoa(book.Year))
+	}
+	if book.Pages > 0 {
+		// O RIS não tem campo de total de páginas; o Zotero e o Mendeley usam SP em livros.
+		tag("SP", strconv.Itoa(book.Pages))
+	}
+	tag("T3", book.Series)
+	if book.Series != "" && book.SeriesPosition > 0 {
+		tag("VL", strconv.FormatFloat(book.SeriesPosition, 'f', -1, 64))
+	}
+	tag("SN", book.ISBN13)
+	tag("AB", book.Description)
+	for _, t := range book.Tags {
+		tag("KW", t)
+	}
+	b.WriteString("ER  - \r\n")
+	return b.String()
+}
+
+// risValue keeps a value on a single line, as RIS tags cannot span lines.
+func risValue(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,175 @@
+package citation
+
+import (
+	"errors"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var ErrUnknownStyle = errors.New("unknown reference style")
+
+// Reference holds the bibliographic data used by reference styles.
+type Reference struct {
+	Authors   []Name
+	Title     string
+	Subtitle  string
+	Edition   string
+	Publisher string
+	Year      int
+}
+
+// NewReference builds a reference from a book.
+func NewReference(book *domain.Book) Reference {
+	return Reference{
+		Authors:   Authors(book),
+		Title:     strings.TrimSpace(book.Title),
+		Subtitle:  strings.TrimSpace(book.Subtitle),
+		Edition:   strings.TrimSpace(book.Edition),
+		Publisher: strings.TrimSpace(book.Publisher),
+		Year:      book.Year,
+	}
+}
+
+// Markup decides how emphasis and text are written, so the same style can
+// produce plain text or HTML.
+type Markup interface {
+	Text(s string) string
+	Italic(s string) string
+}
+
+// PlainText writes references without any formatting.
+type PlainText struct{}
+
+func (PlainText) Text(s string) string   { return s }
+func (PlainText) Italic(s string) string { return s }
+
+// HTML escapes text and writes emphasis with <i> elements.
+type HTML struct{}
+
+func (HTML) Text(s string) string   { return html.EscapeString(s) }
+func (HTML) Italic(s string) string { return "<i>" + html.EscapeString(s) + "</i>" }
+
+// Style formats a reference according to a citation style guide.
+type Style interface {
+	Name() string
+	Format(ref Reference, m Markup) string
+}
+
+var (
+	stylesMu sync.RWMutex
+	styles   = make(map[string]Style)
+)
+
+// RegisterStyle makes a style available by name. Registering a style with
+// an existing name replaces it.
+func RegisterStyle(style Style) {
+	stylesMu.Lock()
+	defer stylesMu.Unlock()
+	styles[strings.ToLower(style.Name())] = style
+}
+
+// LookupStyle returns the style registered under name.
+func LookupStyle(name string) (Style, error) {
+	stylesMu.RLock()
+	defer stylesMu.RUnlock()
+	style, ok := styles[strings.ToLower(name)]
+	if !ok {
+		return nil, ErrUnknownStyle
+	}
+	return style, nil
+}
+
+// StyleNames returns the names of every registered style, sorted.
+func StyleNames() []string {
+	stylesMu.RLock()
+	defer stylesMu.RUnlock()
+	names := make([]string, 0, len(styles))
+	for name := range styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterStyle(abnt{})
+	RegisterStyle(apa{})
+	RegisterStyle(mla{})
+}
+
+var leadingNumber = regexp.MustCompile(`^\d+`)
+
+// editionNumber returns the number at the start of an edition statement,
+// such as "2", "2nd ed." or "2ª edição", or 0 when it is free text.
+func editionNumber(edition string) int {
+	n, _ := strconv.Atoi(leadingNumber.FindString(strings.TrimSpace(edition)))
+	return n
+}
+
+// ordinal returns the English ordinal of n, such as "2nd" or "11th".
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 100 {
+	case 11, 12, 13:
+	default:
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// englishEdition formats an edition statement as "2nd ed.", or returns an
+// empty string for first editions.
+func englishEdition(edition string) string {
+	if edition == "" {
+		return ""
+	}
+	n := editionNumber(edition)
+	switch {
+	case n == 1:
+		return ""
+	case n > 1:
+		return ordinal(n) + " ed."
+	}
+	return withEditionWord(edition)
+}
+
+// withEditionWord appends "ed." to free-text editions such as "Revised",
+// unless they already mention the edition.
+func withEditionWord(edition string) string {
+	for _, word := range strings.Fields(strings.ToLower(edition)) {
+		switch strings.Trim(word, ".,") {
+		case "ed", "edition", "edição", "edicao":
+			return terminate(edition)
+		}
+	}
+	return edition + " ed."
+}
+
+// capitalizeFirst upper-cases the first letter of s.
+func capitalizeFirst(s string) string {
+	for i, r := range s {
+		return strings.ToUpper(string(r)) + s[i+len(string(r)):]
+	}
+	return s
+}
+
+// terminate appends a period unless s already ends with punctuation.
+func terminate(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") || strings.HasSuffix(s, "?") || strings.HasSuffix(s, "!") {
+		return s
+	}
+	return s + "."
+}
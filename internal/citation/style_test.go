@@ -0,0 +1,132 @@
+package citation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStyles(t *testing.T) {
+	assis := Name{Given: "Machado de", Family: "Assis"}
+	rosa := Name{Given: "João Guimarães", Family: "Rosa"}
+	sartre := Name{Given: "Jean-Paul", Family: "Sartre"}
+	platao := Name{Family: "Platão"}
+	casmurro := Reference{
+		Authors:   []Name{assis},
+		Title:     "Dom Casmurro",
+		Subtitle:  "romance",
+		Edition:   "2",
+		Publisher: "Garnier & Cia",
+		Year:      1899,
+	}
+	with := func(change func(ref *Reference)) Reference {
+		ref := casmurro
+		change(&ref)
+		return ref
+	}
+
+	tests := []struct {
+		name   string
+		style  string
+		ref    Reference
+		markup Markup
+		want   string
+	}{
+		{name: "abnt", style: "abnt", ref: casmurro, markup: PlainText{},
+			want: "ASSIS, Machado de. Dom Casmurro: romance. 2. ed. [S. l.]: Garnier & Cia, 1899."},
+		{name: "abnt html", style: "abnt", ref: casmurro, markup: HTML{},
+			want: "ASSIS, Machado de. <i>Dom Casmurro</i>: romance. 2. ed. [S. l.]: Garnier &amp; Cia, 1899."},
+		{name: "abnt without author, publisher or year", style: "abnt", markup: HTML{},
+			ref:  Reference{Title: "memórias póstumas", Edition: "1"},
+			want: "MEMÓRIAS póstumas. [S. l.]: [s. n.], [s. d.]."},
+		{name: "abnt several authors", style: "abnt", markup: PlainText{},
+			ref:  with(func(r *Reference) { r.Authors = []Name{assis, rosa, platao}; r.Edition = "Revista" }),
+			want: "ASSIS, Machado de; ROSA, João Guimarães; PLATÃO. Dom Casmurro: romance. Revista ed. [S. l.]: Garnier & Cia, 1899."},
+		{name: "abnt more than three authors", style: "abnt", markup: PlainText{},
+			ref:  with(func(r *Reference) { r.Authors = []Name{assis, rosa, sartre, platao}; r.Edition = "" }),
+			want: "ASSIS, Machado de et al. Dom Casmurro: romance. [S. l.]: Garnier & Cia, 1899."},
+
+		{name: "apa", style: "apa", ref: casmurro, markup: PlainText{},
+			want: "Assis, M. (1899). Dom Casmurro: Romance (2nd ed.). Garnier & Cia."},
+		{name: "apa html", style: "apa", ref: casmurro, markup: HTML{},
+			want: "Assis, M. (1899). <i>Dom Casmurro: Romance</i> (2nd ed.). Garnier &amp; Cia."},
+		{name: "apa without author, publisher or year", style: "apa", markup: PlainText{},
+			ref:  Reference{Title: "Beowulf", Edition: "Revised"},
+			want: "Beowulf (Revised ed.). (n.d.)."},
+		{name: "apa two authors", style: "apa", markup: PlainText{},
+			ref:  with(func(r *Reference) { r.Authors = []Name{assis, rosa}; r.Edition = "11" }),
+			want: "Assis, M., & Rosa, J. G. (1899). Dom Casmurro: Romance (11th ed.). Garnier & Cia."},
+		{name: "apa three authors", style: "apa", markup: PlainText{},
+			ref:  with(func(r *Reference) { r.Authors = []Name{assis, sartre, platao}; r.Edition = "1st ed." }),
+			want: "Assis, M., Sartre, J.-P., & Platão. (1899). Dom Casmurro: Romance. Garnier & Cia."},
+
+		{name: "mla", style: "mla", ref: casmurro, markup: PlainText{},
+			want: "Assis, Machado de. Dom Casmurro: Romance. 2nd ed., Garnier & Cia, 1899."},
+		{name: "mla html", style: "mla", ref: casmurro, markup: HTML{},
+			want: "Assis, Machado de. <i>Dom Casmurro: Romance</i>. 2nd ed., Garnier &amp; Cia, 1899."},
+		{name: "mla two authors", style: "mla", markup: PlainText{},
+			ref:  with(func(r *Reference) { r.Authors = []Name{assis, rosa}; r.Edition = "3rd edition" }),
+			want: "Assis, Machado de, and João Guimarães Rosa. Dom Casmurro: Romance. 3rd ed., Garnier & Cia, 1899."},
+		{name: "mla three authors", style: "mla", markup: PlainText{},
+			ref:  with(func(r *Reference) { r.Authors = []Name{assis, rosa, sartre} }),
+			want: "Assis, Machado de, et al. Dom Casmurro: Romance. 2nd ed., Garnier & Cia, 1899."},
+		{name: "mla title only", style: "mla", markup: PlainText{},
+			ref:  Reference{Title: "Beowulf"},
+			want: "Beowulf."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style, err := LookupStyle(tt.style)
+			if err != nil {
+				t.Fatalf("LookupStyle(%q): %v", tt.style, err)
+			}
+			if got := style.Format(tt.ref, tt.markup); got != tt.want {
+				t.Errorf("Format =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupStyle(t *testing.T) {
+	if style, err := LookupStyle("APA"); err != nil || style.Name() != "apa" {
+		t.Errorf("LookupStyle(APA) = %v, %v; want the apa style", style, err)
+	}
+	if _, err := LookupStyle("chicago"); !errors.Is(err, ErrUnknownStyle) {
+		t.Errorf("LookupStyle(chicago) error = %v, want %v", err, ErrUnknownStyle)
+	}
+	if got := strings.Join(StyleNames(), " "); got != "abnt apa mla" {
+		t.Errorf("StyleNames = %q, want %q", got, "abnt apa mla")
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	tests := map[int]string{
+		1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th",
+		21: "21st", 22: "22nd", 101: "101st", 111: "111th",
+	}
+	for n, want := range tests {
+		if got := ordinal(n); got != want {
+			t.Errorf("ordinal(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestInitials(t *testing.T) {
+	tests := []struct {
+		given string
+		want  string
+	}{
+		{given: "Machado de", want: "M."},
+		{given: "João Guimarães", want: "J. G."},
+		{given: "Jean-Paul", want: "J.-P."},
+		{given: "Émile", want: "É."},
+		{given: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			if got := initials(tt.given); got != tt.want {
+				t.Errorf("initials(%q) = %q, want %q", tt.given, got, tt.want)
+			}
+		})
+	}
+}
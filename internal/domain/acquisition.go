@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// Ways a copy can be acquired.
+const (
+	AcquiredPurchase = "purchase"
+	AcquiredGift     = "gift"
+	AcquiredTrade    = "trade"
+)
+
+// AcquisitionMethods lists the ways a copy can be acquired.
+var AcquisitionMethods = []string{AcquiredPurchase, AcquiredGift, AcquiredTrade}
+
+// IsAcquisitionMethod reports whether method is one of AcquisitionMethods.
+func IsAcquisitionMethod(method string) bool {
+	for _, m := range AcquisitionMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// Acquisition tells how and when a copy was acquired and, for purchases,
+// where and for how much. Currency is an ISO 4217 code such as "BRL".
+type Acquisition struct {
+	Method   string     `json:"method" bson:"method"`
+	Date     *time.Time `json:"date,omitempty" bson:"date,omitempty"`
+	Store    string     `json:"store,omitempty" bson:"store,omitempty"`
+	Price    float64    `json:"price,omitempty" bson:"price,omitempty"`
+	Currency string     `json:"currency,omitempty" bson:"currency,omitempty"`
+}
+
+// ExchangeRate is the value of one unit of a currency in the base currency
+// of the collection reports. Rates are kept by hand.
+type ExchangeRate struct {
+	Currency  string    `json:"currency" bson:"_id"`
+	Rate      float64   `json:"rate" bson:"rate"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// SpendingTotal is the amount spent on purchases in a year, at a store and
+// in a currency. Year is zero for purchases without a date.
+type SpendingTotal struct {
+	Year     int     `json:"year" bson:"year"`
+	Store    string  `json:"store" bson:"store"`
+	Currency string  `json:"currency" bson:"currency"`
+	Amount   float64 `json:"amount" bson:"amount"`
+	Count    int     `json:"count" bson:"count"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// Attachment is a file kept alongside a book, such as the EPUB it was
+// created from. The content lives in the blob store.
+type Attachment struct {
+	ID          string `json:"id" bson:"id"`
+	Name        string `json:"name" bson:"name"`
+	ContentType string `json:"content_type" bson:"content_type"`
+	Size        int64  `json:"size" bson:"size"`
+	// Metadata holds technical details of the file, such as the
+	// application that produced a PDF.
+	Metadata  map[string]string `json:"metadata,omitempty" bson:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// Audit session statuses.
+const (
+	AuditOpen   = "open"
+	AuditClosed = "closed"
+)
+
+// Outcomes of a scanned code.
+const (
+	ScanFound     = "found"
+	ScanMisplaced = "misplaced"
+	ScanUnknown   = "unknown"
+	ScanDuplicate = "duplicate"
+)
+
+// AuditSession checks the copies kept in a location against the catalogue. Codes
+// are scanned while the session is open, possibly over several days, and
+// closing it stores the report.
+type AuditSession struct {
+	ID         string       `json:"id" bson:"_id,omitempty"`
+	LocationID string       `json:"location_id" bson:"location_id"`
+	Status     string       `json:"status" bson:"status"`
+	Notes      string       `json:"notes,omitempty" bson:"notes,omitempty"`
+	Scans      []AuditScan  `json:"scans" bson:"scans"`
+	Report     *AuditReport `json:"report,omitempty" bson:"report,omitempty"`
+	StartedAt  time.Time    `json:"started_at" bson:"started_at"`
+	ClosedAt   *time.Time   `json:"closed_at,omitempty" bson:"closed_at,omitempty"`
+}
+
+// AuditScan is a code scanned during an audit: a copy ID, an ISBN or a book
+// ID. BookID is empty when the code matched no book, and CopyID when the
+// book has no copy left to match.
+type AuditScan struct {
+	Code      string    `json:"code" bson:"code"`
+	BookID    string    `json:"book_id,omitempty" bson:"book_id,omitempty"`
+	CopyID    string    `json:"copy_id,omitempty" bson:"copy_id,omitempty"`
+	Result    string    `json:"result" bson:"result"`
+	ScannedAt time.Time `json:"scanned_at" bson:"scanned_at"`
+}
+
+// AuditReport compares the copies scanned during an audit with those the
+// catalogue places in the audited location or inside it.
+type AuditReport struct {
+	// Found are the copies scanned where the catalogue places them.
+	Found []AuditItem `json:"found"`
+	// Missing are the copies the catalogue places there that were not scanned.
+	Missing []AuditItem `json:"missing"`
+	// Misplaced are the copies scanned there that the catalogue places
+	// somewhere else or nowhere.
+	Misplaced []AuditItem `json:"misplaced"`
+	// Unknown are the scanned codes that matched no book.
+	Unknown []string `json:"unknown"`
+}
+
+// AuditItem is a copy of an audit report with the location the catalogue
+// gives it.
+type AuditItem struct {
+	CopyID   string `json:"copy_id,omitempty" bson:"copy_id,omitempty"`
+	BookID   string `json:"book_id" bson:"book_id"`
+	Title    string `json:"title" bson:"title"`
+	Author   string `json:"author,omitempty" bson:"author,omitempty"`
+	Location string `json:"location,omitempty" bson:"location,omitempty"`
+}
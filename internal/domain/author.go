@@ -0,0 +1,22 @@
+package domain
+
+// Author is a person credited in books. AlternateSpellings are other ways of
+// writing the same name, such as "Tolkien, J.R.R.", and Aliases are pen names.
+// Book contributors are linked to an author through Contributor.AuthorID.
+type Author struct {
+	ID                 string   `json:"id" bson:"_id,omitempty"`
+	Name               string   `json:"name" bson:"name"`
+	BirthYear          int      `json:"birth_year,omitempty" bson:"birth_year,omitempty"`
+	DeathYear          int      `json:"death_year,omitempty" bson:"death_year,omitempty"`
+	Nationality        string   `json:"nationality,omitempty" bson:"nationality,omitempty"`
+	AlternateSpellings []string `json:"alternate_spellings,omitempty" bson:"alternate_spellings,omitempty"`
+	Aliases            []string `json:"aliases,omitempty" bson:"aliases,omitempty"`
+}
+
+// Names returns every name the author is known by: the main name, the
+// alternate spellings and the aliases.
+func (a *Author) Names() []string {
+	names := []string{a.Name}
+	names = append(names, a.AlternateSpellings...)
+	return append(names, a.Aliases...)
+}
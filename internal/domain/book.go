@@ -1,11 +1,75 @@
 package domain
 
+// Book is an edition of a work: publisher, ISBN and page count describe the
+// edition, while the physical copies owned are kept apart as Copy records.
 type Book struct {
-	ID        string `json:"id" bson:"_id,omitempty"`
-	Title     string `json:"title" bson:"title"`
-	Subtitle  string `json:"subtitle" bson:"subtitle"`
-	Author    string `json:"author" bson:"author"`
-	Pages     int    `json:"pages" bson:"pages"`
-	Publisher string `json:"publisher" bson:"publisher"`
-	Comments  string `json:"comments" bson:"comments"`
+	ID string `json:"id" bson:"_id,omitempty"`
+	// WorkID links the edition to its work; editions saved without one are
+	// linked to the work with the same title and author, created if needed.
+	WorkID   string `json:"work_id,omitempty" bson:"work_id,omitempty"`
+	Title    string `json:"title" bson:"title"`
+	Subtitle string `json:"subtitle" bson:"subtitle"`
+	// Author is the authors' names, kept for compatibility and rebuilt from
+	// Contributors whenever they are given.
+	Author       string        `json:"author" bson:"author"`
+	Contributors []Contributor `json:"contributors,omitempty" bson:"contributors,omitempty"`
+	Pages        int           `json:"pages" bson:"pages"`
+	Publisher    string        `json:"publisher" bson:"publisher"`
+	Comments     string        `json:"comments" bson:"comments"`
+	Edition      string        `json:"edition,omitempty" bson:"edition,omitempty"`
+	Year         int           `json:"year,omitempty" bson:"year,omitempty"`
+	ISBN10       string        `json:"isbn10,omitempty" bson:"isbn10,omitempty"`
+	ISBN13       string        `json:"isbn13,omitempty" bson:"isbn13,omitempty"`
+	Language     string        `json:"language,omitempty" bson:"language,omitempty"`
+	Description  string        `json:"description,omitempty" bson:"description,omitempty"`
+	// Series is the series name, kept for compatibility and filled in from
+	// the series record when SeriesID is given.
+	Series         string            `json:"series,omitempty" bson:"series,omitempty"`
+	SeriesID       string            `json:"series_id,omitempty" bson:"series_id,omitempty"`
+	SeriesPosition float64           `json:"series_position,omitempty" bson:"series_position,omitempty"`
+	Tags           []string          `json:"tags,omitempty" bson:"tags,omitempty"`
+	Identifiers    map[string]string `json:"identifiers,omitempty" bson:"identifiers,omitempty"`
+	Cover          *Cover            `json:"cover,omitempty" bson:"cover,omitempty"`
+	Attachments    []Attachment      `json:"attachments,omitempty" bson:"attachments,omitempty"`
+	// Status is where the book stands in its lifecycle. It follows the loans
+	// and the disposal of the book and is never set by the request body.
+	Status   string    `json:"status,omitempty" bson:"status,omitempty"`
+	Disposal *Disposal `json:"disposal,omitempty" bson:"disposal,omitempty"`
+	// Availability is worked out from the loans when a single book is
+	// fetched and is never stored.
+	Availability *Availability `json:"availability,omitempty" bson:"-"`
+}
+
+// Well-known keys of Book.Identifiers.
+const (
+	IdentifierCalibreUUID = "calibre_uuid"
+	IdentifierOpenLibrary = "openlibrary"
+	// IdentifierMARCControlNumber is the 001 field of the MARC record the book was imported from.
+	IdentifierMARCControlNumber = "marc_control_number"
+)
+
+// BookFilter narrows book listings. Empty fields are ignored; text fields
+// match case-insensitively anywhere in the value.
+type BookFilter struct {
+	Title     string
+	Author    string
+	Publisher string
+	Series    string
+	Tag       string
+	// Contributor matches the name of any contributor, narrowed to Role when
+	// it is also given.
+	Contributor string
+	Role        string
+	// AuthorID matches the books crediting the given author record.
+	AuthorID string
+	SeriesID string
+	WorkID   string
+	// Status matches the books in the given status, or in any status when it
+	// is BookStatusAll. Without it, only the books in the library match.
+	Status string
+}
+
+// IsEmpty reports whether the filter has no criteria.
+func (f BookFilter) IsEmpty() bool {
+	return f == BookFilter{}
 }
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// Contributor roles.
+const (
+	RoleAuthor      = "author"
+	RoleTranslator  = "translator"
+	RoleEditor      = "editor"
+	RoleIllustrator = "illustrator"
+	RoleForeword    = "foreword"
+)
+
+// ContributorRoles lists the accepted roles.
+var ContributorRoles = []string{RoleAuthor, RoleTranslator, RoleEditor, RoleIllustrator, RoleForeword}
+
+// Contributor is a person credited in a book. Order is the position of the
+// person in the credits, starting at 1. Name is the name as printed in the
+// book, while AuthorID links the person to an Author record.
+type Contributor struct {
+	Name     string `json:"name" bson:"name"`
+	Role     string `json:"role" bson:"role"`
+	Order    int    `json:"order" bson:"order"`
+	AuthorID string `json:"author_id,omitempty" bson:"author_id,omitempty"`
+}
+
+// IsContributorRole reports whether role is one of ContributorRoles.
+func IsContributorRole(role string) bool {
+	for _, r := range ContributorRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// relatorRoles maps MARC relator codes and terms, also used by OPF files,
+// to contributor roles.
+var relatorRoles = map[string]string{
+	"aut": RoleAuthor, "author": RoleAuthor,
+	"trl": RoleTranslator, "translator": RoleTranslator,
+	"edt": RoleEditor, "editor": RoleEditor,
+	"ill": RoleIllustrator, "illustrator": RoleIllustrator,
+	"aui": RoleForeword, "author of introduction": RoleForeword,
+	"win": RoleForeword, "writer of introduction": RoleForeword,
+	"wpr": RoleForeword, "writer of preface": RoleForeword,
+	"wfw": RoleForeword, "writer of foreword": RoleForeword,
+}
+
+// RoleFromRelator returns the role matching a MARC relator code such as
+// "trl" or term such as "translator.". Relators without a matching role,
+// like "bkd" (book designer), report false.
+func RoleFromRelator(relator string) (string, bool) {
+	relator = strings.ToLower(strings.TrimRight(strings.TrimSpace(relator), " .,"))
+	role, ok := relatorRoles[relator]
+	return role, ok
+}
+
+// SplitAuthorNames splits a free-text author field into individual names.
+// Names are separated by ";", "&", " and " or " e "; commas only separate
+// names when every part looks like a full name, so "Assis, Machado de" is
+// kept as a single inverted name.
+func SplitAuthorNames(author string) []string {
+	author = strings.TrimSpace(author)
+	if author == "" {
+		return nil
+	}
+
+	replacer := strings.NewReplacer(";", "\x00", "&", "\x00", " and ", "\x00", " e ", "\x00")
+	parts := strings.Split(replacer.Replace(author), "\x00")
+	if len(parts) == 1 {
+		commaParts := strings.Split(author, ",")
+		allFull := len(commaParts) > 1
+		for _, p := range commaParts {
+			if !strings.Contains(strings.TrimSpace(p), " ") {
+				allFull = false
+				break
+			}
+		}
+		if allFull {
+			parts = commaParts
+		}
+	}
+
+	var names []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			names = append(names, p)
+		}
+	}
+	return names
+}
+
+// JoinAuthorNames builds the free-text author field from individual names.
+// Names are joined with ", ", or with "; " when one of them is inverted, so
+// the result splits back into the same names.
+func JoinAuthorNames(names []string) string {
+	sep := ", "
+	for _, name := range names {
+		if strings.Contains(name, ",") {
+			sep = "; "
+			break
+		}
+	}
+	return strings.Join(names, sep)
+}
+
+// ContributorsFromAuthor builds the author credits of a free-text author field.
+func ContributorsFromAuthor(author string) []Contributor {
+	var contributors []Contributor
+	for i, name := range SplitAuthorNames(author) {
+		contributors = append(contributors, Contributor{Name: name, Role: RoleAuthor, Order: i + 1})
+	}
+	return contributors
+}
+
+// AuthorNames returns the names of the book's authors in display order. Books
+// without contributors fall back to splitting the Author field.
+func (b *Book) AuthorNames() []string {
+	if len(b.Contributors) == 0 {
+		return SplitAuthorNames(b.Author)
+	}
+	var names []string
+	for _, c := range b.Contributors {
+		if c.Role == RoleAuthor {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// SyncContributors keeps Contributors and Author consistent. Without
+// contributors, they are built from Author; otherwise they are sorted by
+// Order and renumbered from 1. When the list credits no author, as when
+// only a translator is given, the author credits are built from Author and
+// placed first; otherwise Author is rebuilt from the authors' names.
+func (b *Book) SyncContributors() {
+	if len(b.Contributors) == 0 {
+		b.Contributors = ContributorsFromAuthor(b.Author)
+		return
+	}
+	sort.SliceStable(b.Contributors, func(i, j int) bool {
+		return b.Contributors[i].Order < b.Contributors[j].Order
+	})
+	for i := range b.Contributors {
+		b.Contributors[i].Name = strings.Join(strings.Fields(b.Contributors[i].Name), " ")
+		b.Contributors[i].Order = i + 1
+	}
+	if names := b.AuthorNames(); len(names) > 0 {
+		b.Author = JoinAuthorNames(names)
+		return
+	}
+	b.Contributors = ReplaceAuthors(b.Contributors, b.Author)
+}
+
+// ReplaceAuthors returns contributors with the author credits replaced by
+// those parsed from author. Other roles are kept, after the authors, and
+// authors whose names did not change keep their link to an Author record.
+func ReplaceAuthors(contributors []Contributor, author string) []Contributor {
+	result := ContributorsFromAuthor(author)
+	for i := range result {
+		for _, c := range contributors {
+			if c.Role == RoleAuthor && strings.EqualFold(c.Name, result[i].Name) {
+				result[i].AuthorID = c.AuthorID
+				break
+			}
+		}
+	}
+	for _, c := range contributors {
+		if c.Role != RoleAuthor {
+			c.Order = len(result) + 1
+			result = append(result, c)
+		}
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSplitAuthorNames(t *testing.T) {
+	tests := []struct {
+		author string
+		want   []string
+	}{
+		{author: "Machado de Assis", want: []string{"Machado de Assis"}},
+		{author: "Assis, Machado de", want: []string{"Assis, Machado de"}},
+		{author: "Neil Gaiman & Terry Pratchett", want: []string{"Neil Gaiman", "Terry Pratchett"}},
+		{author: "Jorge Amado e Zélia Gattai", want: []string{"Jorge Amado", "Zélia Gattai"}},
+		{author: "Machado de Assis, José de Alencar", want: []string{"Machado de Assis", "José de Alencar"}},
+		{author: "Assis, Machado de; Alencar, José de", want: []string{"Assis, Machado de", "Alencar, José de"}},
+		{author: "  ", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.author, func(t *testing.T) {
+			if got := SplitAuthorNames(tt.author); fmt.Sprintf("%q", got) != fmt.Sprintf("%q", tt.want) {
+				t.Errorf("SplitAuthorNames(%q) = %q, want %q", tt.author, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinAuthorNamesRoundTrip(t *testing.T) {
+	tests := [][]string{
+		{"Machado de Assis"},
+		{"Machado de Assis", "José de Alencar"},
+		// Com um nome invertido, a vírgula não pode separar os autores.
+		{"Assis, Machado de", "José de Alencar"},
+		{"Assis, Machado de", "Alencar, José de"},
+	}
+	for _, names := range tests {
+		author := JoinAuthorNames(names)
+		if got := SplitAuthorNames(author); strings.Join(got, "|") != strings.Join(names, "|") {
+			t.Errorf("SplitAuthorNames(JoinAuthorNames(%q)) = %q via %q", names, got, author)
+		}
+	}
+}
+
+func TestReplaceAuthors(t *testing.T) {
+	contributors := []Contributor{
+		{Name: "Machado de Assis", Role: RoleAuthor, Order: 1, AuthorID: "a1"},
+		{Name: "Helen Caldwell", Role: RoleTranslator, Order: 2, AuthorID: "a2"},
+		{Name: "José de Alencar", Role: RoleAuthor, Order: 3, AuthorID: "a3"},
+	}
+
+	got := ReplaceAuthors(contributors, "machado de assis & Jorge Amado")
+	want := []Contributor{
+		// O autor mantido continua ligado ao seu registro, mesmo com outra caixa.
+		{Name: "machado de assis", Role: RoleAuthor, Order: 1, AuthorID: "a1"},
+		{Name: "Jorge Amado", Role: RoleAuthor, Order: 2},
+		{Name: "Helen Caldwell", Role: RoleTranslator, Order: 3, AuthorID: "a2"},
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ReplaceAuthors =\n%v\nwant\n%v", got, want)
+	}
+	if contributors[1].Order != 2 {
+		t.Error("ReplaceAuthors changed the contributors it was given")
+	}
+}
+
+func TestSyncContributors(t *testing.T) {
+	tests := []struct {
+		name       string
+		book       Book
+		wantAuthor string
+		want       string
+	}{
+		{
+			name:       "built from author",
+			book:       Book{Author: "Neil Gaiman & Terry Pratchett"},
+			wantAuthor: "Neil Gaiman & Terry Pratchett",
+			want:       "author:Neil Gaiman author:Terry Pratchett",
+		},
+		{
+			name: "author rebuilt from credits",
+			book: Book{Author: "stale", Contributors: []Contributor{
+				{Name: "Helen  Caldwell", Role: RoleTranslator, Order: 5},
+				{Name: "Assis, Machado de", Role: RoleAuthor, Order: 1},
+				{Name: "José de Alencar", Role: RoleAuthor, Order: 2},
+			}},
+			wantAuthor: "Assis, Machado de; José de Alencar",
+			want:       "author:Assis, Machado de author:José de Alencar translator:Helen Caldwell",
+		},
+		{
+			name:       "only a translator",
+			book:       Book{Author: "Machado de Assis", Contributors: []Contributor{{Name: "Helen Caldwell", Role: RoleTranslator}}},
+			wantAuthor: "Machado de Assis",
+			want:       "author:Machado de Assis translator:Helen Caldwell",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.book.SyncContributors()
+			var got []string
+			for i, c := range tt.book.Contributors {
+				if c.Order != i+1 {
+					t.Errorf("contributor %d has order %d", i, c.Order)
+				}
+				got = append(got, c.Role+":"+c.Name)
+			}
+			if tt.book.Author != tt.wantAuthor || strings.Join(got, " ") != tt.want {
+				t.Errorf("SyncContributors = %q %q, want %q %q", tt.book.Author, strings.Join(got, " "), tt.wantAuthor, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// Conditions of a copy, from the best to the worst.
+const (
+	ConditionNew      = "new"
+	ConditionFine     = "fine"
+	ConditionVeryGood = "very_good"
+	ConditionGood     = "good"
+	ConditionFair     = "fair"
+	ConditionPoor     = "poor"
+)
+
+// CopyConditions lists the conditions of a copy from the best to the worst.
+var CopyConditions = []string{ConditionNew, ConditionFine, ConditionVeryGood, ConditionGood, ConditionFair, ConditionPoor}
+
+// IsCopyCondition reports whether condition is one of CopyConditions.
+func IsCopyCondition(condition string) bool {
+	for _, c := range CopyConditions {
+		if c == condition {
+			return true
+		}
+	}
+	return false
+}
+
+// Copy is a physical item of an edition. Condition, location and
+// acquisition describe the copy, so two copies of the same edition can differ.
+// LastLocation keeps where the copy was when its book left the library.
+type Copy struct {
+	ID           string        `json:"id" bson:"_id,omitempty"`
+	BookID       string        `json:"book_id" bson:"book_id"`
+	Condition    string        `json:"condition,omitempty" bson:"condition,omitempty"`
+	Notes        string        `json:"notes,omitempty" bson:"notes,omitempty"`
+	Location     *BookLocation `json:"location,omitempty" bson:"location,omitempty"`
+	LastLocation *BookLocation `json:"last_location,omitempty" bson:"last_location,omitempty"`
+	Acquisition  *Acquisition  `json:"acquisition,omitempty" bson:"acquisition,omitempty"`
+	CreatedAt    time.Time     `json:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// CoverSizeOriginal names the image exactly as it was uploaded.
+const CoverSizeOriginal = "original"
+
+// Cover describes the cover image of a book. The image itself lives in the
+// blob store; the book only keeps what is needed to serve it.
+type Cover struct {
+	ContentType string `json:"content_type" bson:"content_type"`
+	Width       int    `json:"width" bson:"width"`
+	Height      int    `json:"height" bson:"height"`
+	Size        int64  `json:"size" bson:"size"`
+	// Thumbnails lists the generated sizes, e.g. "small" and "medium".
+	Thumbnails []string  `json:"thumbnails,omitempty" bson:"thumbnails,omitempty"`
+	Checksum   string    `json:"checksum" bson:"checksum"`
+	UpdatedAt  time.Time `json:"updated_at" bson:"updated_at"`
+	// Version names the blobs of this upload, so a new cover is stored
+	// beside the old one before replacing it. Covers uploaded before
+	// versions existed have none.
+	Version string `json:"-" bson:"version,omitempty"`
+}
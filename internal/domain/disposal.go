@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// Book statuses. Owned and lent books are in the library; the others have
+// left it.
+const (
+	BookOwned     = "owned"
+	BookLent      = "lent"
+	BookSold      = "sold"
+	BookDonated   = "donated"
+	BookLost      = "lost"
+	BookDiscarded = "discarded"
+)
+
+// BookStatusAll is the BookFilter status matching books in any status.
+const BookStatusAll = "all"
+
+// BookStatuses lists the statuses a book can have.
+var BookStatuses = []string{BookOwned, BookLent, BookSold, BookDonated, BookLost, BookDiscarded}
+
+// DisposalStatuses lists the statuses of the books that left the library.
+var DisposalStatuses = []string{BookSold, BookDonated, BookLost, BookDiscarded}
+
+// IsBookStatus reports whether status is one of BookStatuses.
+func IsBookStatus(status string) bool {
+	for _, s := range BookStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDisposalStatus reports whether status is one of DisposalStatuses.
+func IsDisposalStatus(status string) bool {
+	for _, s := range DisposalStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// InLibrary reports whether the book is still in the library. Books saved
+// before statuses existed have none and are owned.
+func (b *Book) InLibrary() bool {
+	return !IsDisposalStatus(b.Status)
+}
+
+// Disposal tells when and how a book left the library: to whom it was sold
+// or donated and, for sales, for how much. Currency is an ISO 4217 code.
+type Disposal struct {
+	Date      time.Time `json:"date" bson:"date"`
+	Recipient string    `json:"recipient,omitempty" bson:"recipient,omitempty"`
+	Price     float64   `json:"price,omitempty" bson:"price,omitempty"`
+	Currency  string    `json:"currency,omitempty" bson:"currency,omitempty"`
+	Notes     string    `json:"notes,omitempty" bson:"notes,omitempty"`
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// Loan statuses.
+const (
+	LoanActive   = "active"
+	LoanReturned = "returned"
+)
+
+// Loan records a copy of a book lent to someone, from the day it was lent
+// until it comes back.
+type Loan struct {
+	ID         string     `json:"id" bson:"_id,omitempty"`
+	BookID     string     `json:"book_id" bson:"book_id"`
+	CopyID     string     `json:"copy_id" bson:"copy_id"`
+	Borrower   string     `json:"borrower" bson:"borrower"`
+	Status     string     `json:"status" bson:"status"`
+	Notes      string     `json:"notes,omitempty" bson:"notes,omitempty"`
+	LentAt     time.Time  `json:"lent_at" bson:"lent_at"`
+	DueAt      time.Time  `json:"due_at" bson:"due_at"`
+	ReturnedAt *time.Time `json:"returned_at,omitempty" bson:"returned_at,omitempty"`
+}
+
+// IsOverdue reports whether the loan is still active past its due date.
+func (l *Loan) IsOverdue(now time.Time) bool {
+	return l.Status == LoanActive && now.After(l.DueAt)
+}
+
+// Availability tells whether a book has a copy on hand, derived from its
+// copies and active loans.
+type Availability struct {
+	Available bool `json:"available"`
+	Copies    int  `json:"copies"`
+	Lent      int  `json:"lent"`
+	// DueAt is the earliest due date among the copies lent.
+	DueAt *time.Time `json:"due_at,omitempty"`
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Location kinds, from the outermost to the innermost.
+const (
+	LocationHouse    = "house"
+	LocationRoom     = "room"
+	LocationBookcase = "bookcase"
+	LocationShelf    = "shelf"
+)
+
+// LocationKinds lists the location kinds from the outermost to the innermost.
+var LocationKinds = []string{LocationHouse, LocationRoom, LocationBookcase, LocationShelf}
+
+// LocationDepth returns the level of a location kind, 0 for a house, or -1
+// for an unknown kind.
+func LocationDepth(kind string) int {
+	for i, k := range LocationKinds {
+		if k == kind {
+			return i
+		}
+	}
+	return -1
+}
+
+// Location is a place where physical books are kept, nested as house > room
+// > bookcase > shelf. A location can only hold locations of inner kinds, but
+// levels may be skipped, such as a bookcase straight in a house.
+type Location struct {
+	ID          string `json:"id" bson:"_id,omitempty"`
+	Name        string `json:"name" bson:"name"`
+	Kind        string `json:"kind" bson:"kind"`
+	ParentID    string `json:"parent_id,omitempty" bson:"parent_id,omitempty"`
+	Description string `json:"description,omitempty" bson:"description,omitempty"`
+	// Ancestors holds the IDs of the enclosing locations from the outermost
+	// down, so every location below another can be found at once.
+	Ancestors []string `json:"ancestors,omitempty" bson:"ancestors,omitempty"`
+}
+
+// BookLocation places a copy of a book in a location, optionally at a
+// numbered position counted from the left of the shelf.
+type BookLocation struct {
+	LocationID string `json:"location_id" bson:"location_id"`
+	Position   int    `json:"position,omitempty" bson:"position,omitempty"`
+}
+
+// LocationLabel describes where a book is, given its location path from the
+// outermost location down: "Home > Study > Bookcase 2 > Shelf 3 > position 14".
+func LocationLabel(path []*Location, position int) string {
+	names := make([]string, 0, len(path)+1)
+	for _, l := range path {
+		names = append(names, l.Name)
+	}
+	if position > 0 {
+		names = append(names, "position "+strconv.Itoa(position))
+	}
+	return strings.Join(names, " > ")
+}
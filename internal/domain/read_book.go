@@ -4,12 +4,35 @@ import (
 	"time"
 )
 
+// ReadBook is a reading of a work. BookID tells which edition was read and
+// may be left empty; WorkID is filled in from it.
 type ReadBook struct {
-	ID              string     `json:"id,omitempty"`
-	BookID          string     `json:"book_id" validate:"required"`
-	StartDate       time.Time  `json:"start_date" validate:"required"`
-	ExpectedEndDate time.Time  `json:"expected_end_date"`
-	ActualEndDate   *time.Time `json:"actual_end_date,omitempty"`
-	Comments        []string   `json:"comments,omitempty"`
-	Rating          *int       `json:"rating,omitempty"`
+	ID              string     `json:"id,omitempty" bson:"id"`
+	WorkID          string     `json:"work_id,omitempty" bson:"work_id,omitempty"`
+	BookID          string     `json:"book_id,omitempty" bson:"book_id,omitempty"`
+	StartDate       time.Time  `json:"start_date" bson:"start_date" validate:"required"`
+	ExpectedEndDate time.Time  `json:"expected_end_date" bson:"expected_end_date"`
+	ActualEndDate   *time.Time `json:"actual_end_date,omitempty" bson:"actual_end_date,omitempty"`
+	Comments        []string   `json:"comments,omitempty" bson:"comments,omitempty"`
+	Rating          *int       `json:"rating,omitempty" bson:"rating,omitempty"`
+}
+
+// Read statuses of a book, derived from its reading records.
+const (
+	ReadStatusUnread  = "unread"
+	ReadStatusReading = "reading"
+	ReadStatusRead    = "read"
+)
+
+// ReadStatus summarises the reading records of a book: it is read once any
+// reading has finished, and being read while a reading is in progress.
+func ReadStatus(readBooks []*ReadBook) string {
+	status := ReadStatusUnread
+	for _, rb := range readBooks {
+		if rb.ActualEndDate != nil {
+			return ReadStatusRead
+		}
+		status = ReadStatusReading
+	}
+	return status
 }
@@ -0,0 +1,10 @@
+package domain
+
+// Series is a sequence of books read in order, such as "Discworld". Books
+// join a series through Book.SeriesID and are ordered by Book.SeriesPosition,
+// which may be fractional for novellas placed between two books (2.5).
+type Series struct {
+	ID          string `json:"id" bson:"_id,omitempty"`
+	Name        string `json:"name" bson:"name"`
+	Description string `json:"description,omitempty" bson:"description,omitempty"`
+}
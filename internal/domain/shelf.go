@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// Shelf is a user-defined, manually ordered list of books, such as
+// "Favorites" or "Books to give away". A book may be on several shelves.
+type Shelf struct {
+	ID          string       `json:"id" bson:"_id,omitempty"`
+	Name        string       `json:"name" bson:"name"`
+	Description string       `json:"description,omitempty" bson:"description,omitempty"`
+	Entries     []ShelfEntry `json:"entries" bson:"entries"`
+	CreatedAt   time.Time    `json:"created_at" bson:"created_at"`
+	// Revision counts the changes made to the entries. Positions are written
+	// only if the shelf is still at the revision they were computed from.
+	Revision int64 `json:"-" bson:"revision,omitempty"`
+}
+
+// ShelfEntry places a book on a shelf. Entries are ordered by Position,
+// which is fractional so a book can be moved between two others without
+// renumbering the rest of the shelf.
+type ShelfEntry struct {
+	BookID   string    `json:"book_id" bson:"book_id"`
+	Position float64   `json:"position" bson:"position"`
+	AddedAt  time.Time `json:"added_at" bson:"added_at"`
+}
+
+// SortEntries orders the entries by position. Entries sharing a position,
+// as when two books are dropped in the same place at once, are ordered by
+// the time they were added and then by book ID, so the order is stable.
+func (s *Shelf) SortEntries() {
+	sort.SliceStable(s.Entries, func(i, j int) bool {
+		a, b := s.Entries[i], s.Entries[j]
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		if !a.AddedAt.Equal(b.AddedAt) {
+			return a.AddedAt.Before(b.AddedAt)
+		}
+		return a.BookID < b.BookID
+	})
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// Suggestion statuses.
+const (
+	SuggestionPending  = "pending"
+	SuggestionApproved = "approved"
+	SuggestionRejected = "rejected"
+)
+
+// Suggestion is a set of changes proposed for a book by the enrichment job.
+// It is only applied to the book once approved.
+type Suggestion struct {
+	ID         string        `json:"id" bson:"_id"`
+	BookID     string        `json:"book_id" bson:"book_id"`
+	Source     string        `json:"source" bson:"source"`
+	Changes    []FieldChange `json:"changes" bson:"changes"`
+	Status     string        `json:"status" bson:"status"`
+	CreatedAt  time.Time     `json:"created_at" bson:"created_at"`
+	ResolvedAt *time.Time    `json:"resolved_at,omitempty" bson:"resolved_at,omitempty"`
+}
+
+// FieldChange is a single proposed value for a book field. Current holds the
+// value the book had when the suggestion was made.
+type FieldChange struct {
+	Field    string `json:"field" bson:"field"`
+	Current  string `json:"current" bson:"current"`
+	Proposed string `json:"proposed" bson:"proposed"`
+}
+
+// EnrichmentProgress records how far the enrichment job got, so an
+// interrupted run resumes after the last processed book.
+type EnrichmentProgress struct {
+	LastBookID  string     `json:"last_book_id" bson:"last_book_id"`
+	Processed   int        `json:"processed" bson:"processed"`
+	Suggested   int        `json:"suggested" bson:"suggested"`
+	StartedAt   time.Time  `json:"started_at" bson:"started_at"`
+	UpdatedAt   time.Time  `json:"updated_at" bson:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// TagSeparator separates the levels of a tag path, as in "fiction/sci-fi/cyberpunk".
+const TagSeparator = "/"
+
+// Tag is a node of the tag tree. Books are tagged with the full path of the
+// tag, and a tag includes every tag below it.
+type Tag struct {
+	ID          string `json:"id" bson:"_id,omitempty"`
+	Path        string `json:"path" bson:"path"`
+	Name        string `json:"name" bson:"name"`
+	Parent      string `json:"parent,omitempty" bson:"parent,omitempty"`
+	Description string `json:"description,omitempty" bson:"description,omitempty"`
+}
+
+// SetPath sets the path of the tag along with its name and parent path.
+func (t *Tag) SetPath(path string) {
+	t.Path = path
+	t.Parent = TagParent(path)
+	t.Name = strings.TrimPrefix(path[len(t.Parent):], TagSeparator)
+}
+
+// Tag change actions.
+const (
+	TagRenamed = "rename"
+	TagMoved   = "move"
+	TagMerged  = "merge"
+	TagDeleted = "delete"
+)
+
+// TagChange records an operation that rewrote the tags of books, with the
+// books it changed, so merges and deletions can be traced and undone by hand.
+type TagChange struct {
+	ID        string    `json:"id" bson:"_id"`
+	Action    string    `json:"action" bson:"action"`
+	From      string    `json:"from" bson:"from"`
+	To        string    `json:"to,omitempty" bson:"to,omitempty"`
+	BookIDs   []string  `json:"book_ids,omitempty" bson:"book_ids,omitempty"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// NormalizeTagPath trims the levels of a tag path and drops the empty ones,
+// so " fiction / / sci-fi " becomes "fiction/sci-fi".
+func NormalizeTagPath(path string) string {
+	var levels []string
+	for _, level := range strings.Split(path, TagSeparator) {
+		if level = strings.Join(strings.Fields(level), " "); level != "" {
+			levels = append(levels, level)
+		}
+	}
+	return strings.Join(levels, TagSeparator)
+}
+
+// NormalizeTags normalizes every tag path, dropping blanks and repeated tags.
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		if tag = NormalizeTagPath(tag); tag != "" && !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// TagParent returns the path of the parent of a tag, or "" for a root tag.
+func TagParent(path string) string {
+	if i := strings.LastIndex(path, TagSeparator); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// TagAncestors returns the path of the tag preceded by those of its
+// ancestors, from the root down: "a", "a/b" and "a/b/c" for "a/b/c".
+func TagAncestors(path string) []string {
+	var paths []string
+	for i, c := range path {
+		if string(c) == TagSeparator {
+			paths = append(paths, path[:i])
+		}
+	}
+	return append(paths, path)
+}
+
+// IsTagWithin reports whether path is ancestor or one of its descendants.
+func IsTagWithin(path, ancestor string) bool {
+	return path == ancestor || strings.HasPrefix(path, ancestor+TagSeparator)
+}
+
+// RebaseTag moves path from below from to below to. Paths outside from are
+// returned unchanged.
+func RebaseTag(path, from, to string) string {
+	if !IsTagWithin(path, from) {
+		return path
+	}
+	return to + path[len(from):]
+}
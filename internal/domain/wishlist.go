@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+// Wishlist priorities, from the most wanted down.
+const (
+	PriorityHigh   = "high"
+	PriorityMedium = "medium"
+	PriorityLow    = "low"
+)
+
+// WishlistPriorities lists the priorities from the most wanted down.
+var WishlistPriorities = []string{PriorityHigh, PriorityMedium, PriorityLow}
+
+// PriorityRank returns the place of priority in WishlistPriorities, or -1
+// when it is not one of them.
+func PriorityRank(priority string) int {
+	for i, p := range WishlistPriorities {
+		if p == priority {
+			return i
+		}
+	}
+	return -1
+}
+
+// Formats a wished book may be wanted in.
+const (
+	FormatHardcover = "hardcover"
+	FormatPaperback = "paperback"
+	FormatEbook     = "ebook"
+	FormatAudiobook = "audiobook"
+)
+
+// BookFormats lists the formats a wished book may be wanted in.
+var BookFormats = []string{FormatHardcover, FormatPaperback, FormatEbook, FormatAudiobook}
+
+// IsBookFormat reports whether format is one of BookFormats.
+func IsBookFormat(format string) bool {
+	for _, f := range BookFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// WishlistItem is a book wanted but not owned yet, optionally narrowed to
+// an edition or format. ISBN accepts either form and is kept as an ISBN-13.
+type WishlistItem struct {
+	ID       string `json:"id" bson:"_id,omitempty"`
+	Title    string `json:"title" bson:"title"`
+	Author   string `json:"author,omitempty" bson:"author,omitempty"`
+	ISBN     string `json:"isbn,omitempty" bson:"isbn,omitempty"`
+	Edition  string `json:"edition,omitempty" bson:"edition,omitempty"`
+	Format   string `json:"format,omitempty" bson:"format,omitempty"`
+	Priority string `json:"priority" bson:"priority"`
+	Notes    string `json:"notes,omitempty" bson:"notes,omitempty"`
+	// Reservation is made by a friend through the share link and is never
+	// shown to the owner, so gifts stay a surprise.
+	Reservation *Reservation `json:"-" bson:"reservation,omitempty"`
+	CreatedAt   time.Time    `json:"created_at" bson:"created_at"`
+}
+
+// Reservation marks a wished book as taken by someone planning to give it.
+// Code is handed to that person only, who needs it to cancel.
+type Reservation struct {
+	Code       string    `bson:"code"`
+	ReservedAt time.Time `bson:"reserved_at"`
+}
+
+// WishlistShare is a read-only link to the wishlist, identified by a
+// random token.
+type WishlistShare struct {
+	Token     string    `json:"token" bson:"_id"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
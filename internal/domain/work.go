@@ -0,0 +1,22 @@
+package domain
+
+// Work is a creation independent of how it was published, such as "Dom
+// Casmurro". Its editions are the books pointing to it through Book.WorkID,
+// and each edition has the physical copies owned. Reading records and the
+// rating belong to the work, whichever edition was read.
+type Work struct {
+	ID               string `json:"id" bson:"_id,omitempty"`
+	Title            string `json:"title" bson:"title"`
+	Author           string `json:"author" bson:"author"`
+	OriginalTitle    string `json:"original_title,omitempty" bson:"original_title,omitempty"`
+	OriginalLanguage string `json:"original_language,omitempty" bson:"original_language,omitempty"`
+	FirstPublished   int    `json:"first_published,omitempty" bson:"first_published,omitempty"`
+	Description      string `json:"description,omitempty" bson:"description,omitempty"`
+	// Rating goes from 1 to 5.
+	Rating *int `json:"rating,omitempty" bson:"rating,omitempty"`
+}
+
+// WorkFromBook starts a work from the title and authors of an edition.
+func WorkFromBook(book *Book) *Work {
+	return &Work{Title: book.Title, Author: book.Author}
+}
@@ -0,0 +1,210 @@
+// Package epub reads the metadata, cover and text of EPUB files.
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/isbn"
+	"github.com/rfulgencio3/go-personal-library/internal/opf"
+)
+
+// MediaType is the content of the mimetype entry of every EPUB.
+const MediaType = "application/epub+zip"
+
+// charsPerPage approximates the text of a printed page, used to estimate
+// the page count from the spine content.
+const charsPerPage = 1800
+
+// maxEntrySize guards against compressed entries that expand to huge sizes.
+const maxEntrySize = 64 << 20
+
+var (
+	ErrNotEPUB     = errors.New("file is not an EPUB")
+	ErrNoPackage   = errors.New("EPUB has no package document")
+	ErrNoCover     = errors.New("EPUB has no cover image")
+	ErrEntryTooBig = errors.New("EPUB entry is too large")
+)
+
+// File is an opened EPUB.
+type File struct {
+	Package *opf.Package
+	zip     *zip.Reader
+	// opfDir is the directory of the package document; manifest hrefs are
+	// relative to it.
+	opfDir string
+}
+
+type container struct {
+	Rootfiles []struct {
+		FullPath  string `xml:"full-path,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// IsEPUB reports whether data looks like an EPUB: a ZIP archive whose first
+// entry is the "mimetype" file.
+func IsEPUB(data []byte) bool {
+	return len(data) > 58 && bytes.HasPrefix(data, []byte("PK\x03\x04")) &&
+		string(data[30:38]) == "mimetype" && bytes.HasPrefix(data[38:], []byte(MediaType))
+}
+
+// Open reads the container and package document of an EPUB.
+func Open(r io.ReaderAt, size int64) (*File, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, ErrNotEPUB
+	}
+	f := &File{zip: zr}
+
+	data, err := f.read("META-INF/container.xml")
+	if err != nil {
+		return nil, ErrNoPackage
+	}
+	var c container
+	if err := xml.Unmarshal(data, &c); err != nil {
+		return nil, ErrNoPackage
+	}
+
+	for _, root := range c.Rootfiles {
+		if root.MediaType != "" && root.MediaType != "application/oebps-package+xml" {
+			continue
+		}
+		data, err := f.read(root.FullPath)
+		if err != nil {
+			continue
+		}
+		pkg, err := opf.Parse(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		f.Package = pkg
+		f.opfDir = path.Dir(root.FullPath)
+		return f, nil
+	}
+	return nil, ErrNoPackage
+}
+
+func (f *File) read(name string) ([]byte, error) {
+	entry, err := f.zip.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer entry.Close()
+	data, err := io.ReadAll(io.LimitReader(entry, maxEntrySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxEntrySize {
+		return nil, ErrEntryTooBig
+	}
+	return data, nil
+}
+
+// readItem reads a manifest item, resolving its href against the package directory.
+func (f *File) readItem(item opf.Item) ([]byte, error) {
+	href := item.Href
+	if i := strings.IndexAny(href, "#?"); i >= 0 {
+		href = href[:i]
+	}
+	// Os hrefs podem vir codificados, como "Text/chapter%201.xhtml".
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+	return f.read(path.Join(f.opfDir, href))
+}
+
+// Cover returns the cover image and its media type. EPUB 3 marks it with the
+// cover-image property; EPUB 2 points to it from a "cover" meta element.
+func (f *File) Cover() ([]byte, string, error) {
+	for _, item := range f.Package.Manifest {
+		if hasProperty(item.Properties, "cover-image") {
+			data, err := f.readItem(item)
+			return data, item.MediaType, err
+		}
+	}
+	if id := f.Package.Meta("cover"); id != "" {
+		if item, ok := f.Package.Item(id); ok && strings.HasPrefix(item.MediaType, "image/") {
+			data, err := f.readItem(item)
+			return data, item.MediaType, err
+		}
+	}
+	// Alguns geradores só nomeiam o arquivo, sem declarar a capa.
+	for _, item := range f.Package.Manifest {
+		if strings.HasPrefix(item.MediaType, "image/") && strings.Contains(strings.ToLower(item.ID+item.Href), "cover") {
+			data, err := f.readItem(item)
+			return data, item.MediaType, err
+		}
+	}
+	return nil, "", ErrNoCover
+}
+
+// EstimatePages estimates the printed page count from the amount of text in
+// the spine documents.
+func (f *File) EstimatePages() int {
+	chars := 0
+	for _, ref := range f.Package.Spine {
+		item, ok := f.Package.Item(ref.IDRef)
+		if !ok {
+			continue
+		}
+		data, err := f.readItem(item)
+		if err != nil {
+			continue
+		}
+		chars += len([]rune(strings.Join(strings.Fields(opf.StripHTML(string(data))), " ")))
+	}
+	if chars == 0 {
+		return 0
+	}
+	return max(1, (chars+charsPerPage-1)/charsPerPage)
+}
+
+// Book maps the package metadata to a book, including the estimated page count.
+func (f *File) Book() *domain.Book {
+	pkg := f.Package
+	book := &domain.Book{
+		Title:        pkg.Title(),
+		Subtitle:     pkg.Subtitle(),
+		Author:       domain.JoinAuthorNames(pkg.Authors()),
+		Contributors: pkg.Contributors(),
+		Publisher:    pkg.Publisher(),
+		Language:     pkg.Language(),
+		Description:  pkg.Description(),
+		Tags:         pkg.Tags(),
+		Pages:        f.EstimatePages(),
+	}
+	book.Year, _ = strconv.Atoi(pkg.Year())
+
+	identifiers := make(map[string]string)
+	for scheme, value := range pkg.Identifiers() {
+		if scheme == "isbn" {
+			if isbn10, isbn13, err := isbn.Parse(value); err == nil {
+				book.ISBN10, book.ISBN13 = isbn10, isbn13
+				continue
+			}
+		}
+		identifiers[scheme] = value
+	}
+	if len(identifiers) > 0 {
+		book.Identifiers = identifiers
+	}
+	return book
+}
+
+func hasProperty(properties, name string) bool {
+	for _, p := range strings.Fields(properties) {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const containerXML = `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>
+</container>`
+
+// packageDoc wraps metadata and manifest entries in an OPF document whose
+// spine lists the chapter.
+func packageDoc(version, metadata, manifest string) string {
+	return `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="` + version + `">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">` + metadata + `</metadata>
+  <manifest>
+    <item id="ch1" href="Text/chapter%201.xhtml" media-type="application/xhtml+xml"/>` + manifest + `
+  </manifest>
+  <spine><itemref idref="ch1"/></spine>
+</package>`
+}
+
+// chapter is an XHTML document with n characters of text.
+func chapter(n int) string {
+	return "<html><body><p>" + strings.Repeat("a", n) + "</p></body></html>"
+}
+
+// buildEPUB zips files into an EPUB, storing the mimetype entry first as the
+// format requires. A nil files map gets the default container.
+func buildEPUB(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte(MediaType))
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestOpen(t *testing.T) {
+	metadata := `<dc:title>Vidas Secas</dc:title>
+    <dc:creator>Graciliano Ramos</dc:creator>
+    <dc:identifier>urn:isbn:9788535902778</dc:identifier>
+    <dc:identifier>urn:uuid:0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b</dc:identifier>
+    <dc:date>1938-01-01</dc:date>`
+	tests := []struct {
+		name          string
+		files         map[string]string
+		wantErr       error
+		wantPages     int
+		wantCover     string
+		wantCoverType string
+		wantCoverErr  error
+	}{
+		{
+			name: "EPUB 3 cover-image",
+			files: map[string]string{
+				"META-INF/container.xml":     containerXML,
+				"OEBPS/content.opf":          packageDoc("3.0", metadata, `<item id="img" href="images/front.png" media-type="image/png" properties="cover-image"/>`),
+				"OEBPS/Text/chapter 1.xhtml": chapter(3600),
+				"OEBPS/images/front.png":     "png bytes",
+			},
+			wantPages:     2,
+			wantCover:     "png bytes",
+			wantCoverType: "image/png",
+		},
+		{
+			name: "EPUB 2 cover meta",
+			files: map[string]string{
+				"META-INF/container.xml":     containerXML,
+				"OEBPS/content.opf":          packageDoc("2.0", metadata+`<meta name="cover" content="capa"/>`, `<item id="capa" href="capa.jpg" media-type="image/jpeg"/>`),
+				"OEBPS/Text/chapter 1.xhtml": chapter(100),
+				"OEBPS/capa.jpg":             "jpeg bytes",
+			},
+			wantPages:     1,
+			wantCover:     "jpeg bytes",
+			wantCoverType: "image/jpeg",
+		},
+		{
+			name: "cover named only",
+			files: map[string]string{
+				"META-INF/container.xml": containerXML,
+				"OEBPS/content.opf":      packageDoc("2.0", metadata, `<item id="i1" href="img/Cover.gif" media-type="image/gif"/>`),
+				"OEBPS/img/Cover.gif":    "gif bytes",
+			},
+			wantCover:     "gif bytes",
+			wantCoverType: "image/gif",
+		},
+		{
+			name: "no cover",
+			files: map[string]string{
+				"META-INF/container.xml":     containerXML,
+				"OEBPS/content.opf":          packageDoc("3.0", metadata, ""),
+				"OEBPS/Text/chapter 1.xhtml": chapter(1800),
+			},
+			wantPages:    1,
+			wantCoverErr: ErrNoCover,
+		},
+		{
+			name:    "no container",
+			files:   map[string]string{"OEBPS/content.opf": packageDoc("3.0", metadata, "")},
+			wantErr: ErrNoPackage,
+		},
+		{
+			name:    "missing package document",
+			files:   map[string]string{"META-INF/container.xml": containerXML},
+			wantErr: ErrNoPackage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := buildEPUB(t, tt.files)
+			if !IsEPUB(data) {
+				t.Fatal("IsEPUB = false")
+			}
+			f, err := Open(bytes.NewReader(data), int64(len(data)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Open error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			book := f.Book()
+			if book.Title != "Vidas Secas" || book.Author != "Graciliano Ramos" || book.Year != 1938 {
+				t.Errorf("book = %+v", book)
+			}
+			if book.ISBN13 != "9788535902778" || book.Identifiers["uuid"] != "0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b" {
+				t.Errorf("ISBN13 = %q, identifiers = %v", book.ISBN13, book.Identifiers)
+			}
+			if book.Pages != tt.wantPages {
+				t.Errorf("pages = %d, want %d", book.Pages, tt.wantPages)
+			}
+
+			cover, mediaType, err := f.Cover()
+			if !errors.Is(err, tt.wantCoverErr) {
+				t.Fatalf("Cover error = %v, want %v", err, tt.wantCoverErr)
+			}
+			if string(cover) != tt.wantCover || mediaType != tt.wantCoverType {
+				t.Errorf("Cover = %q, %q; want %q, %q", cover, mediaType, tt.wantCover, tt.wantCoverType)
+			}
+		})
+	}
+}
+
+func TestIsEPUB(t *testing.T) {
+	var plainZip bytes.Buffer
+	zw := zip.NewWriter(&plainZip)
+	w, _ := zw.Create("readme.txt")
+	w.Write([]byte(strings.Repeat("not an epub ", 10)))
+	zw.Close()
+
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{"EPUB", buildEPUB(t, nil), true},
+		{"other ZIP", plainZip.Bytes(), false},
+		{"PDF", []byte("%PDF-1.7\n" + strings.Repeat(" ", 64)), false},
+		{"empty", nil, false},
+	}
+	for _, tt := range tests {
+		if got := IsEPUB(tt.data); got != tt.want {
+			t.Errorf("IsEPUB(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if _, err := Open(bytes.NewReader([]byte("garbage")), 7); !errors.Is(err, ErrNotEPUB) {
+		t.Errorf("Open(garbage) error = %v, want ErrNotEPUB", err)
+	}
+}
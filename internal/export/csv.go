@@ -0,0 +1,76 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var csvHeader = []string{
+	"id", "title", "subtitle", "author", "isbn10", "isbn13", "pages", "publisher", "edition", "year", "comments",
+	"times_read", "last_start_date", "last_end_date", "rating", "reading_comments",
+}
+
+// csvWriter writes one row per book with its reading records summarised.
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (cw *csvWriter) Write(entry *Entry) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	var lastStart, lastEnd, rating string
+	if last := lastRead(entry.ReadBooks); last != nil {
+		lastStart = last.StartDate.Format(time.RFC3339)
+	}
+	if finished := lastFinished(entry.ReadBooks); finished != nil {
+		lastEnd = finished.ActualEndDate.Format(time.RFC3339)
+	}
+	if r := latestRating(entry.ReadBooks); r != nil {
+		rating = strconv.Itoa(*r)
+	}
+
+	return cw.w.Write([]string{
+		entry.ID,
+		entry.Title,
+		entry.Subtitle,
+		entry.Author,
+		entry.ISBN10,
+		entry.ISBN13,
+		strconv.Itoa(entry.Pages),
+		entry.Publisher,
+		entry.Edition,
+		yearString(entry.Year),
+		entry.Comments,
+		strconv.Itoa(finishedCount(entry.ReadBooks)),
+		lastStart,
+		lastEnd,
+		rating,
+		strings.Join(readingComments(entry.ReadBooks), " | "),
+	})
+}
+
+func (cw *csvWriter) Close() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvWriter) writeHeader() error {
+	if cw.headerWritten {
+		return nil
+	}
+	cw.headerWritten = true
+	return cw.w.Write(csvHeader)
+}
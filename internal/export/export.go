@@ -0,0 +1,160 @@
+package export
+
+import (
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+// Format identifies one of the supported export formats.
+type Format string
+
+const (
+	FormatCSV       Format = "csv"
+	FormatJSON      Format = "json"
+	FormatNDJSON    Format = "ndjson"
+	FormatGoodreads Format = "goodreads"
+	FormatMARCXML   Format = "marcxml"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Entry joins a book with all of its reading records.
+type Entry struct {
+	*domain.Book
+	ReadBooks []*domain.ReadBook `json:"read_books"`
+}
+
+// Writer encodes entries one at a time, so the export can be streamed.
+type Writer interface {
+	Write(entry *Entry) error
+	// Close flushes any buffered data and writes trailing content.
+	Close() error
+}
+
+// IsSupported reports whether format is a known export format.
+func IsSupported(format Format) bool {
+	switch format {
+	case FormatCSV, FormatJSON, FormatNDJSON, FormatGoodreads, FormatMARCXML:
+		return true
+	}
+	return false
+}
+
+// NewWriter returns a Writer that encodes entries in the given format to w.
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatJSON:
+		return newJSONWriter(w), nil
+	case FormatNDJSON:
+		return newNDJSONWriter(w), nil
+	case FormatGoodreads:
+		return newGoodreadsWriter(w), nil
+	case FormatMARCXML:
+		return newMARCXMLWriter(w), nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// ContentType returns the MIME type used when serving the given format.
+func ContentType(format Format) string {
+	switch format {
+	case FormatCSV, FormatGoodreads:
+		return "text/csv; charset=utf-8"
+	case FormatJSON:
+		return "application/json"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatMARCXML:
+		return "application/marcxml+xml"
+	}
+	return "application/octet-stream"
+}
+
+// FileExtension returns the file extension used for downloads of the given format.
+func FileExtension(format Format) string {
+	switch format {
+	case FormatCSV, FormatGoodreads:
+		return "csv"
+	case FormatJSON:
+		return "json"
+	case FormatNDJSON:
+		return "ndjson"
+	case FormatMARCXML:
+		return "xml"
+	}
+	return "bin"
+}
+
+// lastRead returns the most recent reading record, or nil when the book was never read.
+func lastRead(readBooks []*domain.ReadBook) *domain.ReadBook {
+	var last *domain.ReadBook
+	for _, rb := range readBooks {
+		if last == nil || rb.StartDate.After(last.StartDate) {
+			last = rb
+		}
+	}
+	return last
+}
+
+// lastFinished returns the most recently finished reading record, if any.
+func lastFinished(readBooks []*domain.ReadBook) *domain.ReadBook {
+	var last *domain.ReadBook
+	for _, rb := range readBooks {
+		if rb.ActualEndDate == nil {
+			continue
+		}
+		if last == nil || rb.ActualEndDate.After(*last.ActualEndDate) {
+			last = rb
+		}
+	}
+	return last
+}
+
+// finishedCount returns how many times the book was read to the end.
+func finishedCount(readBooks []*domain.ReadBook) int {
+	count := 0
+	for _, rb := range readBooks {
+		if rb.ActualEndDate != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// latestRating returns the rating from the most recent rated reading.
+func latestRating(readBooks []*domain.ReadBook) *int {
+	var rating *int
+	var ratedAt *domain.ReadBook
+	for _, rb := range readBooks {
+		if rb.Rating == nil {
+			continue
+		}
+		if ratedAt == nil || rb.StartDate.After(ratedAt.StartDate) {
+			ratedAt = rb
+			rating = rb.Rating
+		}
+	}
+	return rating
+}
+
+// yearString formats a publication year, leaving unknown years blank.
+func yearString(year int) string {
+	if year <= 0 {
+		return ""
+	}
+	return strconv.Itoa(year)
+}
+
+// readingComments flattens the comments of every reading record.
+func readingComments(readBooks []*domain.ReadBook) []string {
+	var comments []string
+	for _, rb := range readBooks {
+		comments = append(comments, rb.Comments...)
+	}
+	return comments
+}
@@ -0,0 +1,223 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// entries returns a book read once and being read again, and a book never read.
+func entries() []*Entry {
+	finished, rating := date("2023-02-01"), 4
+	return []*Entry{
+		{
+			Book: &domain.Book{
+				ID: "b1", Title: "Dom Casmurro", Subtitle: "romance", Author: "Machado de Assis",
+				ISBN10: "8535902775", ISBN13: "9788535902778", Pages: 256, Publisher: "Garnier",
+				Edition: "2", Year: 1899, Comments: "Edição anotada",
+			},
+			ReadBooks: []*domain.ReadBook{
+				{StartDate: date("2024-03-05"), Comments: []string{"releitura"}},
+				{StartDate: date("2023-01-10"), ActualEndDate: &finished, Rating: &rating, Comments: []string{"ótimo"}},
+			},
+		},
+		{Book: &domain.Book{ID: "b2", Title: "Beowulf"}},
+	}
+}
+
+func write(t *testing.T, format Format, entries []*Entry) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf)
+	if err != nil {
+		t.Fatalf("NewWriter(%s): %v", format, err)
+	}
+	for _, entry := range entries {
+		if err := w.Write(entry); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.String()
+}
+
+func TestCSVWriters(t *testing.T) {
+	tests := []struct {
+		format  Format
+		entries []*Entry
+		want    [][]string
+	}{
+		{
+			format:  FormatCSV,
+			entries: entries(),
+			want: [][]string{
+				csvHeader,
+				{"b1", "Dom Casmurro", "romance", "Machado de Assis", "8535902775", "9788535902778", "256", "Garnier", "2", "1899",
+					"Edição anotada", "1", "2024-03-05T00:00:00Z", "2023-02-01T00:00:00Z", "4", "releitura | ótimo"},
+				{"b2", "Beowulf", "", "", "", "", "0", "", "", "", "", "0", "", "", "", ""},
+			},
+		},
+		{
+			format:  FormatGoodreads,
+			entries: entries(),
+			want: [][]string{
+				goodreadsHeader,
+				{"b1", "Dom Casmurro: romance", "Machado de Assis", "Assis, Machado de", "", `="8535902775"`, `="9788535902778"`,
+					"4", "", "Garnier", "", "256", "1899", "1899", "2023/02/01", "2023/01/10",
+					"currently-reading", "currently-reading (#1)", "currently-reading", "Edição anotada", "", "releitura\nótimo", "1", "1"},
+				{"b2", "Beowulf", "", "", "", `=""`, `=""`, "0", "", "", "", "", "", "", "", "",
+					"to-read", "to-read (#1)", "to-read", "", "", "", "0", "1"},
+			},
+		},
+		// Sem livros, só o cabeçalho é escrito.
+		{format: FormatCSV, want: [][]string{csvHeader}},
+		{format: FormatGoodreads, want: [][]string{goodreadsHeader}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			got, err := csv.NewReader(strings.NewReader(write(t, tt.format, tt.entries))).ReadAll()
+			if err != nil {
+				t.Fatalf("output is not CSV: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d rows, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if strings.Join(got[i], "|") != strings.Join(tt.want[i], "|") {
+					t.Errorf("row %d = %q\nwant %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestJSONWriters(t *testing.T) {
+	tests := []struct {
+		format  Format
+		entries []*Entry
+		want    []string
+	}{
+		{format: FormatJSON, entries: entries(), want: []string{"b1", "b2"}},
+		{format: FormatJSON},
+		{format: FormatNDJSON, entries: entries(), want: []string{"b1", "b2"}},
+		{format: FormatNDJSON},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			out := write(t, tt.format, tt.entries)
+
+			var got []Entry
+			if tt.format == FormatJSON {
+				if err := json.Unmarshal([]byte(out), &got); err != nil {
+					t.Fatalf("output is not a JSON array: %v\n%s", err, out)
+				}
+			} else {
+				for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+					if line == "" {
+						continue
+					}
+					var entry Entry
+					if err := json.Unmarshal([]byte(line), &entry); err != nil {
+						t.Fatalf("line is not JSON: %v\n%s", err, line)
+					}
+					got = append(got, entry)
+				}
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d entries, want %d\n%s", len(got), len(tt.want), out)
+			}
+			for i, entry := range got {
+				if entry.ID != tt.want[i] {
+					t.Errorf("entry %d ID = %q, want %q", i, entry.ID, tt.want[i])
+				}
+			}
+			if len(got) > 0 && len(got[0].ReadBooks) != 2 {
+				t.Errorf("first entry has %d reading records, want 2", len(got[0].ReadBooks))
+			}
+		})
+	}
+}
+
+func TestMARCXMLWriter(t *testing.T) {
+	out := write(t, FormatMARCXML, entries())
+	if got := strings.Count(out, "<record"); got != 2 {
+		t.Errorf("got %d records, want 2\n%s", got, out)
+	}
+	if !strings.Contains(out, "Dom Casmurro") || !strings.Contains(out, "9788535902778") {
+		t.Errorf("record misses the book data:\n%s", out)
+	}
+}
+
+func TestNewWriterUnsupported(t *testing.T) {
+	if _, err := NewWriter("xlsx", &bytes.Buffer{}); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("NewWriter error = %v, want %v", err, ErrUnsupportedFormat)
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	// Os formatos aceitos são exatamente os que têm um Writer.
+	for _, format := range []Format{FormatCSV, FormatJSON, FormatNDJSON, FormatGoodreads, FormatMARCXML, "xlsx", ""} {
+		_, err := NewWriter(format, &bytes.Buffer{})
+		if got := IsSupported(format); got != (err == nil) {
+			t.Errorf("IsSupported(%q) = %v, but NewWriter error = %v", format, got, err)
+		}
+	}
+}
+
+func TestExclusiveShelf(t *testing.T) {
+	end := date("2023-02-01")
+	finished := &domain.ReadBook{StartDate: date("2023-01-10"), ActualEndDate: &end}
+	reading := &domain.ReadBook{StartDate: date("2024-03-05")}
+	abandoned := &domain.ReadBook{StartDate: date("2022-05-01")}
+
+	tests := []struct {
+		name      string
+		readBooks []*domain.ReadBook
+		want      string
+	}{
+		{name: "never read", want: "to-read"},
+		{name: "finished", readBooks: []*domain.ReadBook{finished}, want: "read"},
+		{name: "reading again", readBooks: []*domain.ReadBook{finished, reading}, want: "currently-reading"},
+		// Só a leitura mais recente conta.
+		{name: "finished after an unfinished reading", readBooks: []*domain.ReadBook{abandoned, finished}, want: "read"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exclusiveShelf(tt.readBooks); got != tt.want {
+				t.Errorf("exclusiveShelf = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorLastFirst(t *testing.T) {
+	tests := map[string]string{
+		"Machado de Assis":    "Assis, Machado de",
+		"  Clarice Lispector": "Lispector, Clarice",
+		"Assis, Machado de":   "Assis, Machado de",
+		"Platão":              "Platão",
+		"":                    "",
+	}
+	for author, want := range tests {
+		if got := authorLastFirst(author); got != want {
+			t.Errorf("authorLastFirst(%q) = %q, want %q", author, got, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+// goodreadsHeader matches the columns of the Goodreads library export, which
+// is the de facto import format of other book-tracking services.
+var goodreadsHeader = []string{
+	"Book Id", "Title", "Author", "Author l-f", "Additional Authors", "ISBN", "ISBN13",
+	"My Rating", "Average Rating", "Publisher", "Binding", "Number of Pages",
+	"Year Published", "Original Publication Year", "Date Read", "Date Added",
+	"Bookshelves", "Bookshelves with positions", "Exclusive Shelf", "My Review",
+	"Spoiler", "Private Notes", "Read Count", "Owned Copies",
+}
+
+const goodreadsDateLayout = "2006/01/02"
+
+// goodreadsWriter writes a Goodreads-compatible CSV file.
+type goodreadsWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func newGoodreadsWriter(w io.Writer) *goodreadsWriter {
+	return &goodreadsWriter{w: csv.NewWriter(w)}
+}
+
+func (gw *goodreadsWriter) Write(entry *Entry) error {
+	if err := gw.writeHeader(); err != nil {
+		return err
+	}
+
+	title := entry.Title
+	if entry.Subtitle != "" {
+		title += ": " + entry.Subtitle
+	}
+
+	rating := "0"
+	if r := latestRating(entry.ReadBooks); r != nil {
+		rating = strconv.Itoa(*r)
+	}
+
+	var dateRead, dateAdded string
+	if finished := lastFinished(entry.ReadBooks); finished != nil {
+		dateRead = finished.ActualEndDate.Format(goodreadsDateLayout)
+	}
+	if first := firstRead(entry.ReadBooks); first != nil {
+		dateAdded = first.StartDate.Format(goodreadsDateLayout)
+	}
+
+	shelf := exclusiveShelf(entry.ReadBooks)
+	pages := ""
+	if entry.Pages > 0 {
+		pages = strconv.Itoa(entry.Pages)
+	}
+	year := yearString(entry.Year)
+
+	return gw.w.Write([]string{
+		entry.ID,
+		title,
+		entry.Author,
+		authorLastFirst(entry.Author),
+		"",
+		goodreadsISBN(entry.ISBN10),
+		goodreadsISBN(entry.ISBN13),
+		rating,
+		"",
+		entry.Publisher,
+		"",
+		pages,
+		year,
+		year,
+		dateRead,
+		dateAdded,
+		shelf,
+		shelf + " (#1)",
+		shelf,
+		entry.Comments,
+		"",
+		strings.Join(readingComments(entry.ReadBooks), "\n"),
+		strconv.Itoa(finishedCount(entry.ReadBooks)),
+		"1",
+	})
+}
+
+func (gw *goodreadsWriter) Close() error {
+	if err := gw.writeHeader(); err != nil {
+		return err
+	}
+	gw.w.Flush()
+	return gw.w.Error()
+}
+
+func (gw *goodreadsWriter) writeHeader() error {
+	if gw.headerWritten {
+		return nil
+	}
+	gw.headerWritten = true
+	return gw.w.Write(goodreadsHeader)
+}
+
+// exclusiveShelf maps the reading records to one of the three Goodreads default shelves.
+func exclusiveShelf(readBooks []*domain.ReadBook) string {
+	if len(readBooks) == 0 {
+		return "to-read"
+	}
+	if last := lastRead(readBooks); last.ActualEndDate == nil {
+		return "currently-reading"
+	}
+	return "read"
+}
+
+// firstRead returns the earliest reading record, if any.
+func firstRead(readBooks []*domain.ReadBook) *domain.ReadBook {
+	var first *domain.ReadBook
+	for _, rb := range readBooks {
+		if first == nil || rb.StartDate.Before(first.StartDate) {
+			first = rb
+		}
+	}
+	return first
+}
+
+// goodreadsISBN wraps the ISBN as a spreadsheet formula, as Goodreads does,
+// so spreadsheet tools keep leading zeros.
+func goodreadsISBN(isbn string) string {
+	return `="` + isbn + `"`
+}
+
+// authorLastFirst converts "Machado de Assis" into "Assis, Machado de".
+func authorLastFirst(author string) string {
+	author = strings.TrimSpace(author)
+	if author == "" || strings.Contains(author, ",") {
+		return author
+	}
+	idx := strings.LastIndex(author, " ")
+	if idx < 0 {
+		return author
+	}
+	return author[idx+1:] + ", " + author[:idx]
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// jsonWriter streams entries as a single JSON array.
+type jsonWriter struct {
+	w       *bufio.Writer
+	written bool
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{w: bufio.NewWriter(w)}
+}
+
+func (jw *jsonWriter) Write(entry *Entry) error {
+	sep := ","
+	if !jw.written {
+		sep = "["
+		jw.written = true
+	}
+	if _, err := jw.w.WriteString(sep); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = jw.w.Write(data)
+	return err
+}
+
+func (jw *jsonWriter) Close() error {
+	closing := "]\n"
+	if !jw.written {
+		closing = "[]\n"
+	}
+	if _, err := jw.w.WriteString(closing); err != nil {
+		return err
+	}
+	return jw.w.Flush()
+}
+
+// ndjsonWriter streams entries as newline-delimited JSON, one entry per line.
+type ndjsonWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	bw := bufio.NewWriter(w)
+	return &ndjsonWriter{w: bw, enc: json.NewEncoder(bw)}
+}
+
+func (nw *ndjsonWriter) Write(entry *Entry) error {
+	return nw.enc.Encode(entry)
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nw.w.Flush()
+}
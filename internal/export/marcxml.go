@@ -0,0 +1,25 @@
+package export
+
+import (
+	"io"
+
+	"github.com/rfulgencio3/go-personal-library/internal/marc"
+)
+
+// marcXMLWriter writes each book as a MARCXML bibliographic record.
+// Reading records have no MARC counterpart and are left out.
+type marcXMLWriter struct {
+	w *marc.XMLWriter
+}
+
+func newMARCXMLWriter(w io.Writer) *marcXMLWriter {
+	return &marcXMLWriter{w: marc.NewXMLWriter(w)}
+}
+
+func (mw *marcXMLWriter) Write(entry *Entry) error {
+	return mw.w.Write(marc.RecordFromBook(entry.Book))
+}
+
+func (mw *marcXMLWriter) Close() error {
+	return mw.w.Close()
+}
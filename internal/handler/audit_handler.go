@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+)
+
+type AuditHandler struct {
+	auditUseCase usecase.AuditUseCase
+}
+
+func NewAuditHandler(au usecase.AuditUseCase) *AuditHandler {
+	return &AuditHandler{
+		auditUseCase: au,
+	}
+}
+
+func (h *AuditHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/audits", h.StartAudit).Methods("POST")
+	router.HandleFunc("/audits", h.GetAudits).Methods("GET")
+	router.HandleFunc("/audits/{id}", h.GetAudit).Methods("GET")
+	router.HandleFunc("/audits/{id}/scans", h.Scan).Methods("POST")
+	router.HandleFunc("/audits/{id}/close", h.CloseAudit).Methods("POST")
+}
+
+// StartAuditRequest names the location to audit.
+type StartAuditRequest struct {
+	LocationID string `json:"location_id"`
+	Notes      string `json:"notes,omitempty"`
+}
+
+// AuditInProgressResponse names the session already open for a location.
+type AuditInProgressResponse struct {
+	Message string `json:"message"`
+	AuditID string `json:"audit_id"`
+}
+
+// StartAudit godoc
+// @Summary Start an audit session
+// @Description Open a session to check the copies kept in a location, and of the locations inside it, against the catalogue. A location has at most one open session; starting another one answers 409 with the ID of the open session, so it can be resumed.
+// @Tags audits
+// @Accept json
+// @Produce json
+// @Param request body StartAuditRequest true "Location to audit"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} AuditInProgressResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /audits [post]
+func (h *AuditHandler) StartAudit(w http.ResponseWriter, r *http.Request) {
+	var req StartAuditRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.LocationID == "" {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	session, err := h.auditUseCase.StartAudit(req.LocationID, req.Notes)
+	if errors.Is(err, repository.ErrAuditInProgress) && session != nil {
+		respondWithJSON(w, http.StatusConflict, AuditInProgressResponse{Message: err.Error(), AuditID: session.ID})
+		return
+	}
+	if err != nil {
+		h.respondWithAuditError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: session})
+}
+
+// GetAudits godoc
+// @Summary Get the audit history
+// @Description List the audit sessions, the most recent first, without their scans and reports
+// @Tags audits
+// @Produce json
+// @Param location_id query string false "Only the sessions of this location"
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /audits [get]
+func (h *AuditHandler) GetAudits(w http.ResponseWriter, r *http.Request) {
+	sessions, err := h.auditUseCase.GetAudits(r.URL.Query().Get("location_id"))
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: sessions})
+}
+
+// GetAudit godoc
+// @Summary Get an audit session
+// @Description Retrieve a session with its scans and report. The report of an open session is provisional.
+// @Tags audits
+// @Produce json
+// @Param id path string true "Audit session ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /audits/{id} [get]
+func (h *AuditHandler) GetAudit(w http.ResponseWriter, r *http.Request) {
+	session, err := h.auditUseCase.GetAudit(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithAuditError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: session})
+}
+
+// ScanRequest carries scanned copy IDs, ISBNs or book IDs.
+type ScanRequest struct {
+	Codes []string `json:"codes"`
+}
+
+// Scan godoc
+// @Summary Scan copies during an audit
+// @Description Record scanned copy IDs, ISBNs or book IDs in an open session. Each scan is reported as found, misplaced, unknown or duplicate.
+// @Tags audits
+// @Accept json
+// @Produce json
+// @Param id path string true "Audit session ID"
+// @Param request body ScanRequest true "Scanned codes"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /audits/{id}/scans [post]
+func (h *AuditHandler) Scan(w http.ResponseWriter, r *http.Request) {
+	var req ScanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	scans, err := h.auditUseCase.Scan(mux.Vars(r)["id"], req.Codes)
+	if err != nil {
+		h.respondWithAuditError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: scans})
+}
+
+// CloseAudit godoc
+// @Summary Close an audit session
+// @Description Close a session and store its report of copies found, missing, misplaced and unknown codes
+// @Tags audits
+// @Produce json
+// @Param id path string true "Audit session ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /audits/{id}/close [post]
+func (h *AuditHandler) CloseAudit(w http.ResponseWriter, r *http.Request) {
+	session, err := h.auditUseCase.CloseAudit(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithAuditError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: session})
+}
+
+func (h *AuditHandler) respondWithAuditError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidScan):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrAuditNotFound):
+		respondWithError(w, http.StatusNotFound, "Audit session not found")
+	case errors.Is(err, repository.ErrLocationNotFound):
+		respondWithError(w, http.StatusNotFound, "Location not found")
+	case errors.Is(err, repository.ErrAuditClosed), errors.Is(err, repository.ErrAuditInProgress):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
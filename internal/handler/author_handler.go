@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+type AuthorHandler struct {
+	authorUseCase usecase.AuthorUseCase
+}
+
+func NewAuthorHandler(au usecase.AuthorUseCase) *AuthorHandler {
+	return &AuthorHandler{
+		authorUseCase: au,
+	}
+}
+
+func (h *AuthorHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/authors", h.CreateAuthor).Methods("POST")
+	router.HandleFunc("/authors", h.GetAllAuthors).Methods("GET")
+	router.HandleFunc("/authors/{id}", h.GetAuthorByID).Methods("GET")
+	router.HandleFunc("/authors/{id}", h.UpdateAuthor).Methods("PUT")
+	router.HandleFunc("/authors/{id}", h.DeleteAuthor).Methods("DELETE")
+	router.HandleFunc("/authors/{id}/books", h.GetAuthorBooks).Methods("GET")
+	router.HandleFunc("/authors/{id}/merge", h.MergeAuthors).Methods("POST")
+}
+
+// CreateAuthor godoc
+// @Summary Create a new author
+// @Description Add an author record. Books crediting the author's name, alternate spellings or aliases are linked to it.
+// @Tags authors
+// @Accept json
+// @Produce json
+// @Param author body domain.Author true "Author to add"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /authors [post]
+func (h *AuthorHandler) CreateAuthor(w http.ResponseWriter, r *http.Request) {
+	var author domain.Author
+	if err := json.NewDecoder(r.Body).Decode(&author); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.authorUseCase.CreateAuthor(&author); err != nil {
+		switch {
+		case errors.Is(err, validator.ErrInvalidAuthorData):
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Failed to create author")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: author})
+}
+
+// GetAllAuthors godoc
+// @Summary Get all authors
+// @Description Retrieve the authors sorted by name, optionally filtered by any of their names
+// @Tags authors
+// @Produce json
+// @Param name query string false "Name, alternate spelling or alias contains"
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /authors [get]
+func (h *AuthorHandler) GetAllAuthors(w http.ResponseWriter, r *http.Request) {
+	authors, err := h.authorUseCase.SearchAuthors(r.URL.Query().Get("name"))
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: authors})
+}
+
+// GetAuthorByID godoc
+// @Summary Get an author by ID
+// @Tags authors
+// @Produce json
+// @Param id path string true "Author ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /authors/{id} [get]
+func (h *AuthorHandler) GetAuthorByID(w http.ResponseWriter, r *http.Request) {
+	author, err := h.authorUseCase.GetAuthorByID(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithAuthorError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: author})
+}
+
+// UpdateAuthor godoc
+// @Summary Update an author by ID
+// @Description Replace an author record. Books crediting a newly added spelling or alias are linked to it.
+// @Tags authors
+// @Accept json
+// @Produce json
+// @Param id path string true "Author ID"
+// @Param author body domain.Author true "Updated author data"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /authors/{id} [put]
+func (h *AuthorHandler) UpdateAuthor(w http.ResponseWriter, r *http.Request) {
+	var author domain.Author
+	if err := json.NewDecoder(r.Body).Decode(&author); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	author.ID = mux.Vars(r)["id"]
+
+	if err := h.authorUseCase.UpdateAuthor(&author); err != nil {
+		h.respondWithAuthorError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: author})
+}
+
+// DeleteAuthor godoc
+// @Summary Delete an author by ID
+// @Description Remove an author record. Its books keep the contributor names but are no longer linked to it.
+// @Tags authors
+// @Param id path string true "Author ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /authors/{id} [delete]
+func (h *AuthorHandler) DeleteAuthor(w http.ResponseWriter, r *http.Request) {
+	if err := h.authorUseCase.DeleteAuthor(mux.Vars(r)["id"]); err != nil {
+		h.respondWithAuthorError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetAuthorBooks godoc
+// @Summary Get the books of an author
+// @Description Retrieve the books crediting the author in any role
+// @Tags authors
+// @Produce json
+// @Param id path string true "Author ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /authors/{id}/books [get]
+func (h *AuthorHandler) GetAuthorBooks(w http.ResponseWriter, r *http.Request) {
+	books, err := h.authorUseCase.GetAuthorBooks(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithAuthorError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: books})
+}
+
+// MergeAuthorsRequest lists the authors to fold into the author in the path.
+type MergeAuthorsRequest struct {
+	AuthorIDs []string `json:"author_ids"`
+}
+
+// MergeAuthors godoc
+// @Summary Merge authors
+// @Description Fold the listed authors into this one. Their names become alternate spellings, their books are relinked and they are deleted.
+// @Tags authors
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the author that is kept"
+// @Param request body MergeAuthorsRequest true "Authors to merge"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /authors/{id}/merge [post]
+func (h *AuthorHandler) MergeAuthors(w http.ResponseWriter, r *http.Request) {
+	var req MergeAuthorsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.AuthorIDs) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	author, err := h.authorUseCase.MergeAuthors(mux.Vars(r)["id"], req.AuthorIDs)
+	if err != nil {
+		h.respondWithAuthorError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: author})
+}
+
+func (h *AuthorHandler) respondWithAuthorError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, validator.ErrInvalidAuthorData), errors.Is(err, usecase.ErrMergeIntoSelf):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrAuthorNotFound):
+		respondWithError(w, http.StatusNotFound, "Author not found")
+	default:
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
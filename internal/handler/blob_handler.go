@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/storage"
+)
+
+// BlobHandler serves the signed links created by blob stores that have no
+// URLs of their own, such as the local filesystem store.
+type BlobHandler struct {
+	verifier storage.SignatureVerifier
+	store    storage.BlobStore
+}
+
+func NewBlobHandler(store storage.BlobStore) *BlobHandler {
+	verifier, _ := store.(storage.SignatureVerifier)
+	return &BlobHandler{
+		verifier: verifier,
+		store:    store,
+	}
+}
+
+func (h *BlobHandler) RegisterRoutes(router *mux.Router) {
+	// Links assinados do S3 apontam para o próprio serviço; não há rota a registrar.
+	if h.verifier == nil {
+		return
+	}
+	router.HandleFunc("/blobs/{key:.+}", h.GetBlob).Methods("GET")
+}
+
+// GetBlob godoc
+// @Summary Download a blob through a signed link
+// @Description Serve a stored object when the link's expires and signature parameters are valid
+// @Tags blobs
+// @Produce octet-stream
+// @Param key path string true "Blob key"
+// @Param expires query int true "Expiry as a Unix timestamp"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /blobs/{key} [get]
+func (h *BlobHandler) GetBlob(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["key"]
+	if err := h.verifier.VerifySignedURL(key, r.URL.Query()); err != nil {
+		respondWithError(w, http.StatusForbidden, "Invalid or expired link")
+		return
+	}
+
+	body, info, err := h.store.Get(r.Context(), key)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound), errors.Is(err, storage.ErrInvalidKey):
+			respondWithError(w, http.StatusNotFound, "Blob not found")
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	defer body.Close()
+
+	if seeker, ok := body.(io.ReadSeeker); ok {
+		if info.ContentType != "" {
+			w.Header().Set("Content-Type", info.ContentType)
+		}
+		http.ServeContent(w, r, "", info.ModTime, seeker)
+		return
+	}
+	w.Header().Set("Content-Type", info.ContentType)
+	io.Copy(w, body)
+}
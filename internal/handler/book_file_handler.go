@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/epub"
+	"github.com/rfulgencio3/go-personal-library/internal/pdf"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+// maxBookFileSize limits the size of uploaded e-book files.
+const maxBookFileSize = 100 << 20
+
+type BookFileHandler struct {
+	bookFileUseCase   usecase.BookFileUseCase
+	attachmentUseCase usecase.AttachmentUseCase
+}
+
+func NewBookFileHandler(bu usecase.BookFileUseCase, au usecase.AttachmentUseCase) *BookFileHandler {
+	return &BookFileHandler{
+		bookFileUseCase:   bu,
+		attachmentUseCase: au,
+	}
+}
+
+func (h *BookFileHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/books/from-file", h.CreateBookFromFile).Methods("POST")
+	router.HandleFunc("/books/{id}/attachments/{attachmentId}", h.GetAttachment).Methods("GET")
+}
+
+// CreateBookFromFile godoc
+// @Summary Create a book from an e-book file
+// @Description Create a book from the metadata of an EPUB or PDF file (up to 100 MB). EPUB page counts are estimated from the text and PDF page counts come from the page tree. An embedded EPUB cover becomes the book cover, and the file is kept as an attachment. Form fields, when sent, take precedence over the file's metadata.
+// @Tags books
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "EPUB or PDF file"
+// @Param title formData string false "Title"
+// @Param subtitle formData string false "Subtitle"
+// @Param author formData string false "Author"
+// @Param publisher formData string false "Publisher"
+// @Param comments formData string false "Comments"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/from-file [post]
+func (h *BookFileHandler) CreateBookFromFile(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBookFileSize+1<<20)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondWithError(w, http.StatusRequestEntityTooLarge, "File is too large")
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, "Missing file")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxBookFileSize+1))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid file")
+		return
+	}
+	if len(data) > maxBookFileSize {
+		respondWithError(w, http.StatusRequestEntityTooLarge, "File is too large")
+		return
+	}
+
+	draft := &domain.Book{
+		Title:     r.FormValue("title"),
+		Subtitle:  r.FormValue("subtitle"),
+		Author:    r.FormValue("author"),
+		Publisher: r.FormValue("publisher"),
+		Comments:  r.FormValue("comments"),
+	}
+	book, err := h.bookFileUseCase.CreateBookFromFile(filepath.Base(header.Filename), data, draft)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUnsupportedFile):
+			respondWithError(w, http.StatusUnsupportedMediaType, "Unsupported file format")
+		case errors.Is(err, epub.ErrNotEPUB), errors.Is(err, epub.ErrNoPackage), errors.Is(err, epub.ErrEntryTooBig),
+			errors.Is(err, pdf.ErrInvalidPDF):
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, validator.ErrInvalidBookData):
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, repository.ErrDuplicateISBN):
+			respondWithError(w, http.StatusConflict, err.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: book})
+}
+
+// GetAttachment godoc
+// @Summary Download an attachment
+// @Description Download a file kept with the book, such as the EPUB it was created from
+// @Tags books
+// @Produce octet-stream
+// @Param id path string true "Book ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/attachments/{attachmentId} [get]
+func (h *BookFileHandler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	body, attachment, err := h.attachmentUseCase.GetAttachment(vars["id"], vars["attachmentId"])
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrBookNotFound):
+			respondWithError(w, http.StatusNotFound, "Book not found")
+		case errors.Is(err, usecase.ErrAttachmentNotFound):
+			respondWithError(w, http.StatusNotFound, "Attachment not found")
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name}))
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	io.Copy(w, body)
+}
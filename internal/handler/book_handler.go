@@ -7,6 +7,9 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/isbn"
+	"github.com/rfulgencio3/go-personal-library/internal/metadata"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
 	"github.com/rfulgencio3/go-personal-library/internal/usecase"
 	"github.com/rfulgencio3/go-personal-library/internal/validator"
 )
@@ -23,9 +26,13 @@ func NewBookHandler(bu usecase.BookUseCase) *BookHandler {
 
 func (h *BookHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/books", h.CreateBook).Methods("POST")
+	router.HandleFunc("/books/from-isbn", h.CreateBookFromISBN).Methods("POST")
+	router.HandleFunc("/books/isbn/{isbn}", h.GetBookByISBN).Methods("GET")
 	router.HandleFunc("/books/{id}", h.GetBookByID).Methods("GET")
 	router.HandleFunc("/books/{id}", h.UpdateBook).Methods("PUT")
 	router.HandleFunc("/books/{id}", h.DeleteBook).Methods("DELETE")
+	router.HandleFunc("/books/{id}/disposal", h.DisposeBook).Methods("POST")
+	router.HandleFunc("/books/{id}/disposal", h.RestoreBook).Methods("DELETE")
 	router.HandleFunc("/books", h.GetAllBooks).Methods("GET")
 }
 
@@ -38,6 +45,7 @@ func (h *BookHandler) RegisterRoutes(router *mux.Router) {
 // @Param book body domain.Book true "Book to add"
 // @Success 201 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /books [post]
 func (h *BookHandler) CreateBook(w http.ResponseWriter, r *http.Request) {
@@ -49,7 +57,14 @@ func (h *BookHandler) CreateBook(w http.ResponseWriter, r *http.Request) {
 
 	// Chamar o caso de uso para criar o livro
 	if err := h.bookUseCase.CreateBook(&book); err != nil {
-		h.respondWithError(w, http.StatusInternalServerError, "Failed to create book")
+		switch {
+		case errors.Is(err, validator.ErrInvalidBookData):
+			h.respondWithError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, repository.ErrDuplicateISBN):
+			h.respondWithError(w, http.StatusConflict, err.Error())
+		default:
+			h.respondWithError(w, http.StatusInternalServerError, "Failed to create book")
+		}
 		return
 	}
 
@@ -57,6 +72,55 @@ func (h *BookHandler) CreateBook(w http.ResponseWriter, r *http.Request) {
 	h.respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: book})
 }
 
+// CreateBookFromISBNRequest carries the ISBN to look up and any book fields
+// the user already filled in, which are kept over the provider's data.
+type CreateBookFromISBNRequest struct {
+	ISBN string `json:"isbn"`
+	domain.Book
+}
+
+// CreateBookFromISBN godoc
+// @Summary Create a book from its ISBN
+// @Description Fetch title, author, publisher, page count and other metadata for the ISBN and create the book. Fields sent in the request are never overwritten.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param request body CreateBookFromISBNRequest true "ISBN and optional book fields"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /books/from-isbn [post]
+func (h *BookHandler) CreateBookFromISBN(w http.ResponseWriter, r *http.Request) {
+	var req CreateBookFromISBNRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	book := req.Book
+	if err := h.bookUseCase.CreateBookFromISBN(req.ISBN, &book); err != nil {
+		switch {
+		case errors.Is(err, isbn.ErrInvalidISBN):
+			h.respondWithError(w, http.StatusBadRequest, "Invalid ISBN")
+		case errors.Is(err, validator.ErrInvalidBookData):
+			h.respondWithError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, metadata.ErrNotFound):
+			h.respondWithError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, repository.ErrDuplicateISBN):
+			h.respondWithError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, usecase.ErrMetadataUnavailable):
+			h.respondWithError(w, http.StatusServiceUnavailable, err.Error())
+		default:
+			h.respondWithError(w, http.StatusInternalServerError, "Failed to create book")
+		}
+		return
+	}
+	h.respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: book})
+}
+
 // GetBookByID godoc
 // @Summary Get a book by ID
 // @Description Retrieve a book from the library by its ID
@@ -73,7 +137,41 @@ func (h *BookHandler) GetBookByID(w http.ResponseWriter, r *http.Request) {
 	id := vars["id"]
 	book, err := h.bookUseCase.GetBookByID(id)
 	if err != nil {
-		h.respondWithError(w, http.StatusNotFound, "Book not found")
+		switch {
+		case errors.Is(err, repository.ErrBookNotFound):
+			h.respondWithError(w, http.StatusNotFound, "Book not found")
+		default:
+			h.respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	h.respondWithJSON(w, http.StatusOK, SuccessResponse{Data: book})
+}
+
+// GetBookByISBN godoc
+// @Summary Get a book by ISBN
+// @Description Retrieve a book by its ISBN-10 or ISBN-13, with or without hyphens
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param isbn path string true "ISBN-10 or ISBN-13"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/isbn/{isbn} [get]
+func (h *BookHandler) GetBookByISBN(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	book, err := h.bookUseCase.GetBookByISBN(vars["isbn"])
+	if err != nil {
+		switch {
+		case errors.Is(err, isbn.ErrInvalidISBN):
+			h.respondWithError(w, http.StatusBadRequest, "Invalid ISBN")
+		case errors.Is(err, repository.ErrBookNotFound):
+			h.respondWithError(w, http.StatusNotFound, "Book not found")
+		default:
+			h.respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
 		return
 	}
 	h.respondWithJSON(w, http.StatusOK, SuccessResponse{Data: book})
@@ -90,6 +188,7 @@ func (h *BookHandler) GetBookByID(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /books/{id} [put]
 func (h *BookHandler) UpdateBook(w http.ResponseWriter, r *http.Request) {
@@ -102,9 +201,14 @@ func (h *BookHandler) UpdateBook(w http.ResponseWriter, r *http.Request) {
 	}
 	book.ID = id
 	if err := h.bookUseCase.UpdateBook(&book); err != nil {
-		if errors.Is(err, validator.ErrInvalidBookData) {
+		switch {
+		case errors.Is(err, validator.ErrInvalidBookData):
 			h.respondWithError(w, http.StatusBadRequest, err.Error())
-		} else {
+		case errors.Is(err, repository.ErrBookNotFound):
+			h.respondWithError(w, http.StatusNotFound, "Book not found")
+		case errors.Is(err, repository.ErrDuplicateISBN):
+			h.respondWithError(w, http.StatusConflict, err.Error())
+		default:
 			h.respondWithError(w, http.StatusInternalServerError, "Internal server error")
 		}
 		return
@@ -114,36 +218,128 @@ func (h *BookHandler) UpdateBook(w http.ResponseWriter, r *http.Request) {
 
 // DeleteBook godoc
 // @Summary Delete a book by ID
-// @Description Remove a book from the library by its ID
+// @Description Remove a book from the library by its ID, along with its copies, loan history, shelf entries and cover. Books with copies on loan cannot be deleted. To keep the reading history of a book given away, sold or lost, record its disposal instead.
 // @Tags books
 // @Accept json
 // @Produce json
 // @Param id path string true "Book ID"
 // @Success 204
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /books/{id} [delete]
 func (h *BookHandler) DeleteBook(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 	if err := h.bookUseCase.DeleteBook(id); err != nil {
-		h.respondWithError(w, http.StatusNotFound, "Book not found")
+		switch {
+		case errors.Is(err, repository.ErrBookNotFound):
+			h.respondWithError(w, http.StatusNotFound, "Book not found")
+		case errors.Is(err, usecase.ErrBookLent):
+			h.respondWithError(w, http.StatusConflict, err.Error())
+		default:
+			h.respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// DisposeBookRequest carries the status a book leaves the library with and
+// the details of its departure.
+type DisposeBookRequest struct {
+	Status string `json:"status"`
+	domain.Disposal
+}
+
+// DisposeBook godoc
+// @Summary Record that a book left the library
+// @Description Mark a book as sold, donated, lost or discarded, with the date (default now), the recipient and, for sales, the price. The book is hidden from the default listings and its copies are taken off their locations, but its reading records are kept.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param disposal body DisposeBookRequest true "Status and details of the departure"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/disposal [post]
+func (h *BookHandler) DisposeBook(w http.ResponseWriter, r *http.Request) {
+	var req DisposeBookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	book, err := h.bookUseCase.DisposeBook(mux.Vars(r)["id"], req.Status, &req.Disposal)
+	if err != nil {
+		switch {
+		case errors.Is(err, validator.ErrInvalidDisposalData):
+			h.respondWithError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, repository.ErrBookNotFound):
+			h.respondWithError(w, http.StatusNotFound, "Book not found")
+		case errors.Is(err, usecase.ErrBookLent):
+			h.respondWithError(w, http.StatusConflict, err.Error())
+		default:
+			h.respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	h.respondWithJSON(w, http.StatusOK, SuccessResponse{Data: book})
+}
+
+// RestoreBook godoc
+// @Summary Bring a book back into the library
+// @Description Undo the disposal of a book, which becomes owned again
+// @Tags books
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/disposal [delete]
+func (h *BookHandler) RestoreBook(w http.ResponseWriter, r *http.Request) {
+	book, err := h.bookUseCase.RestoreBook(mux.Vars(r)["id"])
+	if err != nil {
+		if errors.Is(err, repository.ErrBookNotFound) {
+			h.respondWithError(w, http.StatusNotFound, "Book not found")
+			return
+		}
+		h.respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	h.respondWithJSON(w, http.StatusOK, SuccessResponse{Data: book})
+}
+
 // GetAllBooks godoc
 // @Summary Get all books
-// @Description Retrieve all books from the library
+// @Description Retrieve the books in the library, optionally filtered. Books sold, donated, lost or discarded are left out unless a status is given.
 // @Tags books
 // @Accept json
 // @Produce json
+// @Param title query string false "Title contains"
+// @Param author query string false "Author contains"
+// @Param publisher query string false "Publisher contains"
+// @Param series query string false "Series name"
+// @Param tag query string false "Tag"
+// @Param contributor query string false "Contributor name contains"
+// @Param role query string false "Contributor role (author, translator, editor, illustrator, foreword)"
+// @Param author_id query string false "ID of a linked author record"
+// @Param series_id query string false "ID of a linked series record"
+// @Param work_id query string false "ID of the work the book is an edition of"
+// @Param status query string false "Status (owned, lent, sold, donated, lost, discarded), or all"
 // @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /books [get]
 func (h *BookHandler) GetAllBooks(w http.ResponseWriter, r *http.Request) {
-	books, err := h.bookUseCase.GetAllBooks()
+	filter, ok := bookFilterFromQuery(w, r)
+	if !ok {
+		return
+	}
+	books, err := h.bookUseCase.SearchBooks(filter)
 	if err != nil {
 		h.respondWithError(w, http.StatusInternalServerError, "Internal server error")
 		return
@@ -151,14 +347,39 @@ func (h *BookHandler) GetAllBooks(w http.ResponseWriter, r *http.Request) {
 	h.respondWithJSON(w, http.StatusOK, SuccessResponse{Data: books})
 }
 
+// bookFilterFromQuery reads the book filter from the query string. An
+// unknown role or status is answered with 400 and reported as not ok.
+func bookFilterFromQuery(w http.ResponseWriter, r *http.Request) (domain.BookFilter, bool) {
+	query := r.URL.Query()
+	filter := domain.BookFilter{
+		Title:       query.Get("title"),
+		Author:      query.Get("author"),
+		Publisher:   query.Get("publisher"),
+		Series:      query.Get("series"),
+		Tag:         query.Get("tag"),
+		Contributor: query.Get("contributor"),
+		Role:        query.Get("role"),
+		AuthorID:    query.Get("author_id"),
+		SeriesID:    query.Get("series_id"),
+		WorkID:      query.Get("work_id"),
+		Status:      query.Get("status"),
+	}
+	if filter.Role != "" && !domain.IsContributorRole(filter.Role) {
+		respondWithError(w, http.StatusBadRequest, "Invalid contributor role")
+		return filter, false
+	}
+	if filter.Status != "" && filter.Status != domain.BookStatusAll && !domain.IsBookStatus(filter.Status) {
+		respondWithError(w, http.StatusBadRequest, "Invalid book status")
+		return filter, false
+	}
+	return filter, true
+}
+
 // Helper functions
 func (h *BookHandler) respondWithJSON(w http.ResponseWriter, statusCode int, payload interface{}) {
-	response, _ := json.Marshal(payload)
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	w.Write(response)
+	respondWithJSON(w, statusCode, payload)
 }
 
 func (h *BookHandler) respondWithError(w http.ResponseWriter, statusCode int, message string) {
-	h.respondWithJSON(w, statusCode, ErrorResponse{Message: message})
+	respondWithError(w, statusCode, message)
 }
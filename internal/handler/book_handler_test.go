@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+var errDatabase = errors.New("connection reset")
+
+// errBookUseCase answers every call with err.
+type errBookUseCase struct {
+	usecase.BookUseCase
+	err error
+}
+
+func (uc errBookUseCase) GetBookByID(id string) (*domain.Book, error) {
+	if uc.err != nil {
+		return nil, uc.err
+	}
+	return &domain.Book{ID: id, Title: "Dom Casmurro"}, nil
+}
+
+func (uc errBookUseCase) UpdateBook(book *domain.Book) error {
+	return uc.err
+}
+
+func (uc errBookUseCase) DeleteBook(id string) error {
+	return uc.err
+}
+
+func (uc errBookUseCase) SearchBooks(filter domain.BookFilter) ([]*domain.Book, error) {
+	return nil, uc.err
+}
+
+func TestBookHandlerStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "get", method: http.MethodGet, path: "/books/b1", wantStatus: http.StatusOK},
+		{name: "get missing", method: http.MethodGet, path: "/books/b1", err: repository.ErrBookNotFound, wantStatus: http.StatusNotFound},
+		{name: "get failing", method: http.MethodGet, path: "/books/b1", err: errDatabase, wantStatus: http.StatusInternalServerError},
+		{name: "update", method: http.MethodPut, path: "/books/b1", body: `{"title":"Dom Casmurro"}`, wantStatus: http.StatusOK},
+		{name: "update bad body", method: http.MethodPut, path: "/books/b1", body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "update invalid", method: http.MethodPut, path: "/books/b1", body: `{}`, err: validator.ErrInvalidBookData, wantStatus: http.StatusBadRequest},
+		{name: "update missing", method: http.MethodPut, path: "/books/b1", body: `{}`, err: repository.ErrBookNotFound, wantStatus: http.StatusNotFound},
+		{name: "update duplicate", method: http.MethodPut, path: "/books/b1", body: `{}`, err: repository.ErrDuplicateISBN, wantStatus: http.StatusConflict},
+		{name: "update failing", method: http.MethodPut, path: "/books/b1", body: `{}`, err: errDatabase, wantStatus: http.StatusInternalServerError},
+		{name: "delete", method: http.MethodDelete, path: "/books/b1", wantStatus: http.StatusNoContent},
+		{name: "delete missing", method: http.MethodDelete, path: "/books/b1", err: repository.ErrBookNotFound, wantStatus: http.StatusNotFound},
+		{name: "delete lent", method: http.MethodDelete, path: "/books/b1", err: usecase.ErrBookLent, wantStatus: http.StatusConflict},
+		{name: "delete failing", method: http.MethodDelete, path: "/books/b1", err: errDatabase, wantStatus: http.StatusInternalServerError},
+		{name: "list bad role", method: http.MethodGet, path: "/books?role=editor-chefe", wantStatus: http.StatusBadRequest},
+		{name: "list bad status", method: http.MethodGet, path: "/books?status=burned", wantStatus: http.StatusBadRequest},
+		{name: "list failing", method: http.MethodGet, path: "/books", err: errDatabase, wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			NewBookHandler(errBookUseCase{err: tt.err}).RegisterRoutes(router)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("%s %s = %d, want %d: %s", tt.method, tt.path, rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/citation"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+)
+
+type CitationHandler struct {
+	citationUseCase usecase.CitationUseCase
+}
+
+func NewCitationHandler(cu usecase.CitationUseCase) *CitationHandler {
+	return &CitationHandler{
+		citationUseCase: cu,
+	}
+}
+
+func (h *CitationHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/books/{id}/cite", h.CiteBook).Methods("GET")
+	router.HandleFunc("/books/{id}/reference", h.GetReference).Methods("GET")
+	router.HandleFunc("/citations", h.CiteBooks).Methods("GET")
+}
+
+// CiteBook godoc
+// @Summary Cite a book
+// @Description Render a book as a BibTeX, RIS or CSL-JSON citation
+// @Tags citations
+// @Produce plain
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param format query string false "Citation format" Enums(bibtex, ris, csljson) default(bibtex)
+// @Success 200 {string} string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/cite [get]
+func (h *CitationHandler) CiteBook(w http.ResponseWriter, r *http.Request) {
+	format, ok := citationFormat(w, r)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.citationUseCase.CiteBook(mux.Vars(r)["id"], format, &buf); err != nil {
+		if errors.Is(err, repository.ErrBookNotFound) {
+			respondWithError(w, http.StatusNotFound, "Book not found")
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	w.Header().Set("Content-Type", citation.ContentType(format))
+	w.Write(buf.Bytes())
+}
+
+// CiteBooks godoc
+// @Summary Cite a list of books
+// @Description Render the books matching the filter as BibTeX, RIS or CSL-JSON for download
+// @Tags citations
+// @Produce plain
+// @Produce json
+// @Param format query string false "Citation format" Enums(bibtex, ris, csljson) default(bibtex)
+// @Param title query string false "Title contains"
+// @Param author query string false "Author contains"
+// @Param publisher query string false "Publisher contains"
+// @Param series query string false "Series name"
+// @Param tag query string false "Tag"
+// @Param contributor query string false "Contributor name contains"
+// @Param role query string false "Contributor role (author, translator, editor, illustrator, foreword)"
+// @Param status query string false "Status (owned, lent, sold, donated, lost, discarded), or all"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /citations [get]
+func (h *CitationHandler) CiteBooks(w http.ResponseWriter, r *http.Request) {
+	format, ok := citationFormat(w, r)
+	if !ok {
+		return
+	}
+	filter, ok := bookFilterFromQuery(w, r)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.citationUseCase.CiteBooks(filter, format, &buf); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	w.Header().Set("Content-Type", citation.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "library."+citation.FileExtension(format)))
+	w.Write(buf.Bytes())
+}
+
+// GetReference godoc
+// @Summary Get a formatted reference
+// @Description Format a book reference in a citation style, such as ABNT (NBR 6023), APA or MLA, as plain text or HTML
+// @Tags citations
+// @Produce plain
+// @Produce html
+// @Param id path string true "Book ID"
+// @Param style query string false "Reference style" Enums(abnt, apa, mla) default(abnt)
+// @Param output query string false "Output markup" Enums(text, html) default(text)
+// @Success 200 {string} string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/reference [get]
+func (h *CitationHandler) GetReference(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	style := query.Get("style")
+	if style == "" {
+		style = "abnt"
+	}
+
+	var markup citation.Markup = citation.PlainText{}
+	contentType := "text/plain; charset=utf-8"
+	switch query.Get("output") {
+	case "", "text":
+	case "html":
+		markup = citation.HTML{}
+		contentType = "text/html; charset=utf-8"
+	default:
+		respondWithError(w, http.StatusBadRequest, "Invalid output, use text or html")
+		return
+	}
+
+	reference, err := h.citationUseCase.FormatReference(mux.Vars(r)["id"], style, markup)
+	if err != nil {
+		switch {
+		case errors.Is(err, citation.ErrUnknownStyle):
+			respondWithError(w, http.StatusBadRequest, "Unknown style, use one of: "+strings.Join(citation.StyleNames(), ", "))
+		case errors.Is(err, repository.ErrBookNotFound):
+			respondWithError(w, http.StatusNotFound, "Book not found")
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write([]byte(reference))
+}
+
+// citationFormat reads the format query parameter, answering with 400 when it is unknown.
+func citationFormat(w http.ResponseWriter, r *http.Request) (citation.Format, bool) {
+	format := citation.Format(r.URL.Query().Get("format"))
+	if format == "" {
+		format = citation.FormatBibTeX
+	}
+	if !citation.IsSupported(format) {
+		respondWithError(w, http.StatusBadRequest, "Invalid citation format")
+		return "", false
+	}
+	return format, true
+}
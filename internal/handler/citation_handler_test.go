@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/citation"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+)
+
+// filterCitationUseCase records the filter it was asked to cite.
+type filterCitationUseCase struct {
+	usecase.CitationUseCase
+	filter *domain.BookFilter
+}
+
+func (uc filterCitationUseCase) CiteBooks(filter domain.BookFilter, format citation.Format, w io.Writer) error {
+	*uc.filter = filter
+	return nil
+}
+
+func TestCiteBooksFilter(t *testing.T) {
+	tests := []struct {
+		query      string
+		wantStatus int
+		wantFilter domain.BookFilter
+	}{
+		{query: "", wantStatus: http.StatusOK},
+		{query: "?format=ris&role=translator&status=sold", wantStatus: http.StatusOK, wantFilter: domain.BookFilter{Role: "translator", Status: "sold"}},
+		{query: "?format=endnote", wantStatus: http.StatusBadRequest},
+		{query: "?role=editor-chefe", wantStatus: http.StatusBadRequest},
+		{query: "?status=burned", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var filter domain.BookFilter
+			router := mux.NewRouter()
+			NewCitationHandler(filterCitationUseCase{filter: &filter}).RegisterRoutes(router)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/citations"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("GET /citations%s = %d, want %d: %s", tt.query, rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if filter != tt.wantFilter {
+				t.Errorf("filter = %+v, want %+v", filter, tt.wantFilter)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+type CopyHandler struct {
+	copyUseCase usecase.CopyUseCase
+}
+
+func NewCopyHandler(cu usecase.CopyUseCase) *CopyHandler {
+	return &CopyHandler{
+		copyUseCase: cu,
+	}
+}
+
+func (h *CopyHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/books/{id}/copies", h.AddCopy).Methods("POST")
+	router.HandleFunc("/books/{id}/copies", h.GetCopies).Methods("GET")
+	router.HandleFunc("/copies/{id}", h.GetCopy).Methods("GET")
+	router.HandleFunc("/copies/{id}", h.UpdateCopy).Methods("PUT")
+	router.HandleFunc("/copies/{id}", h.DeleteCopy).Methods("DELETE")
+}
+
+// AddCopy godoc
+// @Summary Add a copy of a book
+// @Description Register another physical copy of an edition. Its location is set through PUT /copies/{id}/location.
+// @Tags copies
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param copy body domain.Copy true "Condition, notes and acquisition of the copy"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/copies [post]
+func (h *CopyHandler) AddCopy(w http.ResponseWriter, r *http.Request) {
+	var c domain.Copy
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.copyUseCase.AddCopy(mux.Vars(r)["id"], &c); err != nil {
+		h.respondWithCopyError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: c})
+}
+
+// GetCopies godoc
+// @Summary Get the copies of a book
+// @Tags copies
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/copies [get]
+func (h *CopyHandler) GetCopies(w http.ResponseWriter, r *http.Request) {
+	copies, err := h.copyUseCase.GetCopies(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithCopyError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: copies})
+}
+
+// GetCopy godoc
+// @Summary Get a copy by ID
+// @Tags copies
+// @Produce json
+// @Param id path string true "Copy ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /copies/{id} [get]
+func (h *CopyHandler) GetCopy(w http.ResponseWriter, r *http.Request) {
+	c, err := h.copyUseCase.GetCopy(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithCopyError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: c})
+}
+
+// UpdateCopy godoc
+// @Summary Update a copy by ID
+// @Description Replace the condition, notes and acquisition of a copy
+// @Tags copies
+// @Accept json
+// @Produce json
+// @Param id path string true "Copy ID"
+// @Param copy body domain.Copy true "Condition, notes and acquisition of the copy"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /copies/{id} [put]
+func (h *CopyHandler) UpdateCopy(w http.ResponseWriter, r *http.Request) {
+	var c domain.Copy
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	c.ID = mux.Vars(r)["id"]
+
+	if err := h.copyUseCase.UpdateCopy(&c); err != nil {
+		h.respondWithCopyError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: c})
+}
+
+// DeleteCopy godoc
+// @Summary Delete a copy by ID
+// @Tags copies
+// @Param id path string true "Copy ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /copies/{id} [delete]
+func (h *CopyHandler) DeleteCopy(w http.ResponseWriter, r *http.Request) {
+	if err := h.copyUseCase.DeleteCopy(mux.Vars(r)["id"]); err != nil {
+		h.respondWithCopyError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CopyHandler) respondWithCopyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, validator.ErrInvalidCopyData):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrCopyNotFound):
+		respondWithError(w, http.StatusNotFound, "Copy not found")
+	case errors.Is(err, repository.ErrBookNotFound):
+		respondWithError(w, http.StatusNotFound, "Book not found")
+	default:
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+)
+
+// errSeekBackwards is returned when a streamed cover is asked to rewind.
+var errSeekBackwards = errors.New("cannot seek backwards in a streamed blob")
+
+// maxCoverSize limits the size of uploaded cover images.
+const maxCoverSize = 10 << 20
+
+// coverCacheControl lets browsers keep covers for a day; the ETag changes
+// whenever a new cover is uploaded.
+const coverCacheControl = "public, max-age=86400"
+
+type CoverHandler struct {
+	coverUseCase usecase.CoverUseCase
+}
+
+func NewCoverHandler(cu usecase.CoverUseCase) *CoverHandler {
+	return &CoverHandler{
+		coverUseCase: cu,
+	}
+}
+
+func (h *CoverHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/books/{id}/cover", h.UploadCover).Methods("PUT")
+	router.HandleFunc("/books/{id}/cover", h.GetCover).Methods("GET")
+	router.HandleFunc("/books/{id}/cover", h.DeleteCover).Methods("DELETE")
+}
+
+// UploadCover godoc
+// @Summary Upload a book cover
+// @Description Store a JPEG, PNG or WebP image (up to 10 MB) as the book cover. The type is detected from the content. Every cover, WebP included, gets small, medium and large JPEG thumbnails.
+// @Tags covers
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param cover formData file true "Cover image"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/cover [put]
+func (h *CoverHandler) UploadCover(w http.ResponseWriter, r *http.Request) {
+	// Margem para os cabeçalhos do multipart além do próprio arquivo.
+	r.Body = http.MaxBytesReader(w, r.Body, maxCoverSize+1<<20)
+	file, _, err := r.FormFile("cover")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondWithError(w, http.StatusRequestEntityTooLarge, "Cover image is too large")
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, "Missing cover file")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxCoverSize+1))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid cover file")
+		return
+	}
+	if len(data) > maxCoverSize {
+		respondWithError(w, http.StatusRequestEntityTooLarge, "Cover image is too large")
+		return
+	}
+
+	cover, err := h.coverUseCase.SetCover(mux.Vars(r)["id"], data)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrBookNotFound):
+			respondWithError(w, http.StatusNotFound, "Book not found")
+		case errors.Is(err, usecase.ErrUnsupportedImage):
+			respondWithError(w, http.StatusUnsupportedMediaType, err.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: cover})
+}
+
+// GetCover godoc
+// @Summary Get a book cover
+// @Description Serve the cover image with caching headers. Thumbnails are JPEG; covers stored before their thumbnails existed return the original image for every size.
+// @Tags covers
+// @Produce image/jpeg
+// @Produce image/png
+// @Produce image/webp
+// @Param id path string true "Book ID"
+// @Param size query string false "Cover size" Enums(original, small, medium, large)
+// @Success 200 {file} file
+// @Success 304 "Not modified"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/cover [get]
+func (h *CoverHandler) GetCover(w http.ResponseWriter, r *http.Request) {
+	size := r.URL.Query().Get("size")
+	body, cover, err := h.coverUseCase.GetCover(mux.Vars(r)["id"], size)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCoverSize):
+			respondWithError(w, http.StatusBadRequest, "Size must be one of: "+strings.Join(h.coverUseCase.CoverSizes(), ", "))
+		case errors.Is(err, repository.ErrBookNotFound):
+			respondWithError(w, http.StatusNotFound, "Book not found")
+		case errors.Is(err, usecase.ErrCoverNotFound):
+			respondWithError(w, http.StatusNotFound, "Cover not found")
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	defer body.Close()
+
+	content, ok := body.(io.ReadSeeker)
+	if !ok {
+		content = &sizedReader{r: body, size: cover.Size}
+	}
+
+	if size == "" {
+		size = "original"
+	}
+	w.Header().Set("Content-Type", cover.ContentType)
+	w.Header().Set("Cache-Control", coverCacheControl)
+	w.Header().Set("ETag", `"`+cover.Checksum[:16]+"-"+size+`"`)
+	// ServeContent responde 304 quando If-None-Match ou If-Modified-Since coincidem.
+	http.ServeContent(w, r, "", cover.UpdatedAt, content)
+}
+
+// sizedReader lets http.ServeContent stream a blob that cannot seek, such as
+// an S3 response body, without reading it into memory. ServeContent only
+// seeks to the end to learn the size and then forward to the start of the
+// requested range, so seeking forward discards bytes and seeking backwards
+// fails.
+type sizedReader struct {
+	r    io.Reader
+	size int64
+	read int64
+}
+
+func (s *sizedReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	s.read += int64(n)
+	return n, err
+}
+
+func (s *sizedReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekEnd:
+		// Só informa o tamanho; a leitura seguinte recomeça do ponto atual.
+		return s.size + offset, nil
+	case io.SeekCurrent:
+		offset += s.read
+	}
+	if offset < s.read {
+		return s.read, errSeekBackwards
+	}
+	n, err := io.CopyN(io.Discard, s.r, offset-s.read)
+	s.read += n
+	return s.read, err
+}
+
+// DeleteCover godoc
+// @Summary Delete a book cover
+// @Description Remove the cover image and its thumbnails
+// @Tags covers
+// @Param id path string true "Book ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/cover [delete]
+func (h *CoverHandler) DeleteCover(w http.ResponseWriter, r *http.Request) {
+	if err := h.coverUseCase.DeleteCover(mux.Vars(r)["id"]); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrBookNotFound):
+			respondWithError(w, http.StatusNotFound, "Book not found")
+		case errors.Is(err, usecase.ErrCoverNotFound):
+			respondWithError(w, http.StatusNotFound, "Cover not found")
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
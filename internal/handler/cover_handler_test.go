@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+)
+
+// streamCoverUseCase serves a cover whose body cannot seek, like an S3
+// response body.
+type streamCoverUseCase struct {
+	usecase.CoverUseCase
+	data string
+}
+
+func (uc streamCoverUseCase) GetCover(bookID, size string) (io.ReadCloser, *domain.Cover, error) {
+	cover := &domain.Cover{
+		ContentType: "image/jpeg",
+		Size:        int64(len(uc.data)),
+		Checksum:    "0123456789abcdef0123",
+		UpdatedAt:   time.Date(2024, 10, 10, 14, 0, 0, 0, time.UTC),
+	}
+	return io.NopCloser(struct{ io.Reader }{strings.NewReader(uc.data)}), cover, nil
+}
+
+func TestGetCoverStreams(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		value      string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "whole cover", wantStatus: http.StatusOK, wantBody: "capa de Dom Casmurro"},
+		{name: "range", header: "Range", value: "bytes=8-10", wantStatus: http.StatusPartialContent, wantBody: "Dom"},
+		{name: "unchanged", header: "If-None-Match", value: `"0123456789abcdef-original"`, wantStatus: http.StatusNotModified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			NewCoverHandler(streamCoverUseCase{data: "capa de Dom Casmurro"}).RegisterRoutes(router)
+
+			req := httptest.NewRequest(http.MethodGet, "/books/b1/cover", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus || rec.Body.String() != tt.wantBody {
+				t.Errorf("GET = %d %q, want %d %q", rec.Code, rec.Body.String(), tt.wantStatus, tt.wantBody)
+			}
+		})
+	}
+}
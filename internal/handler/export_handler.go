@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/export"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+)
+
+type ExportHandler struct {
+	exportUseCase usecase.ExportUseCase
+}
+
+func NewExportHandler(eu usecase.ExportUseCase) *ExportHandler {
+	return &ExportHandler{
+		exportUseCase: eu,
+	}
+}
+
+func (h *ExportHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/export", h.ExportLibrary).Methods("GET")
+}
+
+// ExportLibrary godoc
+// @Summary Export the library
+// @Description Download every book joined with its read book records as CSV, JSON, NDJSON, Goodreads-compatible CSV or MARCXML
+// @Tags export
+// @Produce text/csv
+// @Produce json
+// @Produce application/x-ndjson
+// @Param format query string false "Export format" Enums(csv, json, ndjson, goodreads, marcxml) default(json)
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /export [get]
+func (h *ExportHandler) ExportLibrary(w http.ResponseWriter, r *http.Request) {
+	format := export.Format(r.URL.Query().Get("format"))
+	if format == "" {
+		format = export.FormatJSON
+	}
+	if !export.IsSupported(format) {
+		respondWithError(w, http.StatusBadRequest, "Invalid export format")
+		return
+	}
+
+	filename := fmt.Sprintf("library-%s-%s.%s", format, time.Now().Format("20060102"), export.FileExtension(format))
+	w.Header().Set("Content-Type", export.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Cache-Control", "no-store")
+
+	// Os dados são enviados à medida que são lidos do banco, portanto só é
+	// possível responder com erro se nada tiver sido escrito ainda.
+	sw := &startedWriter{ResponseWriter: w}
+	if err := h.exportUseCase.Export(format, sw); err != nil {
+		log.Printf("Erro ao exportar a biblioteca: %v", err)
+		if !sw.started {
+			w.Header().Del("Content-Disposition")
+			respondWithError(w, http.StatusInternalServerError, "Failed to export library")
+		}
+	}
+}
+
+// startedWriter records whether any part of the response body has been sent.
+type startedWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (sw *startedWriter) Write(p []byte) (int, error) {
+	sw.started = true
+	return sw.ResponseWriter.Write(p)
+}
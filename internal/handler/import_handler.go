@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/marc"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+)
+
+// maxImportSize limits the size of uploaded import files.
+const maxImportSize = 32 << 20
+
+type ImportHandler struct {
+	importUseCase usecase.ImportUseCase
+}
+
+func NewImportHandler(iu usecase.ImportUseCase) *ImportHandler {
+	return &ImportHandler{
+		importUseCase: iu,
+	}
+}
+
+func (h *ImportHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/import/marc", h.ImportMARC).Methods("POST")
+}
+
+// ImportMARC godoc
+// @Summary Import MARC records
+// @Description Create books from ISO 2709 MARC21 or MARCXML records sent as the request body. Records matching an existing ISBN or control number update that book.
+// @Tags import
+// @Accept application/marc
+// @Accept application/marcxml+xml
+// @Produce json
+// @Param records body string true "ISO 2709 or MARCXML records"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /import/marc [post]
+func (h *ImportHandler) ImportMARC(w http.ResponseWriter, r *http.Request) {
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+	result, err := h.importUseCase.ImportMARC(body)
+	if err != nil {
+		var syntaxErr *xml.SyntaxError
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			respondWithError(w, http.StatusRequestEntityTooLarge, "Import file too large")
+		case errors.As(err, &syntaxErr), errors.Is(err, marc.ErrInvalidRecord), errors.Is(err, io.ErrUnexpectedEOF):
+			respondWithError(w, http.StatusBadRequest, "Invalid MARC data")
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: result})
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/marc"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+)
+
+// readingImportUseCase reads the whole body, like the MARC parser, and
+// then fails with err.
+type readingImportUseCase struct {
+	usecase.ImportUseCase
+	err error
+}
+
+func (uc readingImportUseCase) ImportMARC(r io.Reader) (*usecase.ImportResult, error) {
+	if _, err := io.ReadAll(r); err != nil {
+		return nil, err
+	}
+	if uc.err != nil {
+		return nil, uc.err
+	}
+	return &usecase.ImportResult{}, nil
+}
+
+func TestImportMARCStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "imported", body: "<collection/>", wantStatus: http.StatusOK},
+		{name: "bad XML", body: "<collection>", err: &xml.SyntaxError{Msg: "unexpected EOF", Line: 1}, wantStatus: http.StatusBadRequest},
+		{name: "bad record", body: "00024", err: fmt.Errorf("%w: record too short", marc.ErrInvalidRecord), wantStatus: http.StatusBadRequest},
+		{name: "cut short", body: "00100", err: io.ErrUnexpectedEOF, wantStatus: http.StatusBadRequest},
+		{name: "database down", body: "<collection/>", err: errDatabase, wantStatus: http.StatusInternalServerError},
+		{name: "too large", body: strings.Repeat("x", maxImportSize+1), wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			NewImportHandler(readingImportUseCase{err: tt.err}).RegisterRoutes(router)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/import/marc", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("POST /import/marc = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+type LoanHandler struct {
+	loanUseCase usecase.LoanUseCase
+}
+
+func NewLoanHandler(lu usecase.LoanUseCase) *LoanHandler {
+	return &LoanHandler{
+		loanUseCase: lu,
+	}
+}
+
+func (h *LoanHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/loans", h.Lend).Methods("POST")
+	router.HandleFunc("/loans", h.GetActiveLoans).Methods("GET")
+	router.HandleFunc("/loans/overdue", h.GetOverdueLoans).Methods("GET")
+	router.HandleFunc("/loans/{id}", h.GetLoan).Methods("GET")
+	router.HandleFunc("/loans/{id}/return", h.Return).Methods("POST")
+	router.HandleFunc("/borrowers/{name}/loans", h.GetBorrowerLoans).Methods("GET")
+}
+
+// Lend godoc
+// @Summary Lend a book
+// @Description Lend a copy of a book to someone until a due date. Without copy_id, any copy that is not lent is used; lent_at defaults to now. The book shows as lent while every copy is lent, and books that left the library cannot be lent.
+// @Tags loans
+// @Accept json
+// @Produce json
+// @Param loan body domain.Loan true "Book or copy, borrower, due date and notes; status and returned_at are ignored"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /loans [post]
+func (h *LoanHandler) Lend(w http.ResponseWriter, r *http.Request) {
+	var loan domain.Loan
+	if err := json.NewDecoder(r.Body).Decode(&loan); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.loanUseCase.Lend(&loan); err != nil {
+		h.respondWithLoanError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: loan})
+}
+
+// GetActiveLoans godoc
+// @Summary Get active loans
+// @Description Retrieve the books currently lent, the soonest due first
+// @Tags loans
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /loans [get]
+func (h *LoanHandler) GetActiveLoans(w http.ResponseWriter, r *http.Request) {
+	loans, err := h.loanUseCase.GetActiveLoans()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: loans})
+}
+
+// GetOverdueLoans godoc
+// @Summary Get overdue loans
+// @Description Retrieve the books lent past their due date with the days overdue, the most overdue first
+// @Tags loans
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /loans/overdue [get]
+func (h *LoanHandler) GetOverdueLoans(w http.ResponseWriter, r *http.Request) {
+	loans, err := h.loanUseCase.GetOverdueLoans()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: loans})
+}
+
+// GetLoan godoc
+// @Summary Get a loan by ID
+// @Tags loans
+// @Produce json
+// @Param id path string true "Loan ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /loans/{id} [get]
+func (h *LoanHandler) GetLoan(w http.ResponseWriter, r *http.Request) {
+	loan, err := h.loanUseCase.GetLoan(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithLoanError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: loan})
+}
+
+// Return godoc
+// @Summary Return a lent book
+// @Description Mark a loan as returned today
+// @Tags loans
+// @Produce json
+// @Param id path string true "Loan ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /loans/{id}/return [post]
+func (h *LoanHandler) Return(w http.ResponseWriter, r *http.Request) {
+	loan, err := h.loanUseCase.Return(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithLoanError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: loan})
+}
+
+// GetBorrowerLoans godoc
+// @Summary Get the loans of a borrower
+// @Description Retrieve every loan of a borrower, ignoring case, the most recent first
+// @Tags loans
+// @Produce json
+// @Param name path string true "Borrower name"
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /borrowers/{name}/loans [get]
+func (h *LoanHandler) GetBorrowerLoans(w http.ResponseWriter, r *http.Request) {
+	loans, err := h.loanUseCase.GetBorrowerLoans(mux.Vars(r)["name"])
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: loans})
+}
+
+func (h *LoanHandler) respondWithLoanError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, validator.ErrInvalidLoanData):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrLoanNotFound):
+		respondWithError(w, http.StatusNotFound, "Loan not found")
+	case errors.Is(err, repository.ErrBookNotFound):
+		respondWithError(w, http.StatusNotFound, "Book not found")
+	case errors.Is(err, usecase.ErrBookUnavailable), errors.Is(err, usecase.ErrBookDisposed),
+		errors.Is(err, repository.ErrCopyOnLoan), errors.Is(err, repository.ErrLoanReturned):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
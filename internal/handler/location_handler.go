@@ -0,0 +1,316 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+type LocationHandler struct {
+	locationUseCase usecase.LocationUseCase
+}
+
+func NewLocationHandler(lu usecase.LocationUseCase) *LocationHandler {
+	return &LocationHandler{
+		locationUseCase: lu,
+	}
+}
+
+func (h *LocationHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/locations", h.CreateLocation).Methods("POST")
+	router.HandleFunc("/locations", h.GetLocationTree).Methods("GET")
+	router.HandleFunc("/locations/where", h.FindBooks).Methods("GET")
+	router.HandleFunc("/locations/{id}", h.GetLocation).Methods("GET")
+	router.HandleFunc("/locations/{id}", h.UpdateLocation).Methods("PUT")
+	router.HandleFunc("/locations/{id}", h.DeleteLocation).Methods("DELETE")
+	router.HandleFunc("/locations/{id}/copies", h.GetLocationCopies).Methods("GET")
+	router.HandleFunc("/locations/{id}/relocate", h.RelocateCopies).Methods("POST")
+	router.HandleFunc("/books/{id}/location", h.WhereIs).Methods("GET")
+	router.HandleFunc("/copies/{id}/location", h.PlaceCopy).Methods("PUT")
+	router.HandleFunc("/copies/{id}/location", h.UnplaceCopy).Methods("DELETE")
+}
+
+// CreateLocation godoc
+// @Summary Create a new location
+// @Description Add a house, room, bookcase or shelf, optionally inside a location of an outer kind
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Param location body domain.Location true "Location to add; ancestors are ignored"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /locations [post]
+func (h *LocationHandler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	var location domain.Location
+	if err := json.NewDecoder(r.Body).Decode(&location); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.locationUseCase.CreateLocation(&location); err != nil {
+		h.respondWithLocationError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: location})
+}
+
+// GetLocationTree godoc
+// @Summary Get the location tree
+// @Description Retrieve the outermost locations with the locations inside them
+// @Tags locations
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /locations [get]
+func (h *LocationHandler) GetLocationTree(w http.ResponseWriter, r *http.Request) {
+	tree, err := h.locationUseCase.GetLocationTree()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: tree})
+}
+
+// FindBooks godoc
+// @Summary Find where books are
+// @Description Search books with the same filters as GET /books and tell where each of their copies is kept
+// @Tags locations
+// @Produce json
+// @Param title query string false "Title contains"
+// @Param author query string false "Author contains"
+// @Param publisher query string false "Publisher contains"
+// @Param series query string false "Series name"
+// @Param tag query string false "Tag, including the tags below it"
+// @Param contributor query string false "Contributor name contains"
+// @Param role query string false "Contributor role (author, translator, editor, illustrator, foreword)"
+// @Param author_id query string false "ID of a linked author record"
+// @Param series_id query string false "ID of a linked series record"
+// @Param work_id query string false "ID of the work the book is an edition of"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /locations/where [get]
+func (h *LocationHandler) FindBooks(w http.ResponseWriter, r *http.Request) {
+	filter, ok := bookFilterFromQuery(w, r)
+	if !ok {
+		return
+	}
+	whereabouts, err := h.locationUseCase.FindBooks(filter)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: whereabouts})
+}
+
+// GetLocation godoc
+// @Summary Get a location by ID
+// @Description Retrieve a location with the path of locations leading to it
+// @Tags locations
+// @Produce json
+// @Param id path string true "Location ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /locations/{id} [get]
+func (h *LocationHandler) GetLocation(w http.ResponseWriter, r *http.Request) {
+	details, err := h.locationUseCase.GetLocation(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithLocationError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: details})
+}
+
+// UpdateLocation godoc
+// @Summary Update a location by ID
+// @Description Replace a location. Changing its parent moves it with everything inside it, copies included.
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Param id path string true "Location ID"
+// @Param location body domain.Location true "Updated location data; ancestors are ignored"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /locations/{id} [put]
+func (h *LocationHandler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	var location domain.Location
+	if err := json.NewDecoder(r.Body).Decode(&location); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	location.ID = mux.Vars(r)["id"]
+
+	if err := h.locationUseCase.UpdateLocation(&location); err != nil {
+		h.respondWithLocationError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: location})
+}
+
+// DeleteLocation godoc
+// @Summary Delete a location by ID
+// @Description Remove a location that holds neither copies nor other locations
+// @Tags locations
+// @Param id path string true "Location ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /locations/{id} [delete]
+func (h *LocationHandler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	if err := h.locationUseCase.DeleteLocation(mux.Vars(r)["id"]); err != nil {
+		h.respondWithLocationError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetLocationCopies godoc
+// @Summary Get the copies in a location
+// @Description Retrieve the copies kept in a location or in any location inside it, ordered by location and position
+// @Tags locations
+// @Produce json
+// @Param id path string true "Location ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /locations/{id}/copies [get]
+func (h *LocationHandler) GetLocationCopies(w http.ResponseWriter, r *http.Request) {
+	whereabouts, err := h.locationUseCase.GetLocationCopies(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithLocationError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: whereabouts})
+}
+
+// RelocateRequest names the location that receives the copies.
+type RelocateRequest struct {
+	To string `json:"to"`
+}
+
+// RelocateResponse counts the copies moved by a relocation.
+type RelocateResponse struct {
+	Moved int `json:"moved"`
+}
+
+// RelocateCopies godoc
+// @Summary Move every copy of a location
+// @Description Move all the copies kept in a location, such as a whole shelf, to another location, keeping their positions
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Param id path string true "Location the copies are in"
+// @Param request body RelocateRequest true "Location the copies go to"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /locations/{id}/relocate [post]
+func (h *LocationHandler) RelocateCopies(w http.ResponseWriter, r *http.Request) {
+	var req RelocateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.To == "" {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	moved, err := h.locationUseCase.RelocateCopies(mux.Vars(r)["id"], req.To)
+	if err != nil {
+		h.respondWithLocationError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: RelocateResponse{Moved: moved}})
+}
+
+// WhereIs godoc
+// @Summary Find where a book is
+// @Description Retrieve where each copy of a book is kept, with the path leading to it and a readable label
+// @Tags locations
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/location [get]
+func (h *LocationHandler) WhereIs(w http.ResponseWriter, r *http.Request) {
+	whereabouts, err := h.locationUseCase.WhereIs(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithLocationError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: whereabouts})
+}
+
+// PlaceCopy godoc
+// @Summary Place a copy in a location
+// @Description Set where a copy is kept, optionally at a position counted from the left of the shelf
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Param id path string true "Copy ID"
+// @Param location body domain.BookLocation true "Location and position"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /copies/{id}/location [put]
+func (h *LocationHandler) PlaceCopy(w http.ResponseWriter, r *http.Request) {
+	var location domain.BookLocation
+	if err := json.NewDecoder(r.Body).Decode(&location); err != nil || location.LocationID == "" {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	whereabouts, err := h.locationUseCase.PlaceCopy(mux.Vars(r)["id"], location)
+	if err != nil {
+		h.respondWithLocationError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: whereabouts})
+}
+
+// UnplaceCopy godoc
+// @Summary Remove the location of a copy
+// @Tags locations
+// @Param id path string true "Copy ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /copies/{id}/location [delete]
+func (h *LocationHandler) UnplaceCopy(w http.ResponseWriter, r *http.Request) {
+	if err := h.locationUseCase.UnplaceCopy(mux.Vars(r)["id"]); err != nil {
+		h.respondWithLocationError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *LocationHandler) respondWithLocationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, validator.ErrInvalidLocationData), errors.Is(err, usecase.ErrLocationCycle),
+		errors.Is(err, usecase.ErrRelocateToSelf):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrLocationNotFound):
+		respondWithError(w, http.StatusNotFound, "Location not found")
+	case errors.Is(err, repository.ErrBookNotFound):
+		respondWithError(w, http.StatusNotFound, "Book not found")
+	case errors.Is(err, repository.ErrCopyNotFound):
+		respondWithError(w, http.StatusNotFound, "Copy not found")
+	case errors.Is(err, usecase.ErrBookNotPlaced):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, usecase.ErrLocationInUse):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -2,11 +2,13 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
 	"github.com/rfulgencio3/go-personal-library/internal/domain"
 	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
 )
 
 type ReadBookHandler struct {
@@ -45,7 +47,11 @@ func (h *ReadBookHandler) CreateReadBook(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := h.usecase.CreateReadBook(&readBook); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		status := http.StatusInternalServerError
+		if errors.Is(err, validator.ErrInvalidReadBookData) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
 		return
 	}
 
@@ -123,7 +129,11 @@ func (h *ReadBookHandler) UpdateReadBook(w http.ResponseWriter, r *http.Request)
 
 	readBook.ID = id
 	if err := h.usecase.UpdateReadBook(&readBook); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		status := http.StatusInternalServerError
+		if errors.Is(err, validator.ErrInvalidReadBookData) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
 		return
 	}
 
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+type ReportHandler struct {
+	reportUseCase usecase.ReportUseCase
+}
+
+func NewReportHandler(ru usecase.ReportUseCase) *ReportHandler {
+	return &ReportHandler{
+		reportUseCase: ru,
+	}
+}
+
+func (h *ReportHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/reports/collection-value", h.CollectionValue).Methods("GET")
+	router.HandleFunc("/reports/departures", h.Departures).Methods("GET")
+	router.HandleFunc("/exchange-rates", h.GetExchangeRates).Methods("GET")
+	router.HandleFunc("/exchange-rates/{currency}", h.SetExchangeRate).Methods("PUT")
+	router.HandleFunc("/exchange-rates/{currency}", h.DeleteExchangeRate).Methods("DELETE")
+}
+
+// CollectionValue godoc
+// @Summary Get the value of the collection
+// @Description Total the prices of the copies purchased per year, per store and per currency, converted to the base currency with the exchange rates kept
+// @Tags reports
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reports/collection-value [get]
+func (h *ReportHandler) CollectionValue(w http.ResponseWriter, r *http.Request) {
+	value, err := h.reportUseCase.CollectionValue()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: value})
+}
+
+// Departures godoc
+// @Summary Get the books that left the library
+// @Description List the books sold, donated, lost or discarded by year of departure, the most recent first, with the count per status and the proceeds of the sales per currency. Books without a departure date come last, under year 0
+// @Tags reports
+// @Produce json
+// @Param year query int false "Year of departure"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reports/departures [get]
+func (h *ReportHandler) Departures(w http.ResponseWriter, r *http.Request) {
+	year := 0
+	if value := r.URL.Query().Get("year"); value != "" {
+		var err error
+		if year, err = strconv.Atoi(value); err != nil || year <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid year")
+			return
+		}
+	}
+
+	departures, err := h.reportUseCase.Departures(year)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: departures})
+}
+
+// GetExchangeRates godoc
+// @Summary Get the exchange rates
+// @Description Retrieve the value of one unit of each currency in the base currency
+// @Tags reports
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /exchange-rates [get]
+func (h *ReportHandler) GetExchangeRates(w http.ResponseWriter, r *http.Request) {
+	rates, err := h.reportUseCase.GetExchangeRates()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: rates})
+}
+
+// ExchangeRateRequest carries the value of one unit of a currency in the
+// base currency.
+type ExchangeRateRequest struct {
+	Rate float64 `json:"rate"`
+}
+
+// SetExchangeRate godoc
+// @Summary Set an exchange rate
+// @Description Create or replace the value of one unit of a currency in the base currency
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param currency path string true "Three-letter currency code"
+// @Param request body ExchangeRateRequest true "Rate"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /exchange-rates/{currency} [put]
+func (h *ReportHandler) SetExchangeRate(w http.ResponseWriter, r *http.Request) {
+	var req ExchangeRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	rate := domain.ExchangeRate{Currency: mux.Vars(r)["currency"], Rate: req.Rate}
+	if err := h.reportUseCase.SetExchangeRate(&rate); err != nil {
+		h.respondWithReportError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: rate})
+}
+
+// DeleteExchangeRate godoc
+// @Summary Delete an exchange rate
+// @Tags reports
+// @Param currency path string true "Three-letter currency code"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /exchange-rates/{currency} [delete]
+func (h *ReportHandler) DeleteExchangeRate(w http.ResponseWriter, r *http.Request) {
+	if err := h.reportUseCase.DeleteExchangeRate(mux.Vars(r)["currency"]); err != nil {
+		h.respondWithReportError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ReportHandler) respondWithReportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, validator.ErrInvalidExchangeRate):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrExchangeRateNotFound):
+		respondWithError(w, http.StatusNotFound, "Exchange rate not found")
+	default:
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
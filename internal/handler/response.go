@@ -1,5 +1,10 @@
 package handler
 
+import (
+	"encoding/json"
+	"net/http"
+)
+
 type SuccessResponse struct {
 	Data interface{} `json:"data"`
 }
@@ -7,3 +12,14 @@ type SuccessResponse struct {
 type ErrorResponse struct {
 	Message string `json:"message"`
 }
+
+func respondWithJSON(w http.ResponseWriter, statusCode int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(response)
+}
+
+func respondWithError(w http.ResponseWriter, statusCode int, message string) {
+	respondWithJSON(w, statusCode, ErrorResponse{Message: message})
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/barcode"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+)
+
+// maxScanImageSize limits the size of uploaded barcode photos.
+const maxScanImageSize = 15 << 20
+
+type ScanHandler struct {
+	scanUseCase usecase.ScanUseCase
+}
+
+func NewScanHandler(su usecase.ScanUseCase) *ScanHandler {
+	return &ScanHandler{
+		scanUseCase: su,
+	}
+}
+
+func (h *ScanHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/scan", h.Scan).Methods("POST")
+}
+
+// Scan godoc
+// @Summary Read an ISBN barcode from a photo
+// @Description Decode the EAN-13 barcode in a JPEG or PNG photo (up to 15 MB) of a book's back cover. Returns the matching book when it is already in the library, or a draft filled in from the metadata provider otherwise. The photo may be rotated and moderately blurred.
+// @Tags books
+// @Accept multipart/form-data
+// @Produce json
+// @Param image formData file true "Photo of the barcode"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /scan [post]
+func (h *ScanHandler) Scan(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxScanImageSize+1<<20)
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondWithError(w, http.StatusRequestEntityTooLarge, "Image is too large")
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, "Missing image file")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxScanImageSize+1))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid image file")
+		return
+	}
+	if len(data) > maxScanImageSize {
+		respondWithError(w, http.StatusRequestEntityTooLarge, "Image is too large")
+		return
+	}
+
+	result, err := h.scanUseCase.ScanImage(data)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUnsupportedImage):
+			respondWithError(w, http.StatusUnsupportedMediaType, "Image must be a JPEG or PNG photo")
+		case errors.Is(err, barcode.ErrNotFound):
+			respondWithError(w, http.StatusUnprocessableEntity, "No barcode found in the image")
+		case errors.Is(err, usecase.ErrNotISBN):
+			respondWithError(w, http.StatusUnprocessableEntity, "Barcode "+result.Barcode+" is not an ISBN")
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: result})
+}
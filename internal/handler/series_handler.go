@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+type SeriesHandler struct {
+	seriesUseCase usecase.SeriesUseCase
+}
+
+func NewSeriesHandler(su usecase.SeriesUseCase) *SeriesHandler {
+	return &SeriesHandler{
+		seriesUseCase: su,
+	}
+}
+
+func (h *SeriesHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/series", h.CreateSeries).Methods("POST")
+	router.HandleFunc("/series", h.GetAllSeries).Methods("GET")
+	router.HandleFunc("/series/{id}", h.GetSeriesByID).Methods("GET")
+	router.HandleFunc("/series/{id}", h.UpdateSeries).Methods("PUT")
+	router.HandleFunc("/series/{id}", h.DeleteSeries).Methods("DELETE")
+	router.HandleFunc("/series/{id}/next", h.NextInSeries).Methods("GET")
+	router.HandleFunc("/series/{id}/books/{bookId}", h.AddBookToSeries).Methods("PUT")
+	router.HandleFunc("/series/{id}/books/{bookId}", h.RemoveBookFromSeries).Methods("DELETE")
+}
+
+// CreateSeries godoc
+// @Summary Create a new series
+// @Description Add a series. Books whose series name matches are linked to it.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param series body domain.Series true "Series to add"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /series [post]
+func (h *SeriesHandler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	var series domain.Series
+	if err := json.NewDecoder(r.Body).Decode(&series); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.seriesUseCase.CreateSeries(&series); err != nil {
+		h.respondWithSeriesError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: series})
+}
+
+// GetAllSeries godoc
+// @Summary Get all series
+// @Description Retrieve every series sorted by name
+// @Tags series
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /series [get]
+func (h *SeriesHandler) GetAllSeries(w http.ResponseWriter, r *http.Request) {
+	series, err := h.seriesUseCase.GetAllSeries()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: series})
+}
+
+// GetSeriesByID godoc
+// @Summary Get a series with its books
+// @Description Retrieve a series with its books in reading order, each with its read status (unread, reading or read)
+// @Tags series
+// @Produce json
+// @Param id path string true "Series ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /series/{id} [get]
+func (h *SeriesHandler) GetSeriesByID(w http.ResponseWriter, r *http.Request) {
+	details, err := h.seriesUseCase.GetSeriesByID(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithSeriesError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: details})
+}
+
+// UpdateSeries godoc
+// @Summary Update a series by ID
+// @Description Replace a series. Renaming it also renames the series of its books.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path string true "Series ID"
+// @Param series body domain.Series true "Updated series data"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /series/{id} [put]
+func (h *SeriesHandler) UpdateSeries(w http.ResponseWriter, r *http.Request) {
+	var series domain.Series
+	if err := json.NewDecoder(r.Body).Decode(&series); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	series.ID = mux.Vars(r)["id"]
+
+	if err := h.seriesUseCase.UpdateSeries(&series); err != nil {
+		h.respondWithSeriesError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: series})
+}
+
+// DeleteSeries godoc
+// @Summary Delete a series by ID
+// @Description Remove a series. Its books keep their series name and position but are no longer linked to it.
+// @Tags series
+// @Param id path string true "Series ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /series/{id} [delete]
+func (h *SeriesHandler) DeleteSeries(w http.ResponseWriter, r *http.Request) {
+	if err := h.seriesUseCase.DeleteSeries(mux.Vars(r)["id"]); err != nil {
+		h.respondWithSeriesError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// NextInSeries godoc
+// @Summary Get the next book to read in a series
+// @Description Retrieve the first book of the series, in reading order, that has not been read yet
+// @Tags series
+// @Produce json
+// @Param id path string true "Series ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /series/{id}/next [get]
+func (h *SeriesHandler) NextInSeries(w http.ResponseWriter, r *http.Request) {
+	entry, err := h.seriesUseCase.NextInSeries(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithSeriesError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: entry})
+}
+
+// SeriesPositionRequest is the position of a book in a series.
+type SeriesPositionRequest struct {
+	Position float64 `json:"position" example:"2.5"`
+}
+
+// AddBookToSeries godoc
+// @Summary Place a book in a series
+// @Description Link a book to the series at the given position, which may be fractional for novellas (2.5). A book belongs to a single series.
+// @Tags series
+// @Accept json
+// @Param id path string true "Series ID"
+// @Param bookId path string true "Book ID"
+// @Param request body SeriesPositionRequest true "Position in the series"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /series/{id}/books/{bookId} [put]
+func (h *SeriesHandler) AddBookToSeries(w http.ResponseWriter, r *http.Request) {
+	var req SeriesPositionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.seriesUseCase.AddBookToSeries(vars["id"], vars["bookId"], req.Position); err != nil {
+		h.respondWithSeriesError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveBookFromSeries godoc
+// @Summary Remove a book from a series
+// @Tags series
+// @Param id path string true "Series ID"
+// @Param bookId path string true "Book ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /series/{id}/books/{bookId} [delete]
+func (h *SeriesHandler) RemoveBookFromSeries(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := h.seriesUseCase.RemoveBookFromSeries(vars["id"], vars["bookId"]); err != nil {
+		h.respondWithSeriesError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SeriesHandler) respondWithSeriesError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, validator.ErrInvalidSeriesData):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrSeriesNotFound):
+		respondWithError(w, http.StatusNotFound, "Series not found")
+	case errors.Is(err, repository.ErrBookNotFound):
+		respondWithError(w, http.StatusNotFound, "Book not found")
+	case errors.Is(err, usecase.ErrNotInSeries), errors.Is(err, usecase.ErrSeriesFinished):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
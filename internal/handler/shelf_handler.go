@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+type ShelfHandler struct {
+	shelfUseCase usecase.ShelfUseCase
+}
+
+func NewShelfHandler(su usecase.ShelfUseCase) *ShelfHandler {
+	return &ShelfHandler{
+		shelfUseCase: su,
+	}
+}
+
+func (h *ShelfHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/shelves", h.CreateShelf).Methods("POST")
+	router.HandleFunc("/shelves", h.GetAllShelves).Methods("GET")
+	router.HandleFunc("/shelves/{id}", h.GetShelf).Methods("GET")
+	router.HandleFunc("/shelves/{id}", h.UpdateShelf).Methods("PUT")
+	router.HandleFunc("/shelves/{id}", h.DeleteShelf).Methods("DELETE")
+	router.HandleFunc("/shelves/{id}/books", h.AddBook).Methods("POST")
+	router.HandleFunc("/shelves/{id}/books/{bookId}", h.MoveBook).Methods("PUT")
+	router.HandleFunc("/shelves/{id}/books/{bookId}", h.RemoveBook).Methods("DELETE")
+	router.HandleFunc("/books/{id}/shelves", h.GetBookShelves).Methods("GET")
+}
+
+// CreateShelf godoc
+// @Summary Create a new shelf
+// @Description Add an empty shelf, such as "Favorites" or "Books to give away"
+// @Tags shelves
+// @Accept json
+// @Produce json
+// @Param shelf body domain.Shelf true "Shelf to add; entries are ignored"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shelves [post]
+func (h *ShelfHandler) CreateShelf(w http.ResponseWriter, r *http.Request) {
+	var shelf domain.Shelf
+	if err := json.NewDecoder(r.Body).Decode(&shelf); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.shelfUseCase.CreateShelf(&shelf); err != nil {
+		h.respondWithShelfError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: shelf})
+}
+
+// GetAllShelves godoc
+// @Summary Get all shelves
+// @Description Retrieve every shelf sorted by name, with its entries in order
+// @Tags shelves
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shelves [get]
+func (h *ShelfHandler) GetAllShelves(w http.ResponseWriter, r *http.Request) {
+	shelves, err := h.shelfUseCase.GetAllShelves()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: shelves})
+}
+
+// GetShelf godoc
+// @Summary Get a shelf with its books
+// @Description Retrieve a shelf with its books in shelf order
+// @Tags shelves
+// @Produce json
+// @Param id path string true "Shelf ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shelves/{id} [get]
+func (h *ShelfHandler) GetShelf(w http.ResponseWriter, r *http.Request) {
+	details, err := h.shelfUseCase.GetShelf(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithShelfError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: details})
+}
+
+// UpdateShelf godoc
+// @Summary Update a shelf by ID
+// @Description Rename a shelf or change its description. Its books are left untouched.
+// @Tags shelves
+// @Accept json
+// @Produce json
+// @Param id path string true "Shelf ID"
+// @Param shelf body domain.Shelf true "Updated shelf data; entries are ignored"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shelves/{id} [put]
+func (h *ShelfHandler) UpdateShelf(w http.ResponseWriter, r *http.Request) {
+	var shelf domain.Shelf
+	if err := json.NewDecoder(r.Body).Decode(&shelf); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	shelf.ID = mux.Vars(r)["id"]
+
+	if err := h.shelfUseCase.UpdateShelf(&shelf); err != nil {
+		h.respondWithShelfError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: shelf})
+}
+
+// DeleteShelf godoc
+// @Summary Delete a shelf by ID
+// @Description Remove a shelf. Its books are not deleted.
+// @Tags shelves
+// @Param id path string true "Shelf ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shelves/{id} [delete]
+func (h *ShelfHandler) DeleteShelf(w http.ResponseWriter, r *http.Request) {
+	if err := h.shelfUseCase.DeleteShelf(mux.Vars(r)["id"]); err != nil {
+		h.respondWithShelfError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddShelfBookRequest names the book to put on a shelf and where it goes.
+type AddShelfBookRequest struct {
+	BookID string `json:"book_id"`
+	usecase.ShelfPlacement
+}
+
+// AddBook godoc
+// @Summary Put a book on a shelf
+// @Description Add a book after or before another book of the shelf, or at its end when neither is given
+// @Tags shelves
+// @Accept json
+// @Produce json
+// @Param id path string true "Shelf ID"
+// @Param request body AddShelfBookRequest true "Book and placement"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shelves/{id}/books [post]
+func (h *ShelfHandler) AddBook(w http.ResponseWriter, r *http.Request) {
+	var req AddShelfBookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.BookID == "" {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	shelf, err := h.shelfUseCase.AddBook(mux.Vars(r)["id"], req.BookID, req.ShelfPlacement)
+	if err != nil {
+		h.respondWithShelfError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: shelf})
+}
+
+// MoveBook godoc
+// @Summary Move a book on a shelf
+// @Description Move a book after or before another book of the shelf, as when it is dragged and dropped. Only the moved book changes position.
+// @Tags shelves
+// @Accept json
+// @Produce json
+// @Param id path string true "Shelf ID"
+// @Param bookId path string true "Book ID"
+// @Param request body usecase.ShelfPlacement true "New placement"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shelves/{id}/books/{bookId} [put]
+func (h *ShelfHandler) MoveBook(w http.ResponseWriter, r *http.Request) {
+	var placement usecase.ShelfPlacement
+	if err := json.NewDecoder(r.Body).Decode(&placement); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	vars := mux.Vars(r)
+	shelf, err := h.shelfUseCase.MoveBook(vars["id"], vars["bookId"], placement)
+	if err != nil {
+		h.respondWithShelfError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: shelf})
+}
+
+// RemoveBook godoc
+// @Summary Remove a book from a shelf
+// @Tags shelves
+// @Param id path string true "Shelf ID"
+// @Param bookId path string true "Book ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shelves/{id}/books/{bookId} [delete]
+func (h *ShelfHandler) RemoveBook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := h.shelfUseCase.RemoveBook(vars["id"], vars["bookId"]); err != nil {
+		h.respondWithShelfError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetBookShelves godoc
+// @Summary Get the shelves of a book
+// @Description Retrieve the shelves holding a book, sorted by name
+// @Tags shelves
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/shelves [get]
+func (h *ShelfHandler) GetBookShelves(w http.ResponseWriter, r *http.Request) {
+	shelves, err := h.shelfUseCase.GetBookShelves(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithShelfError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: shelves})
+}
+
+func (h *ShelfHandler) respondWithShelfError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, validator.ErrInvalidShelfData):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrShelfNotFound):
+		respondWithError(w, http.StatusNotFound, "Shelf not found")
+	case errors.Is(err, repository.ErrBookNotFound):
+		respondWithError(w, http.StatusNotFound, "Book not found")
+	case errors.Is(err, repository.ErrBookNotOnShelf):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, repository.ErrBookAlreadyOnShelf), errors.Is(err, repository.ErrShelfChanged):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+type SuggestionHandler struct {
+	enrichmentUseCase usecase.EnrichmentUseCase
+}
+
+func NewSuggestionHandler(eu usecase.EnrichmentUseCase) *SuggestionHandler {
+	return &SuggestionHandler{
+		enrichmentUseCase: eu,
+	}
+}
+
+func (h *SuggestionHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/books/{id}/suggestions", h.GetSuggestions).Methods("GET")
+	router.HandleFunc("/books/{id}/suggestions", h.ResolveSuggestion).Methods("POST")
+}
+
+// ResolveSuggestionRequest approves or rejects a pending suggestion.
+type ResolveSuggestionRequest struct {
+	SuggestionID string `json:"suggestion_id"`
+	// Action is either "approve" or "reject".
+	Action string `json:"action"`
+}
+
+// GetSuggestions godoc
+// @Summary List suggestions for a book
+// @Description List the changes proposed by the enrichment job for a book
+// @Tags suggestions
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param status query string false "Suggestion status" Enums(pending, approved, rejected)
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/suggestions [get]
+func (h *SuggestionHandler) GetSuggestions(w http.ResponseWriter, r *http.Request) {
+	suggestions, err := h.enrichmentUseCase.GetSuggestions(mux.Vars(r)["id"], r.URL.Query().Get("status"))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrBookNotFound):
+			respondWithError(w, http.StatusNotFound, "Book not found")
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: suggestions})
+}
+
+// ResolveSuggestion godoc
+// @Summary Approve or reject a suggestion
+// @Description Approving applies the proposed changes to the book; rejecting keeps the book as it is
+// @Tags suggestions
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param request body ResolveSuggestionRequest true "Suggestion and action"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/suggestions [post]
+func (h *SuggestionHandler) ResolveSuggestion(w http.ResponseWriter, r *http.Request) {
+	var req ResolveSuggestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if req.Action != "approve" && req.Action != "reject" {
+		respondWithError(w, http.StatusBadRequest, "Action must be approve or reject")
+		return
+	}
+
+	book, err := h.enrichmentUseCase.ResolveSuggestion(mux.Vars(r)["id"], req.SuggestionID, req.Action == "approve")
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSuggestionNotFound):
+			respondWithError(w, http.StatusNotFound, "Pending suggestion not found")
+		case errors.Is(err, repository.ErrBookNotFound):
+			respondWithError(w, http.StatusNotFound, "Book not found")
+		case errors.Is(err, validator.ErrInvalidBookData):
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, repository.ErrDuplicateISBN), errors.Is(err, usecase.ErrSuggestionOutdated):
+			respondWithError(w, http.StatusConflict, err.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: struct {
+		Book *domain.Book `json:"book"`
+	}{book}})
+}
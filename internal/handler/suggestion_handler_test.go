@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+// errEnrichmentUseCase answers every call with err.
+type errEnrichmentUseCase struct {
+	usecase.EnrichmentUseCase
+	err error
+}
+
+func (uc errEnrichmentUseCase) GetSuggestions(bookID, status string) ([]*domain.Suggestion, error) {
+	return []*domain.Suggestion{}, uc.err
+}
+
+func (uc errEnrichmentUseCase) ResolveSuggestion(bookID, suggestionID string, approve bool) (*domain.Book, error) {
+	if uc.err != nil {
+		return nil, uc.err
+	}
+	return &domain.Book{ID: bookID}, nil
+}
+
+func TestSuggestionHandlerStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "list", method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "list missing book", method: http.MethodGet, err: repository.ErrBookNotFound, wantStatus: http.StatusNotFound},
+		{name: "list failing", method: http.MethodGet, err: errDatabase, wantStatus: http.StatusInternalServerError},
+		{name: "approve", method: http.MethodPost, body: `{"suggestion_id":"s1","action":"approve"}`, wantStatus: http.StatusOK},
+		{name: "unknown action", method: http.MethodPost, body: `{"suggestion_id":"s1","action":"maybe"}`, wantStatus: http.StatusBadRequest},
+		{name: "missing suggestion", method: http.MethodPost, body: `{"suggestion_id":"s1","action":"approve"}`, err: repository.ErrSuggestionNotFound, wantStatus: http.StatusNotFound},
+		{name: "invalid book", method: http.MethodPost, body: `{"suggestion_id":"s1","action":"approve"}`, err: validator.ErrInvalidBookData, wantStatus: http.StatusBadRequest},
+		{name: "outdated", method: http.MethodPost, body: `{"suggestion_id":"s1","action":"approve"}`, err: usecase.ErrSuggestionOutdated, wantStatus: http.StatusConflict},
+		{name: "duplicate ISBN", method: http.MethodPost, body: `{"suggestion_id":"s1","action":"approve"}`, err: repository.ErrDuplicateISBN, wantStatus: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			NewSuggestionHandler(errEnrichmentUseCase{err: tt.err}).RegisterRoutes(router)
+
+			req := httptest.NewRequest(tt.method, "/books/b1/suggestions", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("%s = %d, want %d: %s", tt.method, rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
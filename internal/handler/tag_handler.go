@@ -0,0 +1,331 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+type TagHandler struct {
+	tagUseCase usecase.TagUseCase
+}
+
+func NewTagHandler(tu usecase.TagUseCase) *TagHandler {
+	return &TagHandler{
+		tagUseCase: tu,
+	}
+}
+
+func (h *TagHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/tags", h.CreateTag).Methods("POST")
+	router.HandleFunc("/tags", h.GetTagTree).Methods("GET")
+	router.HandleFunc("/tags/history", h.GetTagHistory).Methods("GET")
+	router.HandleFunc("/tags/{id}", h.GetTag).Methods("GET")
+	router.HandleFunc("/tags/{id}", h.UpdateTag).Methods("PUT")
+	router.HandleFunc("/tags/{id}", h.DeleteTag).Methods("DELETE")
+	router.HandleFunc("/tags/{id}/rename", h.RenameTag).Methods("POST")
+	router.HandleFunc("/tags/{id}/move", h.MoveTag).Methods("POST")
+	router.HandleFunc("/tags/{id}/merge", h.MergeTag).Methods("POST")
+	router.HandleFunc("/books/{id}/tags", h.TagBook).Methods("POST")
+	router.HandleFunc("/books/{id}/tags", h.UntagBook).Methods("DELETE")
+}
+
+// CreateTag godoc
+// @Summary Create a new tag
+// @Description Add a tag to the tree from its path, such as "fiction/sci-fi/cyberpunk". Missing ancestors are created too.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tag body domain.Tag true "Tag to add; only path and description are read"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tags [post]
+func (h *TagHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	var tag domain.Tag
+	if err := json.NewDecoder(r.Body).Decode(&tag); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.tagUseCase.CreateTag(&tag); err != nil {
+		h.respondWithTagError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: tag})
+}
+
+// GetTagTree godoc
+// @Summary Get the tag tree
+// @Description Retrieve the root tags with their children. Each tag counts the books tagged with it or with one of its descendants.
+// @Tags tags
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tags [get]
+func (h *TagHandler) GetTagTree(w http.ResponseWriter, r *http.Request) {
+	tree, err := h.tagUseCase.GetTagTree()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: tree})
+}
+
+// GetTagHistory godoc
+// @Summary Get the tag history
+// @Description List the renames, moves, merges and deletions of tags, most recent first, with the books each one changed
+// @Tags tags
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tags/history [get]
+func (h *TagHandler) GetTagHistory(w http.ResponseWriter, r *http.Request) {
+	changes, err := h.tagUseCase.GetHistory()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: changes})
+}
+
+// GetTag godoc
+// @Summary Get a tag by ID
+// @Description Retrieve a tag with its descendants and book counts
+// @Tags tags
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tags/{id} [get]
+func (h *TagHandler) GetTag(w http.ResponseWriter, r *http.Request) {
+	node, err := h.tagUseCase.GetTag(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithTagError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: node})
+}
+
+// UpdateTag godoc
+// @Summary Update a tag by ID
+// @Description Change the description of a tag. Use rename, move or merge to change its path.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param tag body domain.Tag true "Updated tag data; only description is read"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tags/{id} [put]
+func (h *TagHandler) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	var tag domain.Tag
+	if err := json.NewDecoder(r.Body).Decode(&tag); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	tag.ID = mux.Vars(r)["id"]
+
+	if err := h.tagUseCase.UpdateTag(&tag); err != nil {
+		h.respondWithTagError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: tag})
+}
+
+// DeleteTag godoc
+// @Summary Delete a tag by ID
+// @Description Remove a tag and its descendants from the tree and from every book. The change is kept in the tag history.
+// @Tags tags
+// @Param id path string true "Tag ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tags/{id} [delete]
+func (h *TagHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	if err := h.tagUseCase.DeleteTag(mux.Vars(r)["id"]); err != nil {
+		h.respondWithTagError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RenameTagRequest carries the new name of a tag, without its parent path.
+type RenameTagRequest struct {
+	Name string `json:"name"`
+}
+
+// RenameTag godoc
+// @Summary Rename a tag
+// @Description Change the last level of a tag path. Its descendants and every tagged book follow.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param request body RenameTagRequest true "New name"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tags/{id}/rename [post]
+func (h *TagHandler) RenameTag(w http.ResponseWriter, r *http.Request) {
+	var req RenameTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	tag, err := h.tagUseCase.RenameTag(mux.Vars(r)["id"], req.Name)
+	if err != nil {
+		h.respondWithTagError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: tag})
+}
+
+// MoveTagRequest carries the path of the new parent; empty moves the tag to the root.
+type MoveTagRequest struct {
+	Parent string `json:"parent"`
+}
+
+// MoveTag godoc
+// @Summary Move a tag
+// @Description Move a tag below another parent. Its descendants and every tagged book follow.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param request body MoveTagRequest true "New parent path"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tags/{id}/move [post]
+func (h *TagHandler) MoveTag(w http.ResponseWriter, r *http.Request) {
+	var req MoveTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	tag, err := h.tagUseCase.MoveTag(mux.Vars(r)["id"], req.Parent)
+	if err != nil {
+		h.respondWithTagError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: tag})
+}
+
+// MergeTagRequest names the tag that absorbs the tag in the path.
+type MergeTagRequest struct {
+	TargetID string `json:"target_id"`
+}
+
+// MergeTag godoc
+// @Summary Merge a tag into another
+// @Description Fold the tag and its descendants into the target tag and delete it. Books tagged with both keep a single tag, and the change is kept in the tag history.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the tag merged away"
+// @Param request body MergeTagRequest true "Target tag"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tags/{id}/merge [post]
+func (h *TagHandler) MergeTag(w http.ResponseWriter, r *http.Request) {
+	var req MergeTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.TargetID == "" {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	tag, err := h.tagUseCase.MergeTag(mux.Vars(r)["id"], req.TargetID)
+	if err != nil {
+		h.respondWithTagError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: tag})
+}
+
+// BookTagsRequest lists tag paths to add to a book.
+type BookTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// TagBook godoc
+// @Summary Tag a book
+// @Description Add tags to a book, creating the tags missing from the tree
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param request body BookTagsRequest true "Tag paths"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/tags [post]
+func (h *TagHandler) TagBook(w http.ResponseWriter, r *http.Request) {
+	var req BookTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	book, err := h.tagUseCase.TagBook(mux.Vars(r)["id"], req.Tags)
+	if err != nil {
+		h.respondWithTagError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: book})
+}
+
+// UntagBook godoc
+// @Summary Remove tags from a book
+// @Description Remove the given tags from a book. Tags below them are kept.
+// @Tags tags
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param tag query []string true "Tag paths" collectionFormat(multi)
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /books/{id}/tags [delete]
+func (h *TagHandler) UntagBook(w http.ResponseWriter, r *http.Request) {
+	book, err := h.tagUseCase.UntagBook(mux.Vars(r)["id"], r.URL.Query()["tag"])
+	if err != nil {
+		h.respondWithTagError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: book})
+}
+
+func (h *TagHandler) respondWithTagError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, validator.ErrInvalidTagData), errors.Is(err, usecase.ErrTagCycle):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrTagNotFound):
+		respondWithError(w, http.StatusNotFound, "Tag not found")
+	case errors.Is(err, repository.ErrBookNotFound):
+		respondWithError(w, http.StatusNotFound, "Book not found")
+	case errors.Is(err, repository.ErrDuplicateTag):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
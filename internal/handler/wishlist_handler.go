@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+type WishlistHandler struct {
+	wishlistUseCase usecase.WishlistUseCase
+}
+
+func NewWishlistHandler(wu usecase.WishlistUseCase) *WishlistHandler {
+	return &WishlistHandler{
+		wishlistUseCase: wu,
+	}
+}
+
+func (h *WishlistHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/wishlist", h.AddItem).Methods("POST")
+	router.HandleFunc("/wishlist", h.GetItems).Methods("GET")
+	router.HandleFunc("/wishlist/share", h.Share).Methods("POST")
+	router.HandleFunc("/wishlist/share", h.GetShare).Methods("GET")
+	router.HandleFunc("/wishlist/share", h.RevokeShare).Methods("DELETE")
+	router.HandleFunc("/wishlist/{id}", h.GetItem).Methods("GET")
+	router.HandleFunc("/wishlist/{id}", h.UpdateItem).Methods("PUT")
+	router.HandleFunc("/wishlist/{id}", h.DeleteItem).Methods("DELETE")
+	router.HandleFunc("/wishlist/{id}/acquire", h.Acquire).Methods("POST")
+	router.HandleFunc("/shared/wishlist/{token}", h.GetSharedWishlist).Methods("GET")
+	router.HandleFunc("/shared/wishlist/{token}/items/{id}/reservation", h.Reserve).Methods("POST")
+	router.HandleFunc("/shared/wishlist/{token}/items/{id}/reservation", h.CancelReservation).Methods("DELETE")
+}
+
+// AddItem godoc
+// @Summary Add a book to the wishlist
+// @Description Add a book wanted but not owned yet, optionally narrowed to an edition or format
+// @Tags wishlist
+// @Accept json
+// @Produce json
+// @Param item body domain.WishlistItem true "Wishlist item; priority defaults to medium"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /wishlist [post]
+func (h *WishlistHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	var item domain.WishlistItem
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.wishlistUseCase.AddItem(&item); err != nil {
+		h.respondWithWishlistError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: item})
+}
+
+// GetItems godoc
+// @Summary Get the wishlist
+// @Description Retrieve the wishlist, the most wanted first. Reservations made by friends are not shown.
+// @Tags wishlist
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /wishlist [get]
+func (h *WishlistHandler) GetItems(w http.ResponseWriter, r *http.Request) {
+	items, err := h.wishlistUseCase.GetItems()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: items})
+}
+
+// GetItem godoc
+// @Summary Get a wishlist item by ID
+// @Tags wishlist
+// @Produce json
+// @Param id path string true "Wishlist item ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /wishlist/{id} [get]
+func (h *WishlistHandler) GetItem(w http.ResponseWriter, r *http.Request) {
+	item, err := h.wishlistUseCase.GetItem(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithWishlistError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: item})
+}
+
+// UpdateItem godoc
+// @Summary Update a wishlist item by ID
+// @Tags wishlist
+// @Accept json
+// @Produce json
+// @Param id path string true "Wishlist item ID"
+// @Param item body domain.WishlistItem true "Updated wishlist item"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /wishlist/{id} [put]
+func (h *WishlistHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	var item domain.WishlistItem
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	item.ID = mux.Vars(r)["id"]
+
+	if err := h.wishlistUseCase.UpdateItem(&item); err != nil {
+		h.respondWithWishlistError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: item})
+}
+
+// DeleteItem godoc
+// @Summary Delete a wishlist item by ID
+// @Tags wishlist
+// @Param id path string true "Wishlist item ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /wishlist/{id} [delete]
+func (h *WishlistHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
+	if err := h.wishlistUseCase.DeleteItem(mux.Vars(r)["id"]); err != nil {
+		h.respondWithWishlistError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Acquire godoc
+// @Summary Move a wishlist item into the library
+// @Description Create a book from a wishlist item and remove the item. Book fields sent in the optional body, such as pages, take precedence over the item's.
+// @Tags wishlist
+// @Accept json
+// @Produce json
+// @Param id path string true "Wishlist item ID"
+// @Param book body domain.Book false "Book fields to add or override"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /wishlist/{id}/acquire [post]
+func (h *WishlistHandler) Acquire(w http.ResponseWriter, r *http.Request) {
+	var book domain.Book
+	// O corpo é opcional.
+	if err := json.NewDecoder(r.Body).Decode(&book); err != nil && !errors.Is(err, io.EOF) {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.wishlistUseCase.Acquire(mux.Vars(r)["id"], &book); err != nil {
+		h.respondWithWishlistError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: book})
+}
+
+// Share godoc
+// @Summary Create a share link for the wishlist
+// @Description Create a read-only public link to the wishlist, through which friends can reserve gifts. The previous link stops working.
+// @Tags wishlist
+// @Produce json
+// @Success 201 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /wishlist/share [post]
+func (h *WishlistHandler) Share(w http.ResponseWriter, r *http.Request) {
+	link, err := h.wishlistUseCase.Share()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: link})
+}
+
+// GetShare godoc
+// @Summary Get the share link of the wishlist
+// @Tags wishlist
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /wishlist/share [get]
+func (h *WishlistHandler) GetShare(w http.ResponseWriter, r *http.Request) {
+	link, err := h.wishlistUseCase.GetShare()
+	if err != nil {
+		h.respondWithWishlistError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: link})
+}
+
+// RevokeShare godoc
+// @Summary Revoke the share link of the wishlist
+// @Tags wishlist
+// @Success 204
+// @Failure 500 {object} ErrorResponse
+// @Router /wishlist/share [delete]
+func (h *WishlistHandler) RevokeShare(w http.ResponseWriter, r *http.Request) {
+	if err := h.wishlistUseCase.RevokeShare(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSharedWishlist godoc
+// @Summary Get a shared wishlist
+// @Description Public, read-only view of the wishlist, telling which books are already reserved but not by whom
+// @Tags shared wishlist
+// @Produce json
+// @Param token path string true "Share link token"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shared/wishlist/{token} [get]
+func (h *WishlistHandler) GetSharedWishlist(w http.ResponseWriter, r *http.Request) {
+	items, err := h.wishlistUseCase.GetSharedWishlist(mux.Vars(r)["token"])
+	if err != nil {
+		h.respondWithWishlistError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: items})
+}
+
+// Reserve godoc
+// @Summary Reserve a book of a shared wishlist
+// @Description Reserve a book to give as a gift. The answer carries the code needed to cancel the reservation; the owner never sees who reserved what.
+// @Tags shared wishlist
+// @Produce json
+// @Param token path string true "Share link token"
+// @Param id path string true "Wishlist item ID"
+// @Success 201 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shared/wishlist/{token}/items/{id}/reservation [post]
+func (h *WishlistHandler) Reserve(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	receipt, err := h.wishlistUseCase.Reserve(vars["token"], vars["id"])
+	if err != nil {
+		h.respondWithWishlistError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: receipt})
+}
+
+// CancelReservation godoc
+// @Summary Cancel a reservation on a shared wishlist
+// @Tags shared wishlist
+// @Param token path string true "Share link token"
+// @Param id path string true "Wishlist item ID"
+// @Param code query string true "Code received when reserving"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shared/wishlist/{token}/items/{id}/reservation [delete]
+func (h *WishlistHandler) CancelReservation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := h.wishlistUseCase.CancelReservation(vars["token"], vars["id"], r.URL.Query().Get("code")); err != nil {
+		h.respondWithWishlistError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WishlistHandler) respondWithWishlistError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, validator.ErrInvalidWishlistData), errors.Is(err, validator.ErrInvalidBookData):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrWishlistItemNotFound):
+		respondWithError(w, http.StatusNotFound, "Wishlist item not found")
+	case errors.Is(err, repository.ErrShareNotFound):
+		respondWithError(w, http.StatusNotFound, "Share link not found")
+	case errors.Is(err, repository.ErrAlreadyReserved), errors.Is(err, repository.ErrNotReserved),
+		errors.Is(err, repository.ErrDuplicateISBN):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/usecase"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+type WorkHandler struct {
+	workUseCase usecase.WorkUseCase
+}
+
+func NewWorkHandler(wu usecase.WorkUseCase) *WorkHandler {
+	return &WorkHandler{
+		workUseCase: wu,
+	}
+}
+
+func (h *WorkHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/works", h.CreateWork).Methods("POST")
+	router.HandleFunc("/works", h.GetWorks).Methods("GET")
+	router.HandleFunc("/works/{id}", h.GetWork).Methods("GET")
+	router.HandleFunc("/works/{id}", h.UpdateWork).Methods("PUT")
+	router.HandleFunc("/works/{id}", h.DeleteWork).Methods("DELETE")
+}
+
+// CreateWork godoc
+// @Summary Create a new work
+// @Description Add a work, the creation its editions are published from
+// @Tags works
+// @Accept json
+// @Produce json
+// @Param work body domain.Work true "Work to add"
+// @Success 201 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /works [post]
+func (h *WorkHandler) CreateWork(w http.ResponseWriter, r *http.Request) {
+	var work domain.Work
+	if err := json.NewDecoder(r.Body).Decode(&work); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.workUseCase.CreateWork(&work); err != nil {
+		h.respondWithWorkError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, SuccessResponse{Data: work})
+}
+
+// GetWorks godoc
+// @Summary Get works
+// @Description Retrieve every work sorted by title, or those whose title or original title contains the given text
+// @Tags works
+// @Produce json
+// @Param title query string false "Title contains"
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /works [get]
+func (h *WorkHandler) GetWorks(w http.ResponseWriter, r *http.Request) {
+	works, err := h.workUseCase.GetWorks(r.URL.Query().Get("title"))
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: works})
+}
+
+// GetWork godoc
+// @Summary Get a work by ID
+// @Description Retrieve a work with its editions, their copies, its reading records and read status
+// @Tags works
+// @Produce json
+// @Param id path string true "Work ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /works/{id} [get]
+func (h *WorkHandler) GetWork(w http.ResponseWriter, r *http.Request) {
+	details, err := h.workUseCase.GetWork(mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithWorkError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: details})
+}
+
+// UpdateWork godoc
+// @Summary Update a work by ID
+// @Description Replace a work, including its rating
+// @Tags works
+// @Accept json
+// @Produce json
+// @Param id path string true "Work ID"
+// @Param work body domain.Work true "Updated work data"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /works/{id} [put]
+func (h *WorkHandler) UpdateWork(w http.ResponseWriter, r *http.Request) {
+	var work domain.Work
+	if err := json.NewDecoder(r.Body).Decode(&work); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	work.ID = mux.Vars(r)["id"]
+
+	if err := h.workUseCase.UpdateWork(&work); err != nil {
+		h.respondWithWorkError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, SuccessResponse{Data: work})
+}
+
+// DeleteWork godoc
+// @Summary Delete a work by ID
+// @Description Remove a work that no longer has editions
+// @Tags works
+// @Param id path string true "Work ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /works/{id} [delete]
+func (h *WorkHandler) DeleteWork(w http.ResponseWriter, r *http.Request) {
+	if err := h.workUseCase.DeleteWork(mux.Vars(r)["id"]); err != nil {
+		h.respondWithWorkError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WorkHandler) respondWithWorkError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, validator.ErrInvalidWorkData):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrWorkNotFound):
+		respondWithError(w, http.StatusNotFound, "Work not found")
+	case errors.Is(err, usecase.ErrWorkInUse):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
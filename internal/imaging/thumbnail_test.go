@@ -0,0 +1,59 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestThumbnailSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		bounds     image.Rectangle
+		width      int
+		wantWidth  int
+		wantHeight int
+	}{
+		{name: "shrinks keeping the ratio", bounds: image.Rect(0, 0, 400, 600), width: 128, wantWidth: 128, wantHeight: 192},
+		{name: "not enlarged", bounds: image.Rect(0, 0, 100, 150), width: 128, wantWidth: 100, wantHeight: 150},
+		{name: "no width keeps the size", bounds: image.Rect(0, 0, 100, 150), wantWidth: 100, wantHeight: 150},
+		// Imagens muito largas ainda têm ao menos uma linha.
+		{name: "very wide", bounds: image.Rect(0, 0, 1000, 2), width: 10, wantWidth: 10, wantHeight: 1},
+		{name: "bounds not at origin", bounds: image.Rect(50, 50, 250, 350), width: 100, wantWidth: 100, wantHeight: 150},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Thumbnail(image.NewRGBA(tt.bounds), tt.width).Bounds()
+			if got != image.Rect(0, 0, tt.wantWidth, tt.wantHeight) {
+				t.Errorf("Thumbnail bounds = %v, want %dx%d at the origin", got, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestThumbnailColors(t *testing.T) {
+	// Metade esquerda preta e metade direita transparente.
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		img.Set(0, y, color.Black)
+		img.Set(1, y, color.Black)
+	}
+
+	thumb := Thumbnail(img, 2)
+	want := []color.RGBA{{0, 0, 0, 255}, {255, 255, 255, 255}}
+	for x, w := range want {
+		if got := color.RGBAModel.Convert(thumb.At(x, 0)); got != w {
+			t.Errorf("pixel %d = %v, want %v", x, got, w)
+		}
+	}
+
+	// Pixels pretos e brancos alternados viram cinza ao reduzir.
+	checker := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	checker.Set(0, 0, color.White)
+	checker.Set(1, 1, color.White)
+	checker.Set(1, 0, color.Black)
+	checker.Set(0, 1, color.Black)
+	if got := color.RGBAModel.Convert(Thumbnail(checker, 1).At(0, 0)); got != (color.RGBA{127, 127, 127, 255}) {
+		t.Errorf("averaged pixel = %v, want mid grey", got)
+	}
+}
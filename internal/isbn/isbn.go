@@ -0,0 +1,133 @@
+// Package isbn validates, normalizes and converts ISBN-10 and ISBN-13 numbers.
+package isbn
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidISBN    = errors.New("invalid ISBN")
+	ErrNotConvertible = errors.New("ISBN-13 has no ISBN-10 equivalent")
+)
+
+// Normalize removes hyphens and spaces and upper-cases the ISBN-10 check
+// character. It does not validate the result.
+func Normalize(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == 'x' || r == 'X':
+			b.WriteRune('X')
+		case r == '-' || r == ' ' || r == '‐' || r == '‑':
+		default:
+			// Qualquer outro caractere torna o ISBN inválido, então é mantido.
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// IsValid10 reports whether s is a normalized ISBN-10 with a correct check digit.
+func IsValid10(s string) bool {
+	if len(s) != 10 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 10; i++ {
+		c := s[i]
+		var v int
+		switch {
+		case c >= '0' && c <= '9':
+			v = int(c - '0')
+		case c == 'X' && i == 9:
+			v = 10
+		default:
+			return false
+		}
+		sum += v * (10 - i)
+	}
+	return sum%11 == 0
+}
+
+// IsValid13 reports whether s is a normalized ISBN-13 with a correct check digit.
+func IsValid13(s string) bool {
+	if len(s) != 13 || !(strings.HasPrefix(s, "978") || strings.HasPrefix(s, "979")) {
+		return false
+	}
+	for i := 0; i < 13; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return checkDigit13(s[:12]) == s[12]
+}
+
+// To13 converts a valid ISBN-10 to its ISBN-13 form.
+func To13(isbn10 string) (string, error) {
+	if !IsValid10(isbn10) {
+		return "", ErrInvalidISBN
+	}
+	prefix := "978" + isbn10[:9]
+	return prefix + string(checkDigit13(prefix)), nil
+}
+
+// To10 converts a valid ISBN-13 to its ISBN-10 form. Only ISBNs starting
+// with 978 have an ISBN-10 equivalent.
+func To10(isbn13 string) (string, error) {
+	if !IsValid13(isbn13) {
+		return "", ErrInvalidISBN
+	}
+	if !strings.HasPrefix(isbn13, "978") {
+		return "", ErrNotConvertible
+	}
+	body := isbn13[3:12]
+	return body + string(checkDigit10(body)), nil
+}
+
+// Parse normalizes s, which may be either an ISBN-10 or an ISBN-13, and
+// returns both forms. isbn10 is empty for 979 ISBNs.
+func Parse(s string) (isbn10, isbn13 string, err error) {
+	n := Normalize(s)
+	switch len(n) {
+	case 10:
+		isbn13, err = To13(n)
+		if err != nil {
+			return "", "", err
+		}
+		return n, isbn13, nil
+	case 13:
+		if !IsValid13(n) {
+			return "", "", ErrInvalidISBN
+		}
+		isbn10, _ = To10(n)
+		return isbn10, n, nil
+	}
+	return "", "", ErrInvalidISBN
+}
+
+func checkDigit13(first12 string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		v := int(first12[i] - '0')
+		if i%2 == 1 {
+			v *= 3
+		}
+		sum += v
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func checkDigit10(first9 string) byte {
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(first9[i]-'0') * (10 - i)
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return 'X'
+	}
+	return byte('0' + check)
+}
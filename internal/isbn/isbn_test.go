@@ -0,0 +1,68 @@
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want10  string
+		want13  string
+		wantErr error
+	}{
+		{input: "0-306-40615-2", want10: "0306406152", want13: "9780306406157"},
+		{input: "978-0-306-40615-7", want10: "0306406152", want13: "9780306406157"},
+		{input: "85 359 0277 5", want10: "8535902775", want13: "9788535902778"},
+		{input: "0-8044-2957-x", want10: "080442957X", want13: "9780804429573"},
+		// Os ISBNs 979 não têm forma de 10 dígitos.
+		{input: "979-10-90636-07-1", want13: "9791090636071"},
+		{input: "0-306-40615-3", wantErr: ErrInvalidISBN},
+		{input: "9780306406158", wantErr: ErrInvalidISBN},
+		{input: "9770306406155", wantErr: ErrInvalidISBN},
+		{input: "X306406152", wantErr: ErrInvalidISBN},
+		{input: "0306406152?", wantErr: ErrInvalidISBN},
+		{input: "030640615", wantErr: ErrInvalidISBN},
+		{input: "", wantErr: ErrInvalidISBN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got10, got13, err := Parse(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse error = %v, want %v", err, tt.wantErr)
+			}
+			if got10 != tt.want10 || got13 != tt.want13 {
+				t.Errorf("Parse = %q, %q; want %q, %q", got10, got13, tt.want10, tt.want13)
+			}
+		})
+	}
+}
+
+func TestConversions(t *testing.T) {
+	tests := []struct {
+		isbn10  string
+		isbn13  string
+		wantErr error
+	}{
+		{isbn10: "0306406152", isbn13: "9780306406157"},
+		{isbn10: "080442957X", isbn13: "9780804429573"},
+		{isbn13: "9791090636071", wantErr: ErrNotConvertible},
+	}
+	for _, tt := range tests {
+		got10, err := To10(tt.isbn13)
+		if !errors.Is(err, tt.wantErr) || got10 != tt.isbn10 {
+			t.Errorf("To10(%s) = %q, %v; want %q, %v", tt.isbn13, got10, err, tt.isbn10, tt.wantErr)
+		}
+		if tt.isbn10 == "" {
+			continue
+		}
+		got13, err := To13(tt.isbn10)
+		if err != nil || got13 != tt.isbn13 {
+			t.Errorf("To13(%s) = %q, %v; want %q", tt.isbn10, got13, err, tt.isbn13)
+		}
+	}
+	if _, err := To13("0306406153"); !errors.Is(err, ErrInvalidISBN) {
+		t.Errorf("To13 accepted a wrong check digit: %v", err)
+	}
+}
@@ -0,0 +1,239 @@
+package marc
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/isbn"
+)
+
+var (
+	pagesPattern  = regexp.MustCompile(`(\d+)\s*(p\b|p\.|pages|páginas|pág)`)
+	numberPattern = regexp.MustCompile(`\d+`)
+	yearPattern   = regexp.MustCompile(`\d{4}`)
+	isbnPattern   = regexp.MustCompile(`[0-9Xx][0-9Xx\- ]{8,16}[0-9Xx]`)
+)
+
+// BookFromRecord maps a bibliographic record to a book using the fields
+// 245 (title), 100/700 (contributors), 260/264 (publisher), 300 (pages) and
+// 020 (ISBN). The role of a contributor comes from the relator code ($4) or
+// term ($e) of the field; fields without one credit an author.
+func BookFromRecord(record *Record) *domain.Book {
+	book := &domain.Book{}
+
+	if f, ok := record.Field("245"); ok {
+		book.Title = trimPunctuation(f.Subfield('a'))
+		book.Subtitle = trimPunctuation(f.Subfield('b'))
+	}
+
+	for _, tag := range []string{"100", "700"} {
+		for _, f := range record.FieldsByTag(tag) {
+			name := invertName(trimPunctuation(f.Subfield('a')))
+			role, ok := fieldRole(f)
+			if name == "" || !ok {
+				continue
+			}
+			book.Contributors = append(book.Contributors, domain.Contributor{Name: name, Role: role, Order: len(book.Contributors) + 1})
+		}
+	}
+	book.Author = domain.JoinAuthorNames(book.AuthorNames())
+
+	for _, tag := range []string{"264", "260"} {
+		if book.Publisher != "" {
+			break
+		}
+		for _, f := range record.FieldsByTag(tag) {
+			// No campo 264, o segundo indicador 1 identifica a publicação.
+			if tag == "264" && f.Ind2 != '1' {
+				continue
+			}
+			if publisher := trimPunctuation(f.Subfield('b')); publisher != "" {
+				book.Publisher = publisher
+				break
+			}
+		}
+	}
+
+	for _, tag := range []string{"264", "260"} {
+		for _, f := range record.FieldsByTag(tag) {
+			if tag == "264" && f.Ind2 != '1' {
+				continue
+			}
+			if year, err := strconv.Atoi(yearPattern.FindString(f.Subfield('c'))); err == nil && book.Year == 0 {
+				book.Year = year
+			}
+		}
+	}
+
+	if f, ok := record.Field("250"); ok {
+		book.Edition = trimPunctuation(f.Subfield('a'))
+	}
+
+	if f, ok := record.Field("300"); ok {
+		book.Pages = parsePages(f.Subfield('a'))
+	}
+
+	if f, ok := record.Field("520"); ok {
+		book.Description = f.Subfield('a')
+	}
+
+	if f, ok := record.Field("490"); ok {
+		book.Series = trimPunctuation(f.Subfield('a'))
+		if position, err := strconv.ParseFloat(numberPattern.FindString(f.Subfield('v')), 64); err == nil {
+			book.SeriesPosition = position
+		}
+	}
+
+	for _, f := range record.FieldsByTag("650") {
+		if tag := trimPunctuation(f.Subfield('a')); tag != "" {
+			book.Tags = append(book.Tags, tag)
+		}
+	}
+
+	for _, f := range record.FieldsByTag("020") {
+		if isbn10, isbn13, err := isbn.Parse(isbnPattern.FindString(f.Subfield('a'))); err == nil {
+			book.ISBN10, book.ISBN13 = isbn10, isbn13
+			break
+		}
+	}
+
+	identifiers := make(map[string]string)
+	if f, ok := record.Field("001"); ok && strings.TrimSpace(f.Value) != "" {
+		identifiers[domain.IdentifierMARCControlNumber] = strings.TrimSpace(f.Value)
+	}
+	if len(identifiers) > 0 {
+		book.Identifiers = identifiers
+	}
+
+	return book
+}
+
+// RecordFromBook builds a MARC21 bibliographic record for a book.
+func RecordFromBook(book *domain.Book) *Record {
+	record := &Record{Leader: defaultLeader}
+	record.AddControlField("001", book.ID)
+
+	record.AddDataField("020", ' ', ' ', Subfield{'a', book.ISBN13})
+	record.AddDataField("020", ' ', ' ', Subfield{'a', book.ISBN10})
+
+	contributors := book.Contributors
+	if len(contributors) == 0 {
+		contributors = domain.ContributorsFromAuthor(book.Author)
+	}
+	// O primeiro autor vai no campo 100; os demais colaboradores, no 700.
+	mainEntry := -1
+	for i, c := range contributors {
+		if c.Role == domain.RoleAuthor {
+			mainEntry = i
+			record.AddDataField("100", '1', ' ', Subfield{'a', lastFirst(c.Name)}, Subfield{'e', relatorTerm(c.Role) + "."})
+			break
+		}
+	}
+
+	titleIndicator := byte('0')
+	if mainEntry >= 0 {
+		titleIndicator = '1'
+	}
+	title, subtitle := book.Title, book.Subtitle
+	if subtitle != "" {
+		title += " :"
+	}
+	record.AddDataField("245", titleIndicator, '0', Subfield{'a', title}, Subfield{'b', subtitle})
+
+	record.AddDataField("250", ' ', ' ', Subfield{'a', book.Edition})
+	year := ""
+	if book.Year > 0 {
+		year = strconv.Itoa(book.Year)
+	}
+	record.AddDataField("264", ' ', '1', Subfield{'b', book.Publisher}, Subfield{'c', year})
+	if book.Pages > 0 {
+		record.AddDataField("300", ' ', ' ', Subfield{'a', strconv.Itoa(book.Pages) + " pages"})
+	}
+	if book.Series != "" {
+		volume := ""
+		if book.SeriesPosition > 0 {
+			volume = strconv.FormatFloat(book.SeriesPosition, 'f', -1, 64)
+		}
+		record.AddDataField("490", '0', ' ', Subfield{'a', book.Series}, Subfield{'v', volume})
+	}
+	record.AddDataField("520", ' ', ' ', Subfield{'a', book.Description})
+	for _, tag := range book.Tags {
+		record.AddDataField("650", ' ', '4', Subfield{'a', tag})
+	}
+	for i, c := range contributors {
+		if i != mainEntry {
+			record.AddDataField("700", '1', ' ', Subfield{'a', lastFirst(c.Name)}, Subfield{'e', relatorTerm(c.Role) + "."})
+		}
+	}
+
+	return record
+}
+
+// trimPunctuation removes the ISBD punctuation that cataloguers append to
+// subfields, such as " /", " :" and trailing commas.
+func trimPunctuation(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimRight(s, " /:;,=")
+	// Preserva o ponto de abreviações como "Jr." e "ed." ou iniciais como
+	// "J.R.R.", removendo apenas o ponto final.
+	if strings.HasSuffix(s, ".") && !strings.HasSuffix(s, "..") {
+		words := strings.Fields(s)
+		if last := words[len(words)-1]; len(last) > 3 && strings.Count(last, ".") == 1 {
+			s = strings.TrimSuffix(s, ".")
+		}
+	}
+	return strings.TrimSpace(s)
+}
+
+// invertName turns a heading such as "Assis, Machado de" into "Machado de Assis".
+func invertName(name string) string {
+	parts := strings.SplitN(name, ",", 2)
+	if len(parts) != 2 {
+		return name
+	}
+	return strings.TrimSpace(parts[1]) + " " + strings.TrimSpace(parts[0])
+}
+
+// lastFirst turns "Machado de Assis" into the heading form "Assis, Machado de".
+func lastFirst(name string) string {
+	name = strings.TrimSpace(name)
+	idx := strings.LastIndex(name, " ")
+	if idx < 0 || strings.Contains(name, ",") {
+		return name
+	}
+	return name[idx+1:] + ", " + name[:idx]
+}
+
+// fieldRole reads the role of the person in a 100 or 700 field from its
+// relator code or term. Unknown relators report false.
+func fieldRole(f Field) (string, bool) {
+	relator := f.Subfield('4')
+	if relator == "" {
+		relator = f.Subfield('e')
+	}
+	if relator == "" {
+		return domain.RoleAuthor, true
+	}
+	return domain.RoleFromRelator(relator)
+}
+
+// relatorTerm returns the MARC relator term of a contributor role.
+func relatorTerm(role string) string {
+	if role == domain.RoleForeword {
+		return "writer of foreword"
+	}
+	return role
+}
+
+// parsePages extracts the page count from a physical description like
+// "xii, 345 p. : il. ; 23 cm".
+func parsePages(extent string) int {
+	if m := pagesPattern.FindStringSubmatch(extent); m != nil {
+		pages, _ := strconv.Atoi(m[1])
+		return pages
+	}
+	pages, _ := strconv.Atoi(numberPattern.FindString(extent))
+	return pages
+}
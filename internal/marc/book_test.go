@@ -0,0 +1,167 @@
+package marc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+func TestBookFromRecord(t *testing.T) {
+	record := &Record{Leader: defaultLeader}
+	record.AddControlField("001", " 2004012345 ")
+	record.AddDataField("020", ' ', ' ', Subfield{'a', "978-85-359-0277-8 (broch.)"})
+	record.AddDataField("100", '1', ' ', Subfield{'a', "Tolkien, J.R.R.,"}, Subfield{'e', "author."})
+	record.AddDataField("245", '1', '4', Subfield{'a', "The hobbit :"}, Subfield{'b', "or there and back again /"})
+	record.AddDataField("250", ' ', ' ', Subfield{'a', "2. ed."})
+	record.AddDataField("264", ' ', '4', Subfield{'c', "©1937"})
+	record.AddDataField("264", ' ', '1', Subfield{'b', "Martins Fontes,"}, Subfield{'c', "2003."})
+	record.AddDataField("300", ' ', ' ', Subfield{'a', "xii, 345 p. :"})
+	record.AddDataField("490", '1', ' ', Subfield{'a', "Middle-earth ;"}, Subfield{'v', "v. 1"})
+	record.AddDataField("650", ' ', '4', Subfield{'a', "Fantasy."})
+	record.AddDataField("700", '1', ' ', Subfield{'a', "Mendes, Lenita Maria Rimoli,"}, Subfield{'4', "trl"})
+	record.AddDataField("700", '1', ' ', Subfield{'a', "Someone, Designer"}, Subfield{'4', "bkd"})
+
+	book := BookFromRecord(record)
+
+	checks := []struct {
+		field, got, want string
+	}{
+		{"title", book.Title, "The hobbit"},
+		{"subtitle", book.Subtitle, "or there and back again"},
+		{"author", book.Author, "J.R.R. Tolkien"},
+		{"publisher", book.Publisher, "Martins Fontes"},
+		{"edition", book.Edition, "2. ed."},
+		{"series", book.Series, "Middle-earth"},
+		{"isbn13", book.ISBN13, "9788535902778"},
+		{"isbn10", book.ISBN10, "8535902775"},
+		{"control number", book.Identifiers[domain.IdentifierMARCControlNumber], "2004012345"},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %q, want %q", c.field, c.got, c.want)
+		}
+	}
+	if book.Pages != 345 {
+		t.Errorf("pages = %d, want 345", book.Pages)
+	}
+	if book.Year != 2003 {
+		t.Errorf("year = %d, want 2003", book.Year)
+	}
+	if book.SeriesPosition != 1 {
+		t.Errorf("series position = %v, want 1", book.SeriesPosition)
+	}
+	if len(book.Tags) != 1 || book.Tags[0] != "Fantasy" {
+		t.Errorf("tags = %v, want [Fantasy]", book.Tags)
+	}
+
+	want := []domain.Contributor{
+		{Name: "J.R.R. Tolkien", Role: domain.RoleAuthor, Order: 1},
+		{Name: "Lenita Maria Rimoli Mendes", Role: domain.RoleTranslator, Order: 2},
+	}
+	if len(book.Contributors) != len(want) {
+		t.Fatalf("contributors = %+v, want %+v", book.Contributors, want)
+	}
+	for i := range want {
+		if book.Contributors[i] != want[i] {
+			t.Errorf("contributor %d = %+v, want %+v", i, book.Contributors[i], want[i])
+		}
+	}
+}
+
+func TestRecordFromBookKeepsInvertedAuthor(t *testing.T) {
+	// Sem colaboradores, o autor vem do campo livre e a vírgula não separa nomes.
+	book := &domain.Book{ID: "b1", Title: "The Hobbit", Author: "Tolkien, J.R.R.", Pages: 310}
+
+	record := RecordFromBook(book)
+
+	if got := record.FieldsByTag("100"); len(got) != 1 || got[0].Subfield('a') != "Tolkien, J.R.R." {
+		t.Errorf("100 fields = %+v, want a single \"Tolkien, J.R.R.\"", got)
+	}
+	if got := record.FieldsByTag("700"); len(got) != 0 {
+		t.Errorf("700 fields = %+v, want none", got)
+	}
+}
+
+func TestMARCXMLRoundTrip(t *testing.T) {
+	book := &domain.Book{
+		ID:       "b1",
+		Title:    "Cem anos de solidão",
+		Subtitle: "romance",
+		Contributors: []domain.Contributor{
+			{Name: "Gabriel García Márquez", Role: domain.RoleAuthor, Order: 1},
+			{Name: "Eliane Zagury", Role: domain.RoleTranslator, Order: 2},
+		},
+		Publisher: "Record",
+		Year:      1967,
+		Pages:     448,
+		ISBN13:    "9788501012074",
+		Tags:      []string{"Realismo mágico"},
+	}
+
+	var buf bytes.Buffer
+	writer := NewXMLWriter(&buf)
+	if err := writer.Write(RecordFromBook(book)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	record, err := NewRecordReader(&buf).Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	got := BookFromRecord(record)
+	if got.Title != book.Title || got.Subtitle != book.Subtitle || got.Publisher != book.Publisher {
+		t.Errorf("got %q / %q / %q", got.Title, got.Subtitle, got.Publisher)
+	}
+	if got.Year != book.Year || got.Pages != book.Pages || got.ISBN13 != book.ISBN13 {
+		t.Errorf("got year %d, pages %d, isbn %q", got.Year, got.Pages, got.ISBN13)
+	}
+	if len(got.Contributors) != 2 || got.Contributors[0].Name != "Gabriel García Márquez" ||
+		got.Contributors[1].Role != domain.RoleTranslator {
+		t.Errorf("contributors = %+v", got.Contributors)
+	}
+	if got.Identifiers[domain.IdentifierMARCControlNumber] != "b1" {
+		t.Errorf("identifiers = %v", got.Identifiers)
+	}
+}
+
+func TestParsePages(t *testing.T) {
+	tests := []struct {
+		extent string
+		want   int
+	}{
+		{"xii, 345 p. : il. ; 23 cm", 345},
+		{"210 páginas", 210},
+		{"1 v. (320 pages)", 320},
+		{"96", 96},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := parsePages(tt.extent); got != tt.want {
+			t.Errorf("parsePages(%q) = %d, want %d", tt.extent, got, tt.want)
+		}
+	}
+}
+
+func TestTrimPunctuation(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Dom Casmurro /", "Dom Casmurro"},
+		{"The hobbit :", "The hobbit"},
+		{"Assis, Machado de,", "Assis, Machado de"},
+		{"Fantasy.", "Fantasy"},
+		{"King, Martin Luther, Jr.", "King, Martin Luther, Jr."},
+		{"2. ed.", "2. ed."},
+		{"Tolkien, J.R.R.,", "Tolkien, J.R.R."},
+		{"Continua...", "Continua..."},
+	}
+	for _, tt := range tests {
+		if got := trimPunctuation(tt.in); got != tt.want {
+			t.Errorf("trimPunctuation(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
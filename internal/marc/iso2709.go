@@ -0,0 +1,105 @@
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	subfieldDelimiter = 0x1F
+	fieldTerminator   = 0x1E
+	recordTerminator  = 0x1D
+
+	leaderLength         = 24
+	directoryEntryLength = 12
+)
+
+var ErrInvalidRecord = errors.New("invalid MARC record")
+
+// Reader reads ISO 2709 records one at a time.
+type Reader struct {
+	r *bufio.Reader
+}
+
+// NewReader returns a Reader for a stream of ISO 2709 records.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next record, or io.EOF when the stream is exhausted.
+func (mr *Reader) Read() (*Record, error) {
+	raw, err := mr.r.ReadBytes(recordTerminator)
+	// Ignora quebras de linha e espaços entre registros.
+	raw = bytes.TrimLeft(raw, "\r\n\t ")
+	if err == io.EOF && len(raw) == 0 {
+		return nil, io.EOF
+	}
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return ParseISO2709(raw)
+}
+
+// ParseISO2709 decodes a single ISO 2709 record. The directory is used to
+// locate fields, but the declared lengths are not trusted blindly, so slightly
+// damaged records can still be read.
+func ParseISO2709(raw []byte) (*Record, error) {
+	if len(raw) < leaderLength+1 {
+		return nil, fmt.Errorf("%w: record too short", ErrInvalidRecord)
+	}
+
+	record := &Record{Leader: string(raw[:leaderLength])}
+	baseAddress, err := strconv.Atoi(string(raw[12:17]))
+	if err != nil || baseAddress <= leaderLength || baseAddress > len(raw) {
+		return nil, fmt.Errorf("%w: bad base address", ErrInvalidRecord)
+	}
+
+	directory := raw[leaderLength : baseAddress-1]
+	data := raw[baseAddress:]
+	for len(directory) >= directoryEntryLength {
+		entry := directory[:directoryEntryLength]
+		directory = directory[directoryEntryLength:]
+
+		tag := string(entry[:3])
+		length, err1 := strconv.Atoi(string(entry[3:7]))
+		start, err2 := strconv.Atoi(string(entry[7:12]))
+		// O Atoi aceita sinais, então posições negativas precisam ser recusadas aqui.
+		if err1 != nil || err2 != nil || start < 0 || length < 0 || start >= len(data) {
+			return nil, fmt.Errorf("%w: bad directory entry for tag %s", ErrInvalidRecord, tag)
+		}
+		end := start + length
+		if end > len(data) {
+			end = len(data)
+		}
+		if end < start {
+			return nil, fmt.Errorf("%w: bad directory entry for tag %s", ErrInvalidRecord, tag)
+		}
+		value := bytes.TrimRight(data[start:end], string([]byte{fieldTerminator, recordTerminator}))
+		record.Fields = append(record.Fields, parseField(tag, value))
+	}
+
+	return record, nil
+}
+
+func parseField(tag string, value []byte) Field {
+	if IsControl(tag) {
+		return Field{Tag: tag, Value: string(value)}
+	}
+
+	field := Field{Tag: tag, Ind1: ' ', Ind2: ' '}
+	if len(value) >= 2 && value[0] != subfieldDelimiter {
+		field.Ind1, field.Ind2 = value[0], value[1]
+		value = value[2:]
+	}
+	for _, part := range bytes.Split(value, []byte{subfieldDelimiter}) {
+		if len(part) == 0 {
+			continue
+		}
+		field.Subfields = append(field.Subfields, Subfield{Code: part[0], Value: string(part[1:])})
+	}
+	return field
+}
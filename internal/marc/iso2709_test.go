@@ -0,0 +1,144 @@
+package marc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// buildISO2709 encodes fields as an ISO 2709 record. Control fields are
+// given as "001:value" and data fields as "245:10" followed by subfields,
+// such as "245:10\x1faTitle".
+func buildISO2709(fields ...string) []byte {
+	var directory, data strings.Builder
+	for _, f := range fields {
+		tag, value, _ := strings.Cut(f, ":")
+		value += string(rune(fieldTerminator))
+		fmt.Fprintf(&directory, "%s%04d%05d", tag, len(value), data.Len())
+		data.WriteString(value)
+	}
+	directory.WriteByte(fieldTerminator)
+
+	base := leaderLength + directory.Len()
+	length := base + data.Len() + 1
+	leader := fmt.Sprintf("%05dnam a22%05d i 4500", length, base)
+	return []byte(leader + directory.String() + data.String() + string(rune(recordTerminator)))
+}
+
+func TestParseISO2709(t *testing.T) {
+	raw := buildISO2709(
+		"001:ocm123",
+		"245:10\x1faDom Casmurro /\x1fcMachado de Assis.",
+		"700:1 \x1faBorges, Jorge Luis,\x1feauthor.",
+	)
+
+	record, err := ParseISO2709(raw)
+	if err != nil {
+		t.Fatalf("ParseISO2709: %v", err)
+	}
+	if got, want := record.Leader[5:8], "nam"; got != want {
+		t.Errorf("leader type = %q, want %q", got, want)
+	}
+	if f, _ := record.Field("001"); f.Value != "ocm123" {
+		t.Errorf("001 = %q, want %q", f.Value, "ocm123")
+	}
+	title, ok := record.Field("245")
+	if !ok {
+		t.Fatal("245 not found")
+	}
+	if title.Ind1 != '1' || title.Ind2 != '0' {
+		t.Errorf("245 indicators = %q%q, want \"10\"", title.Ind1, title.Ind2)
+	}
+	if got := title.Subfield('a'); got != "Dom Casmurro /" {
+		t.Errorf("245$a = %q", got)
+	}
+	if got := title.Subfield('c'); got != "Machado de Assis." {
+		t.Errorf("245$c = %q", got)
+	}
+	if got := len(record.FieldsByTag("700")); got != 1 {
+		t.Errorf("700 fields = %d, want 1", got)
+	}
+}
+
+func TestParseISO2709Malformed(t *testing.T) {
+	valid := buildISO2709("001:x", "245:10\x1faTitle")
+	// O diretório começa logo após o líder: tag (3), tamanho (4), início (5).
+	withEntry := func(entry string) []byte {
+		raw := append([]byte(nil), valid...)
+		copy(raw[leaderLength:], entry)
+		return raw
+	}
+	withBase := func(base string) []byte {
+		raw := append([]byte(nil), valid...)
+		copy(raw[12:17], base)
+		return raw
+	}
+
+	tests := []struct {
+		name string
+		raw  []byte
+	}{
+		{"empty", nil},
+		{"shorter than the leader", []byte("00026nam a2200")},
+		{"non-numeric base address", withBase("abcde")},
+		{"negative base address", withBase("-0030")},
+		{"base address inside the leader", withBase("00010")},
+		{"base address past the end", withBase("99999")},
+		{"non-numeric length", withEntry("001abcd00000")},
+		{"non-numeric start", withEntry("0010002abcde")},
+		{"negative start", withEntry("0010002-0005")},
+		{"negative length", withEntry("001-00200000")},
+		{"start past the data", withEntry("001000299999")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, err := ParseISO2709(tt.raw)
+			if !errors.Is(err, ErrInvalidRecord) {
+				t.Fatalf("ParseISO2709 = %v, %v; want ErrInvalidRecord", record, err)
+			}
+		})
+	}
+}
+
+func TestParseISO2709TruncatedField(t *testing.T) {
+	// Um tamanho maior que os dados é tolerado e o campo vai até o fim do registro.
+	raw := buildISO2709("001:abc")
+	copy(raw[leaderLength+3:], "0999")
+	record, err := ParseISO2709(raw)
+	if err != nil {
+		t.Fatalf("ParseISO2709: %v", err)
+	}
+	if f, _ := record.Field("001"); f.Value != "abc" {
+		t.Errorf("001 = %q, want %q", f.Value, "abc")
+	}
+}
+
+func TestReaderSkipsInvalidRecords(t *testing.T) {
+	bad := buildISO2709("001:bad")
+	copy(bad[leaderLength+7:], "-0001")
+	stream := string(buildISO2709("001:one")) + "\n" + string(bad) + string(buildISO2709("001:two"))
+
+	reader := NewReader(strings.NewReader(stream))
+	var ids []string
+	var invalid int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if errors.Is(err, ErrInvalidRecord) {
+			invalid++
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		f, _ := record.Field("001")
+		ids = append(ids, f.Value)
+	}
+	if strings.Join(ids, ",") != "one,two" || invalid != 1 {
+		t.Errorf("read %v with %d invalid records, want [one two] with 1", ids, invalid)
+	}
+}
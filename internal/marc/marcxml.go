@@ -0,0 +1,171 @@
+package marc
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Namespace is the MARCXML schema namespace.
+const Namespace = "http://www.loc.gov/MARC21/slim"
+
+type xmlRecord struct {
+	XMLName       xml.Name          `xml:"record"`
+	Leader        string            `xml:"leader"`
+	ControlFields []xmlControlField `xml:"controlfield"`
+	DataFields    []xmlDataField    `xml:"datafield"`
+}
+
+type xmlControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlDataField struct {
+	Tag       string        `xml:"tag,attr"`
+	Ind1      string        `xml:"ind1,attr"`
+	Ind2      string        `xml:"ind2,attr"`
+	Subfields []xmlSubfield `xml:"subfield"`
+}
+
+type xmlSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// XMLReader reads records from a MARCXML document, which may contain a
+// single record or a collection of records.
+type XMLReader struct {
+	decoder *xml.Decoder
+}
+
+// NewXMLReader returns a reader for a MARCXML document.
+func NewXMLReader(r io.Reader) *XMLReader {
+	return &XMLReader{decoder: xml.NewDecoder(r)}
+}
+
+// Read returns the next record, or io.EOF when there are no more records.
+func (xr *XMLReader) Read() (*Record, error) {
+	for {
+		token, err := xr.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+
+		var raw xmlRecord
+		if err := xr.decoder.DecodeElement(&raw, &start); err != nil {
+			return nil, err
+		}
+		return raw.toRecord(), nil
+	}
+}
+
+func (raw *xmlRecord) toRecord() *Record {
+	record := &Record{Leader: raw.Leader}
+	// O MARCXML separa campos de controle e de dados; mantemos a ordem dos tags.
+	for _, cf := range raw.ControlFields {
+		record.Fields = append(record.Fields, Field{Tag: cf.Tag, Value: cf.Value})
+	}
+	for _, df := range raw.DataFields {
+		field := Field{Tag: df.Tag, Ind1: indicatorByte(df.Ind1), Ind2: indicatorByte(df.Ind2)}
+		for _, sf := range df.Subfields {
+			if sf.Code == "" {
+				continue
+			}
+			field.Subfields = append(field.Subfields, Subfield{Code: sf.Code[0], Value: sf.Value})
+		}
+		record.Fields = append(record.Fields, field)
+	}
+	return record
+}
+
+func indicatorByte(s string) byte {
+	if s == "" {
+		return ' '
+	}
+	return s[0]
+}
+
+// XMLWriter writes records as a MARCXML collection.
+type XMLWriter struct {
+	w       io.Writer
+	encoder *xml.Encoder
+	started bool
+}
+
+// NewXMLWriter returns a writer that emits a MARCXML collection to w.
+func NewXMLWriter(w io.Writer) *XMLWriter {
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return &XMLWriter{w: w, encoder: encoder}
+}
+
+// Write appends a record to the collection.
+func (xw *XMLWriter) Write(record *Record) error {
+	if err := xw.start(); err != nil {
+		return err
+	}
+
+	raw := xmlRecord{Leader: normalizeLeader(record.Leader)}
+	for _, f := range record.Fields {
+		if IsControl(f.Tag) {
+			raw.ControlFields = append(raw.ControlFields, xmlControlField{Tag: f.Tag, Value: f.Value})
+			continue
+		}
+		df := xmlDataField{Tag: f.Tag, Ind1: string(indicator(f.Ind1)), Ind2: string(indicator(f.Ind2))}
+		for _, sf := range f.Subfields {
+			df.Subfields = append(df.Subfields, xmlSubfield{Code: string(sf.Code), Value: sf.Value})
+		}
+		raw.DataFields = append(raw.DataFields, df)
+	}
+	return xw.encoder.Encode(raw)
+}
+
+// Close ends the collection element.
+func (xw *XMLWriter) Close() error {
+	if err := xw.start(); err != nil {
+		return err
+	}
+	if err := xw.encoder.EncodeToken(xml.EndElement{Name: xml.Name{Local: "collection"}}); err != nil {
+		return err
+	}
+	if err := xw.encoder.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(xw.w, "\n")
+	return err
+}
+
+func (xw *XMLWriter) start() error {
+	if xw.started {
+		return nil
+	}
+	xw.started = true
+	if _, err := io.WriteString(xw.w, xml.Header); err != nil {
+		return err
+	}
+	return xw.encoder.EncodeToken(xml.StartElement{
+		Name: xml.Name{Local: "collection"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: Namespace}},
+	})
+}
+
+// defaultLeader describes a new Unicode record for printed language material.
+const defaultLeader = "00000nam a2200000 i 4500"
+
+func normalizeLeader(leader string) string {
+	if len(leader) != leaderLength {
+		return defaultLeader
+	}
+	return leader
+}
+
+func indicator(b byte) byte {
+	if b == 0 {
+		return ' '
+	}
+	return b
+}
@@ -0,0 +1,119 @@
+// Package marc reads and writes bibliographic records in MARC21, both in the
+// binary ISO 2709 transmission format and in MARCXML.
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+)
+
+// RecordReader is implemented by the ISO 2709 and MARCXML readers.
+type RecordReader interface {
+	// Read returns the next record, or io.EOF when there are no more records.
+	Read() (*Record, error)
+}
+
+// NewRecordReader detects whether r holds MARCXML or ISO 2709 data and
+// returns the matching reader.
+func NewRecordReader(r io.Reader) RecordReader {
+	br := bufio.NewReader(r)
+	peek, _ := br.Peek(512)
+	if bytes.HasPrefix(bytes.TrimLeft(peek, "\ufeff \t\r\n"), []byte("<")) {
+		return NewXMLReader(br)
+	}
+	return NewReader(br)
+}
+
+// Record is a single MARC record.
+type Record struct {
+	Leader string
+	Fields []Field
+}
+
+// Field is either a control field (tags 001-009), which only has a Value,
+// or a data field with indicators and subfields.
+type Field struct {
+	Tag       string
+	Ind1      byte
+	Ind2      byte
+	Value     string
+	Subfields []Subfield
+}
+
+// Subfield is a coded piece of a data field.
+type Subfield struct {
+	Code  byte
+	Value string
+}
+
+// IsControl reports whether the tag belongs to a control field.
+func IsControl(tag string) bool {
+	return strings.HasPrefix(tag, "00")
+}
+
+// FieldsByTag returns all fields with the given tag.
+func (r *Record) FieldsByTag(tag string) []Field {
+	var fields []Field
+	for _, f := range r.Fields {
+		if f.Tag == tag {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Field returns the first field with the given tag.
+func (r *Record) Field(tag string) (Field, bool) {
+	for _, f := range r.Fields {
+		if f.Tag == tag {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// AddControlField appends a control field, unless value is empty.
+func (r *Record) AddControlField(tag, value string) {
+	if value == "" {
+		return
+	}
+	r.Fields = append(r.Fields, Field{Tag: tag, Value: value})
+}
+
+// AddDataField appends a data field, skipping subfields with empty values.
+// Nothing is added when every subfield is empty.
+func (r *Record) AddDataField(tag string, ind1, ind2 byte, subfields ...Subfield) {
+	var kept []Subfield
+	for _, sf := range subfields {
+		if sf.Value != "" {
+			kept = append(kept, sf)
+		}
+	}
+	if len(kept) == 0 {
+		return
+	}
+	r.Fields = append(r.Fields, Field{Tag: tag, Ind1: ind1, Ind2: ind2, Subfields: kept})
+}
+
+// Subfield returns the first subfield with the given code.
+func (f Field) Subfield(code byte) string {
+	for _, sf := range f.Subfields {
+		if sf.Code == code {
+			return sf.Value
+		}
+	}
+	return ""
+}
+
+// SubfieldValues returns every subfield with the given code.
+func (f Field) SubfieldValues(code byte) []string {
+	var values []string
+	for _, sf := range f.Subfields {
+		if sf.Code == code {
+			values = append(values, sf.Value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,82 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+// cacheTTL is how long a cached lookup stays fresh.
+const cacheTTL = 30 * 24 * time.Hour
+
+// CachedProvider keeps the results of another provider as JSON files in a
+// local directory, so each ISBN is only fetched once.
+type CachedProvider struct {
+	next Provider
+	dir  string
+}
+
+// NewCachedProvider wraps next with a file cache stored in dir.
+func NewCachedProvider(next Provider, dir string) *CachedProvider {
+	return &CachedProvider{next: next, dir: dir}
+}
+
+func (p *CachedProvider) Name() string { return p.next.Name() }
+
+type cacheEntry struct {
+	FetchedAt time.Time    `json:"fetched_at"`
+	Book      *domain.Book `json:"book"`
+}
+
+func (p *CachedProvider) LookupISBN(ctx context.Context, isbn13 string) (*domain.Book, error) {
+	path := filepath.Join(p.dir, p.next.Name(), isbn13+".json")
+	if book := readCache(path); book != nil {
+		return book, nil
+	}
+
+	book, err := p.next.LookupISBN(ctx, isbn13)
+	if err != nil {
+		return nil, err
+	}
+	// Falhas ao gravar o cache não impedem a consulta.
+	if err := writeCache(path, book); err != nil {
+		log.Printf("Erro ao gravar o cache de metadados: %v", err)
+	}
+	return book, nil
+}
+
+func readCache(path string) *domain.Book {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Book == nil {
+		return nil
+	}
+	if time.Since(entry.FetchedAt) > cacheTTL {
+		return nil
+	}
+	return entry.Book
+}
+
+func writeCache(path string, book *domain.Book) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(cacheEntry{FetchedAt: time.Now(), Book: book})
+	if err != nil {
+		return err
+	}
+	// Grava em um arquivo temporário e renomeia, para nunca deixar um cache pela metade.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
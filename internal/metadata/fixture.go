@@ -0,0 +1,43 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+// FixtureProvider serves metadata from JSON files named after the ISBN-13
+// (for example 9788535902778.json), each holding a book in the API format.
+// It is meant for offline use and for tests.
+type FixtureProvider struct {
+	dir string
+}
+
+// NewFixtureProvider creates a provider that reads fixtures from dir.
+func NewFixtureProvider(dir string) *FixtureProvider {
+	return &FixtureProvider{dir: dir}
+}
+
+func (p *FixtureProvider) Name() string { return ProviderFixture }
+
+func (p *FixtureProvider) LookupISBN(ctx context.Context, isbn13 string) (*domain.Book, error) {
+	data, err := os.ReadFile(filepath.Join(p.dir, isbn13+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var book domain.Book
+	if err := json.Unmarshal(data, &book); err != nil {
+		return nil, err
+	}
+	book.ID = ""
+	return &book, nil
+}
@@ -0,0 +1,67 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fixtureDir is the fixtures folder at the repository root, also used by
+// the fixture provider by default.
+const fixtureDir = "../../fixtures/metadata"
+
+func TestFixtureProviderLookupISBN(t *testing.T) {
+	p := NewFixtureProvider(fixtureDir)
+	tests := []struct {
+		isbn13    string
+		wantTitle string
+		wantPages int
+		wantErr   error
+	}{
+		{isbn13: "9788535902778", wantTitle: "Dom Casmurro", wantPages: 256},
+		{isbn13: "9780306406157", wantTitle: "Semiconductor Physics", wantPages: 476},
+		{isbn13: "9780000000002", wantErr: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.isbn13, func(t *testing.T) {
+			book, err := p.LookupISBN(context.Background(), tt.isbn13)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LookupISBN error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if book.Title != tt.wantTitle || book.Pages != tt.wantPages || book.ISBN13 != tt.isbn13 {
+				t.Errorf("book = %+v, want %q with %d pages", book, tt.wantTitle, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestFixtureProviderIgnoresID(t *testing.T) {
+	dir := t.TempDir()
+	data := `{"id": "kept-elsewhere", "title": "Vidas Secas"}`
+	if err := os.WriteFile(filepath.Join(dir, "9788501006677.json"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	book, err := NewFixtureProvider(dir).LookupISBN(context.Background(), "9788501006677")
+	if err != nil {
+		t.Fatalf("LookupISBN: %v", err)
+	}
+	if book.ID != "" || book.Title != "Vidas Secas" {
+		t.Errorf("book = %+v, want title and no ID", book)
+	}
+}
+
+func TestFixtureProviderInvalidJSON(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "9788501006677.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := NewFixtureProvider(dir).LookupISBN(context.Background(), "9788501006677")
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("LookupISBN error = %v, want a decoding error", err)
+	}
+}
@@ -0,0 +1,52 @@
+package metadata
+
+import "github.com/rfulgencio3/go-personal-library/internal/domain"
+
+// Merge fills the empty fields of dst with the values found in src, field by
+// field. Data already present in dst, typically typed by the user, is never
+// overwritten. ID and Comments are personal and are never copied.
+func Merge(dst, src *domain.Book) {
+	if src == nil {
+		return
+	}
+	mergeString(&dst.Title, src.Title)
+	mergeString(&dst.Subtitle, src.Subtitle)
+	// Os colaboradores da fonte só valem quando descrevem os mesmos autores.
+	if len(dst.Contributors) == 0 && (dst.Author == "" || dst.Author == src.Author) {
+		dst.Contributors = src.Contributors
+	}
+	mergeString(&dst.Author, src.Author)
+	mergeString(&dst.Publisher, src.Publisher)
+	mergeString(&dst.Edition, src.Edition)
+	mergeString(&dst.Language, src.Language)
+	mergeString(&dst.Description, src.Description)
+	mergeString(&dst.Series, src.Series)
+	mergeString(&dst.ISBN10, src.ISBN10)
+	mergeString(&dst.ISBN13, src.ISBN13)
+	if dst.Pages == 0 {
+		dst.Pages = src.Pages
+	}
+	if dst.Year == 0 {
+		dst.Year = src.Year
+	}
+	if dst.SeriesPosition == 0 {
+		dst.SeriesPosition = src.SeriesPosition
+	}
+	if len(dst.Tags) == 0 {
+		dst.Tags = src.Tags
+	}
+	for scheme, value := range src.Identifiers {
+		if dst.Identifiers == nil {
+			dst.Identifiers = make(map[string]string)
+		}
+		if dst.Identifiers[scheme] == "" {
+			dst.Identifiers[scheme] = value
+		}
+	}
+}
+
+func mergeString(dst *string, src string) {
+	if *dst == "" {
+		*dst = src
+	}
+}
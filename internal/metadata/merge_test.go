@@ -0,0 +1,108 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+func TestMerge(t *testing.T) {
+	translator := domain.Contributor{Name: "Eliane Zagury", Role: domain.RoleTranslator, Order: 1}
+	src := &domain.Book{
+		ID:           "provider-id",
+		Title:        "Cem anos de solidão",
+		Author:       "Gabriel García Márquez",
+		Contributors: []domain.Contributor{{Name: "Gabriel García Márquez", Role: domain.RoleAuthor, Order: 1}},
+		Pages:        448,
+		Publisher:    "Record",
+		Comments:     "do provedor",
+		Year:         1967,
+		Tags:         []string{"Realismo mágico"},
+		Identifiers:  map[string]string{domain.IdentifierOpenLibrary: "OL1M", "goodreads": "320"},
+	}
+
+	tests := []struct {
+		name string
+		dst  *domain.Book
+		want *domain.Book
+	}{
+		{
+			name: "empty draft takes everything but ID and comments",
+			dst:  &domain.Book{},
+			want: &domain.Book{
+				Title:        src.Title,
+				Author:       src.Author,
+				Contributors: src.Contributors,
+				Pages:        448,
+				Publisher:    "Record",
+				Year:         1967,
+				Tags:         src.Tags,
+				Identifiers:  src.Identifiers,
+			},
+		},
+		{
+			name: "typed fields win",
+			dst: &domain.Book{
+				Title:       "Cien años de soledad",
+				Pages:       471,
+				Comments:    "presente",
+				Identifiers: map[string]string{domain.IdentifierOpenLibrary: "OL2M"},
+			},
+			want: &domain.Book{
+				Title:        "Cien años de soledad",
+				Author:       src.Author,
+				Contributors: src.Contributors,
+				Pages:        471,
+				Publisher:    "Record",
+				Comments:     "presente",
+				Year:         1967,
+				Tags:         src.Tags,
+				Identifiers:  map[string]string{domain.IdentifierOpenLibrary: "OL2M", "goodreads": "320"},
+			},
+		},
+		{
+			name: "other author keeps provider contributors out",
+			dst:  &domain.Book{Author: "Outro Autor"},
+			want: &domain.Book{
+				Title:       src.Title,
+				Author:      "Outro Autor",
+				Pages:       448,
+				Publisher:   "Record",
+				Year:        1967,
+				Tags:        src.Tags,
+				Identifiers: src.Identifiers,
+			},
+		},
+		{
+			name: "typed contributors are kept",
+			dst:  &domain.Book{Contributors: []domain.Contributor{translator}},
+			want: &domain.Book{
+				Title:        src.Title,
+				Author:       src.Author,
+				Contributors: []domain.Contributor{translator},
+				Pages:        448,
+				Publisher:    "Record",
+				Year:         1967,
+				Tags:         src.Tags,
+				Identifiers:  src.Identifiers,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Merge(tt.dst, src)
+			if !reflect.DeepEqual(tt.dst, tt.want) {
+				t.Errorf("Merge =\n%+v\nwant\n%+v", tt.dst, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeNilSource(t *testing.T) {
+	dst := &domain.Book{Title: "Vidas Secas"}
+	Merge(dst, nil)
+	if !reflect.DeepEqual(dst, &domain.Book{Title: "Vidas Secas"}) {
+		t.Errorf("Merge with nil source changed the book: %+v", dst)
+	}
+}
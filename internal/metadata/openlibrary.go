@@ -0,0 +1,129 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+// DefaultOpenLibraryURL is the public Open Library instance.
+const DefaultOpenLibraryURL = "https://openlibrary.org"
+
+// OpenLibraryProvider queries the Open Library Books API.
+type OpenLibraryProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewOpenLibraryProvider creates a provider for the Open Library instance at baseURL.
+func NewOpenLibraryProvider(baseURL string) *OpenLibraryProvider {
+	if baseURL == "" {
+		baseURL = DefaultOpenLibraryURL
+	}
+	return &OpenLibraryProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *OpenLibraryProvider) Name() string { return ProviderOpenLibrary }
+
+type openLibraryBook struct {
+	Title         string              `json:"title"`
+	Subtitle      string              `json:"subtitle"`
+	Authors       []openLibraryNamed  `json:"authors"`
+	Publishers    []openLibraryNamed  `json:"publishers"`
+	NumberOfPages int                 `json:"number_of_pages"`
+	PublishDate   string              `json:"publish_date"`
+	Subjects      []openLibraryNamed  `json:"subjects"`
+	Identifiers   map[string][]string `json:"identifiers"`
+	Excerpts      []struct {
+		Text string `json:"text"`
+	} `json:"excerpts"`
+}
+
+type openLibraryNamed struct {
+	Name string `json:"name"`
+}
+
+var yearPattern = regexp.MustCompile(`\d{4}`)
+
+// maxSubjectTags caps the subjects kept as tags. Open Library lists dozens
+// for popular books, most first-listed ones being the most relevant, and
+// every tag kept is created in the tag tree.
+const maxSubjectTags = 5
+
+// LookupISBN fetches the book data for an ISBN-13.
+func (p *OpenLibraryProvider) LookupISBN(ctx context.Context, isbn13 string) (*domain.Book, error) {
+	key := "ISBN:" + isbn13
+	query := url.Values{"bibkeys": {key}, "format": {"json"}, "jscmd": {"data"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/api/books?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("open library returned status %d", resp.StatusCode)
+	}
+
+	var results map[string]openLibraryBook
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, err
+	}
+	data, ok := results[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return data.toBook(), nil
+}
+
+func (data openLibraryBook) toBook() *domain.Book {
+	book := &domain.Book{
+		Title:    data.Title,
+		Subtitle: data.Subtitle,
+		Pages:    data.NumberOfPages,
+	}
+
+	var authors []string
+	for _, a := range data.Authors {
+		authors = append(authors, a.Name)
+	}
+	book.Author = domain.JoinAuthorNames(authors)
+	if len(data.Publishers) > 0 {
+		book.Publisher = data.Publishers[0].Name
+	}
+	book.Year, _ = strconv.Atoi(yearPattern.FindString(data.PublishDate))
+	for _, s := range data.Subjects {
+		if len(book.Tags) == maxSubjectTags {
+			break
+		}
+		book.Tags = append(book.Tags, s.Name)
+	}
+	if len(data.Excerpts) > 0 {
+		book.Description = data.Excerpts[0].Text
+	}
+	if ids := data.Identifiers["isbn_10"]; len(ids) > 0 {
+		book.ISBN10 = ids[0]
+	}
+	if ids := data.Identifiers["isbn_13"]; len(ids) > 0 {
+		book.ISBN13 = ids[0]
+	}
+	if ids := data.Identifiers["openlibrary"]; len(ids) > 0 {
+		book.Identifiers = map[string]string{domain.IdentifierOpenLibrary: ids[0]}
+	}
+	return book
+}
@@ -0,0 +1,47 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenLibraryLookupISBN(t *testing.T) {
+	var subjects []string
+	for i := 1; i <= 8; i++ {
+		subjects = append(subjects, fmt.Sprintf(`{"name": "Assunto %d"}`, i))
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("bibkeys") != "ISBN:9788535902778" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		fmt.Fprintf(w, `{"ISBN:9788535902778": {
+			"title": "Os Trabalhadores do Mar",
+			"authors": [{"name": "Hugo, Victor"}, {"name": "Machado de Assis"}],
+			"number_of_pages": 512,
+			"subjects": [%s]
+		}}`, strings.Join(subjects, ","))
+	}))
+	defer server.Close()
+
+	book, err := NewOpenLibraryProvider(server.URL).LookupISBN(context.Background(), "9788535902778")
+	if err != nil {
+		t.Fatalf("LookupISBN: %v", err)
+	}
+	// Um nome invertido pede "; " para que o campo volte a dar os mesmos autores.
+	if book.Author != "Hugo, Victor; Machado de Assis" {
+		t.Errorf("author = %q, want the names joined with \"; \"", book.Author)
+	}
+	if len(book.Tags) != maxSubjectTags || book.Tags[0] != "Assunto 1" {
+		t.Errorf("tags = %v, want the first %d subjects", book.Tags, maxSubjectTags)
+	}
+
+	if _, err := NewOpenLibraryProvider(server.URL).LookupISBN(context.Background(), "9780306406157"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LookupISBN of an unknown ISBN error = %v, want %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,49 @@
+// Package metadata looks up bibliographic data for books from external
+// sources, so they do not have to be typed by hand.
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var ErrNotFound = errors.New("no metadata found for this ISBN")
+
+// Provider returns the metadata known for an ISBN-13 as a partially filled book.
+type Provider interface {
+	Name() string
+	LookupISBN(ctx context.Context, isbn13 string) (*domain.Book, error)
+}
+
+// Provider names accepted by METADATA_PROVIDER.
+const (
+	ProviderOpenLibrary = "openlibrary"
+	ProviderFixture     = "fixture"
+	ProviderNone        = "none"
+)
+
+// NewFromConfig builds the provider selected in the configuration, wrapped
+// with the local cache when a cache directory is set. It returns nil when
+// metadata lookups are disabled.
+func NewFromConfig(config *configs.Config) (Provider, error) {
+	var provider Provider
+	switch config.MetadataProvider {
+	case ProviderOpenLibrary:
+		provider = NewOpenLibraryProvider(config.OpenLibraryURL)
+	case ProviderFixture:
+		provider = NewFixtureProvider(config.MetadataFixtureDir)
+	case ProviderNone, "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown metadata provider %q", config.MetadataProvider)
+	}
+
+	if config.MetadataCacheDir != "" {
+		provider = NewCachedProvider(provider, config.MetadataCacheDir)
+	}
+	return provider, nil
+}
@@ -0,0 +1,285 @@
+// Package opf parses OPF package documents, the metadata format used by
+// EPUB files and by Calibre's metadata.opf sidecar files.
+package opf
+
+import (
+	"encoding/xml"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+// Package is the root element of an OPF document.
+type Package struct {
+	Version  string    `xml:"version,attr"`
+	Metadata Metadata  `xml:"metadata"`
+	Manifest []Item    `xml:"manifest>item"`
+	Spine    []ItemRef `xml:"spine>itemref"`
+}
+
+// Metadata holds the Dublin Core elements and meta tags of a package.
+type Metadata struct {
+	Titles       []Element    `xml:"title"`
+	Creators     []Creator    `xml:"creator"`
+	Contributors []Creator    `xml:"contributor"`
+	Publishers   []string     `xml:"publisher"`
+	Identifiers  []Identifier `xml:"identifier"`
+	Subjects     []string     `xml:"subject"`
+	Description  string       `xml:"description"`
+	Languages    []string     `xml:"language"`
+	Dates        []string     `xml:"date"`
+	Metas        []Meta       `xml:"meta"`
+}
+
+// Element is a generic metadata element with an optional id.
+type Element struct {
+	ID    string `xml:"id,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Creator is a dc:creator or dc:contributor element. OPF 2 stores the role as an attribute,
+// while EPUB 3 refines it through a separate meta element.
+type Creator struct {
+	ID     string `xml:"id,attr"`
+	Role   string `xml:"role,attr"`
+	FileAs string `xml:"file-as,attr"`
+	Name   string `xml:",chardata"`
+}
+
+// Identifier is a dc:identifier element.
+type Identifier struct {
+	ID     string `xml:"id,attr"`
+	Scheme string `xml:"scheme,attr"`
+	Value  string `xml:",chardata"`
+}
+
+// Meta covers both the OPF 2 (name/content) and EPUB 3 (property/refines) forms.
+type Meta struct {
+	Name     string `xml:"name,attr"`
+	Content  string `xml:"content,attr"`
+	Property string `xml:"property,attr"`
+	Refines  string `xml:"refines,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// Item is a manifest entry.
+type Item struct {
+	ID         string `xml:"id,attr"`
+	Href       string `xml:"href,attr"`
+	MediaType  string `xml:"media-type,attr"`
+	Properties string `xml:"properties,attr"`
+}
+
+// ItemRef is a spine entry pointing at a manifest item.
+type ItemRef struct {
+	IDRef string `xml:"idref,attr"`
+}
+
+// Parse decodes an OPF package document.
+func Parse(r io.Reader) (*Package, error) {
+	var pkg Package
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		// Os arquivos OPF são quase sempre UTF-8; outros charsets são lidos como estão.
+		return input, nil
+	}
+	if err := decoder.Decode(&pkg); err != nil {
+		return nil, err
+	}
+	return &pkg, nil
+}
+
+// Title returns the main title of the package.
+func (p *Package) Title() string {
+	main := ""
+	for _, t := range p.Metadata.Titles {
+		if main == "" {
+			main = strings.TrimSpace(t.Value)
+		}
+		if t.ID != "" && p.refinement(t.ID, "title-type") == "main" {
+			return strings.TrimSpace(t.Value)
+		}
+	}
+	return main
+}
+
+// Subtitle returns the EPUB 3 subtitle, when one is declared.
+func (p *Package) Subtitle() string {
+	for _, t := range p.Metadata.Titles {
+		if t.ID != "" && p.refinement(t.ID, "title-type") == "subtitle" {
+			return strings.TrimSpace(t.Value)
+		}
+	}
+	return ""
+}
+
+// Authors returns the names of the creators whose role is author. Creators
+// without a role are treated as authors, as most tools omit it.
+func (p *Package) Authors() []string {
+	var authors []string
+	for _, c := range p.Metadata.Creators {
+		role := c.Role
+		if role == "" && c.ID != "" {
+			role = p.refinement(c.ID, "role")
+		}
+		if role != "" && role != "aut" {
+			continue
+		}
+		if name := strings.TrimSpace(c.Name); name != "" {
+			authors = append(authors, name)
+		}
+	}
+	return authors
+}
+
+// Contributors returns the people credited in the package with a known role,
+// creators first, in document order. Creators without a role are credited as
+// authors; contributors without one are skipped.
+func (p *Package) Contributors() []domain.Contributor {
+	var contributors []domain.Contributor
+	for i, c := range append(p.Metadata.Creators, p.Metadata.Contributors...) {
+		name := strings.TrimSpace(c.Name)
+		relator := c.Role
+		if relator == "" && c.ID != "" {
+			relator = p.refinement(c.ID, "role")
+		}
+		if relator == "" && i < len(p.Metadata.Creators) {
+			relator = "aut"
+		}
+		role, ok := domain.RoleFromRelator(relator)
+		if name == "" || !ok {
+			continue
+		}
+		contributors = append(contributors, domain.Contributor{Name: name, Role: role, Order: len(contributors) + 1})
+	}
+	return contributors
+}
+
+// Publisher returns the first declared publisher.
+func (p *Package) Publisher() string {
+	for _, pub := range p.Metadata.Publishers {
+		if pub = strings.TrimSpace(pub); pub != "" {
+			return pub
+		}
+	}
+	return ""
+}
+
+// Language returns the first declared language.
+func (p *Package) Language() string {
+	for _, lang := range p.Metadata.Languages {
+		if lang = strings.TrimSpace(lang); lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
+
+// Identifiers returns the package identifiers keyed by their lower-cased
+// scheme. EPUB 3 identifiers written as URNs ("urn:isbn:...") are split into
+// scheme and value; identifiers that are URLs have no scheme and are skipped.
+func (p *Package) Identifiers() map[string]string {
+	ids := make(map[string]string)
+	for _, id := range p.Metadata.Identifiers {
+		scheme := strings.ToLower(strings.TrimSpace(id.Scheme))
+		value := strings.TrimSpace(id.Value)
+		if value == "" {
+			continue
+		}
+		if scheme == "" {
+			if parts := strings.SplitN(value, ":", 3); len(parts) == 3 && strings.EqualFold(parts[0], "urn") {
+				scheme, value = strings.ToLower(parts[1]), parts[2]
+			} else if parts := strings.SplitN(value, ":", 2); len(parts) == 2 && !strings.Contains(parts[0], "/") && !strings.HasPrefix(parts[1], "//") {
+				scheme, value = strings.ToLower(parts[0]), parts[1]
+			}
+		}
+		if scheme == "" {
+			continue
+		}
+		if _, exists := ids[scheme]; !exists {
+			ids[scheme] = value
+		}
+	}
+	return ids
+}
+
+// Tags returns the dc:subject entries.
+func (p *Package) Tags() []string {
+	var tags []string
+	for _, s := range p.Metadata.Subjects {
+		if s = strings.TrimSpace(s); s != "" {
+			tags = append(tags, s)
+		}
+	}
+	return tags
+}
+
+// Description returns the description as plain text. Calibre stores it as HTML.
+func (p *Package) Description() string {
+	return StripHTML(p.Metadata.Description)
+}
+
+// Year returns the year of the first dc:date element, or an empty string.
+func (p *Package) Year() string {
+	for _, d := range p.Metadata.Dates {
+		d = strings.TrimSpace(d)
+		if len(d) >= 4 && d[:4] != "0101" {
+			return d[:4]
+		}
+	}
+	return ""
+}
+
+// Meta returns the content of the OPF 2 meta element with the given name,
+// falling back to the EPUB 3 meta element with the same property.
+func (p *Package) Meta(name string) string {
+	for _, m := range p.Metadata.Metas {
+		if m.Name == name {
+			return strings.TrimSpace(m.Content)
+		}
+	}
+	for _, m := range p.Metadata.Metas {
+		if m.Property == name && m.Refines == "" {
+			return strings.TrimSpace(m.Value)
+		}
+	}
+	return ""
+}
+
+// Item returns the manifest item with the given id.
+func (p *Package) Item(id string) (Item, bool) {
+	for _, item := range p.Manifest {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+// refinement returns the value of an EPUB 3 meta that refines the element with the given id.
+func (p *Package) refinement(id, property string) string {
+	for _, m := range p.Metadata.Metas {
+		if m.Refines == "#"+id && m.Property == property {
+			return strings.TrimSpace(m.Value)
+		}
+	}
+	return ""
+}
+
+var (
+	tagPattern        = regexp.MustCompile(`<[^>]*>`)
+	blockTagPattern   = regexp.MustCompile(`(?i)<\s*(br|/p|/div|/li|/h[1-6])\s*/?>`)
+	blankLinesPattern = regexp.MustCompile(`\n\s*\n+`)
+)
+
+// StripHTML converts a small HTML fragment into plain text.
+func StripHTML(s string) string {
+	s = blockTagPattern.ReplaceAllString(s, "\n")
+	s = tagPattern.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = blankLinesPattern.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,101 @@
+package opf
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+// epub3Package is the metadata of an EPUB 3 file, which refines titles and
+// creators through meta elements instead of attributes.
+const epub3Package = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="pub-id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="pub-id">urn:isbn:9780306406157</dc:identifier>
+    <dc:identifier>urn:uuid:0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b</dc:identifier>
+    <dc:identifier>https://example.com/book</dc:identifier>
+    <dc:title id="t2">Uma introdução</dc:title>
+    <dc:title id="t1">Física dos Semicondutores</dc:title>
+    <meta refines="#t1" property="title-type">main</meta>
+    <meta refines="#t2" property="title-type">subtitle</meta>
+    <dc:creator id="c1">Jane Doe</dc:creator>
+    <meta refines="#c1" property="role" scheme="marc:relators">aut</meta>
+    <dc:creator id="c2">John Roe</dc:creator>
+    <meta refines="#c2" property="role" scheme="marc:relators">edt</meta>
+    <dc:creator>Ann Poe</dc:creator>
+    <dc:contributor id="c3">Maria Silva</dc:contributor>
+    <meta refines="#c3" property="role" scheme="marc:relators">trl</meta>
+    <dc:contributor>Sem Papel</dc:contributor>
+    <dc:language>pt-BR</dc:language>
+    <dc:date>2019</dc:date>
+    <meta property="dcterms:modified">2024-01-01T00:00:00Z</meta>
+    <meta property="belongs-to-collection" id="s1">Física Moderna</meta>
+  </metadata>
+  <manifest>
+    <item id="cover" href="images/cover.jpg" media-type="image/jpeg" properties="cover-image"/>
+  </manifest>
+  <spine><itemref idref="cover"/></spine>
+</package>`
+
+func TestParseEPUB3(t *testing.T) {
+	pkg, err := Parse(strings.NewReader(epub3Package))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"title", pkg.Title(), "Física dos Semicondutores"},
+		{"subtitle", pkg.Subtitle(), "Uma introdução"},
+		{"authors", pkg.Authors(), []string{"Jane Doe", "Ann Poe"}},
+		{"contributors", pkg.Contributors(), []domain.Contributor{
+			{Name: "Jane Doe", Role: domain.RoleAuthor, Order: 1},
+			{Name: "John Roe", Role: domain.RoleEditor, Order: 2},
+			{Name: "Ann Poe", Role: domain.RoleAuthor, Order: 3},
+			{Name: "Maria Silva", Role: domain.RoleTranslator, Order: 4},
+		}},
+		{"identifiers", pkg.Identifiers(), map[string]string{"isbn": "9780306406157", "uuid": "0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b"}},
+		{"language", pkg.Language(), "pt-BR"},
+		{"year", pkg.Year(), "2019"},
+		{"meta property", pkg.Meta("belongs-to-collection"), "Física Moderna"},
+		{"missing meta", pkg.Meta("calibre:series"), ""},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %#v, want %#v", tt.name, tt.got, tt.want)
+		}
+	}
+	if item, ok := pkg.Item("cover"); !ok || item.Href != "images/cover.jpg" {
+		t.Errorf("Item(cover) = %+v, %v", item, ok)
+	}
+}
+
+func TestParseRejectsMalformed(t *testing.T) {
+	for _, doc := range []string{"", "<package><metadata>", "not xml"} {
+		if _, err := Parse(strings.NewReader(doc)); err == nil {
+			t.Errorf("Parse(%q) succeeded", doc)
+		}
+	}
+}
+
+func TestStripHTML(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"<p>Um</p><p>Dois</p>", "Um\nDois"},
+		{"linha<br/>outra", "linha\noutra"},
+		{"<div><b>negrito</b> &amp; &quot;aspas&quot;</div>", `negrito & "aspas"`},
+		{"<p>a</p>\n\n\n<p>b</p>", "a\n\nb"},
+		{"  texto puro  ", "texto puro"},
+	}
+	for _, tt := range tests {
+		if got := StripHTML(tt.in); got != tt.want {
+			t.Errorf("StripHTML(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,492 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+)
+
+// maxXrefSections limits how many /Prev links are followed, so cyclic
+// cross-reference chains terminate.
+const maxXrefSections = 256
+
+// xrefEntry locates an object either at a byte offset or inside an object stream.
+type xrefEntry struct {
+	offset   int
+	inStream bool
+	stream   int
+	index    int
+}
+
+// File gives access to the objects of a PDF. It tolerates damaged files by
+// rebuilding the cross-reference table from the object headers when the
+// one recorded in the file cannot be used.
+type File struct {
+	data      []byte
+	xref      map[int]xrefEntry
+	trailer   dict
+	cache     map[int]object
+	resolving map[int]bool
+	objStms   map[int]*objectStream
+	rebuilt   bool
+	// decoded counts the bytes decoded from streams, see maxTotalDecoded.
+	decoded int
+}
+
+type objectStream struct {
+	data    []byte
+	first   int
+	offsets []int
+}
+
+func newFile(data []byte) *File {
+	f := &File{
+		data:      data,
+		xref:      make(map[int]xrefEntry),
+		cache:     make(map[int]object),
+		resolving: make(map[int]bool),
+		objStms:   make(map[int]*objectStream),
+	}
+	if err := f.readXref(); err != nil || f.catalog() == nil {
+		f.rebuild()
+	}
+	return f
+}
+
+// readXref follows the chain of cross-reference sections from the last
+// startxref. Newer sections come first, so entries already known win.
+// Linearized files simply have an extra section at the start of the file.
+func (f *File) readXref() error {
+	idx := bytes.LastIndex(f.data, []byte("startxref"))
+	if idx < 0 {
+		return errMalformed
+	}
+	p := &parser{data: f.data, pos: idx + len("startxref")}
+	offset, ok := mustInt(p.parseObject(0))
+	if !ok {
+		return errMalformed
+	}
+
+	visited := make(map[int]bool)
+	pending := []int{offset}
+	for len(pending) > 0 && len(visited) < maxXrefSections {
+		offset := pending[0]
+		pending = pending[1:]
+		if visited[offset] || offset < 0 || offset >= len(f.data) {
+			continue
+		}
+		visited[offset] = true
+
+		trailer, err := f.readXrefSection(offset)
+		if err != nil {
+			return err
+		}
+		if f.trailer == nil {
+			f.trailer = trailer
+		}
+		// Arquivos híbridos guardam parte das entradas num fluxo separado (XRefStm).
+		if stm, ok := trailer["XRefStm"].(int); ok {
+			pending = append(pending, stm)
+		}
+		if prev, ok := trailer["Prev"].(int); ok {
+			pending = append(pending, prev)
+		}
+	}
+	if f.trailer == nil {
+		return errMalformed
+	}
+	return nil
+}
+
+func (f *File) readXrefSection(offset int) (dict, error) {
+	p := &parser{data: f.data, pos: offset}
+	p.skipSpace()
+	if p.hasPrefix("xref") {
+		p.pos += len("xref")
+		return f.readXrefTable(p)
+	}
+	return f.readXrefStream(offset)
+}
+
+func (f *File) readXrefTable(p *parser) (dict, error) {
+	for {
+		first, err := p.parseObject(0)
+		if err != nil {
+			return nil, err
+		}
+		if kw, ok := first.(keyword); ok && kw == "trailer" {
+			break
+		}
+		start, ok1 := first.(int)
+		count, ok2 := mustInt(p.parseObject(0))
+		if !ok1 || !ok2 || count < 0 {
+			return nil, errMalformed
+		}
+		for i := 0; i < count; i++ {
+			off, ok1 := mustInt(p.parseObject(0))
+			_, ok2 := mustInt(p.parseObject(0))
+			kind, _ := p.parseObject(0)
+			if !ok1 || !ok2 {
+				return nil, errMalformed
+			}
+			if _, known := f.xref[start+i]; !known && kind == keyword("n") {
+				f.xref[start+i] = xrefEntry{offset: off}
+			}
+		}
+	}
+	trailer, err := p.parseObject(0)
+	if err != nil {
+		return nil, err
+	}
+	d, ok := trailer.(dict)
+	if !ok {
+		return nil, errMalformed
+	}
+	return d, nil
+}
+
+// readXrefStream reads a PDF 1.5 cross-reference stream.
+func (f *File) readXrefStream(offset int) (dict, error) {
+	_, _, obj, err := f.parseIndirect(offset)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := obj.(*stream)
+	if !ok || s.dict["Type"] != name("XRef") {
+		return nil, errMalformed
+	}
+	data, err := f.decodeStream(s)
+	if err != nil {
+		return nil, err
+	}
+
+	widths, _ := s.dict["W"].(array)
+	if len(widths) != 3 {
+		return nil, errMalformed
+	}
+	var w [3]int
+	for i := range w {
+		w[i] = f.intValue(widths[i], 0)
+		if w[i] < 0 || w[i] > 8 {
+			return nil, errMalformed
+		}
+	}
+	rowLen := w[0] + w[1] + w[2]
+	if rowLen == 0 {
+		return nil, errMalformed
+	}
+
+	index, _ := s.dict["Index"].(array)
+	if index == nil {
+		index = array{0, s.dict["Size"]}
+	}
+	pos := 0
+	for i := 0; i+1 < len(index); i += 2 {
+		start, count := f.intValue(index[i], 0), f.intValue(index[i+1], 0)
+		for j := 0; j < count && pos+rowLen <= len(data); j++ {
+			row := data[pos : pos+rowLen]
+			pos += rowLen
+
+			kind := 1
+			if w[0] > 0 {
+				kind = readField(row[:w[0]])
+			}
+			a, b := readField(row[w[0]:w[0]+w[1]]), readField(row[w[0]+w[1]:])
+			if _, known := f.xref[start+j]; known {
+				continue
+			}
+			switch kind {
+			case 1:
+				f.xref[start+j] = xrefEntry{offset: a}
+			case 2:
+				f.xref[start+j] = xrefEntry{inStream: true, stream: a, index: b}
+			}
+		}
+	}
+	return s.dict, nil
+}
+
+func readField(b []byte) int {
+	n := 0
+	for _, c := range b {
+		n = n<<8 | int(c)
+	}
+	return n
+}
+
+var objHeaderPattern = regexp.MustCompile(`(\d+)[\x00\t\n\f\r ]+(\d+)[\x00\t\n\f\r ]+obj\b`)
+
+// rebuild scans the whole file for object headers, replacing the
+// cross-reference table. Later definitions win, as in incremental updates.
+func (f *File) rebuild() {
+	if f.rebuilt {
+		return
+	}
+	f.rebuilt = true
+	f.xref = make(map[int]xrefEntry)
+	f.cache = make(map[int]object)
+
+	for _, m := range objHeaderPattern.FindAllSubmatchIndex(f.data, -1) {
+		if m[0] > 0 && isDigit(f.data[m[0]-1]) {
+			continue
+		}
+		num, err := strconv.Atoi(string(f.data[m[2]:m[3]]))
+		if err != nil {
+			continue
+		}
+		f.xref[num] = xrefEntry{offset: m[0]}
+	}
+
+	// Objetos compactados em fluxos de objetos não aparecem na varredura.
+	direct := make(map[int]bool, len(f.xref))
+	for num := range f.xref {
+		direct[num] = true
+	}
+	for num := range direct {
+		s, ok := f.object(num).(*stream)
+		if !ok || s.dict["Type"] != name("ObjStm") {
+			continue
+		}
+		stm := f.objectStream(num)
+		if stm == nil {
+			continue
+		}
+		for i := range stm.offsets {
+			if n, ok := f.objectStreamNumber(stm, i); ok && !direct[n] {
+				f.xref[n] = xrefEntry{inStream: true, stream: num, index: i}
+			}
+		}
+	}
+
+	f.trailer = f.findTrailer()
+}
+
+// findTrailer picks the last trailer dictionary with a catalog, falling back
+// to cross-reference stream dictionaries and then to any catalog object.
+func (f *File) findTrailer() dict {
+	var trailer dict
+	for idx := 0; ; {
+		i := bytes.Index(f.data[idx:], []byte("trailer"))
+		if i < 0 {
+			break
+		}
+		idx += i + len("trailer")
+		p := &parser{data: f.data, pos: idx}
+		if d, err := p.parseObject(0); err == nil {
+			if d, ok := d.(dict); ok && d["Root"] != nil {
+				trailer = d
+			}
+		}
+	}
+	if trailer != nil {
+		return trailer
+	}
+
+	catalog := -1
+	for num := range f.xref {
+		switch obj := f.object(num).(type) {
+		case *stream:
+			if obj.dict["Type"] == name("XRef") && obj.dict["Root"] != nil {
+				return obj.dict
+			}
+		case dict:
+			if obj["Type"] == name("Catalog") && (catalog < 0 || num > catalog) {
+				catalog = num
+			}
+		}
+	}
+	if catalog >= 0 {
+		return dict{"Root": ref{num: catalog}}
+	}
+	return dict{}
+}
+
+// parseIndirect reads "num gen obj ... endobj" at offset, including the
+// stream data that follows a stream dictionary.
+func (f *File) parseIndirect(offset int) (int, int, object, error) {
+	p := &parser{data: f.data, pos: offset}
+	num, ok1 := mustInt(p.parseObject(0))
+	gen, ok2 := mustInt(p.parseObject(0))
+	kw, _ := p.parseObject(0)
+	if !ok1 || !ok2 || kw != keyword("obj") {
+		return 0, 0, nil, errMalformed
+	}
+	obj, err := p.parseObject(0)
+	if err != nil {
+		return num, gen, nil, err
+	}
+
+	d, ok := obj.(dict)
+	if !ok {
+		return num, gen, obj, nil
+	}
+	p.skipSpace()
+	if !p.hasPrefix("stream") {
+		return num, gen, d, nil
+	}
+	p.pos += len("stream")
+	if p.pos < len(f.data) && f.data[p.pos] == '\r' {
+		p.pos++
+	}
+	if p.pos < len(f.data) && f.data[p.pos] == '\n' {
+		p.pos++
+	}
+	return num, gen, &stream{dict: d, raw: f.streamData(d, p.pos)}, nil
+}
+
+// streamData returns the bytes of a stream starting at start. The declared
+// /Length is used when it is consistent; otherwise the data runs up to the
+// endstream keyword.
+func (f *File) streamData(d dict, start int) []byte {
+	if length, ok := f.resolve(d["Length"]).(int); ok && length >= 0 && start+length <= len(f.data) {
+		rest := bytes.TrimLeft(f.data[start+length:min(len(f.data), start+length+32)], "\x00\t\n\f\r ")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			return f.data[start : start+length]
+		}
+	}
+	end := bytes.Index(f.data[start:], []byte("endstream"))
+	if end < 0 {
+		return f.data[start:]
+	}
+	data := f.data[start : start+end]
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	return bytes.TrimSuffix(data, []byte("\r"))
+}
+
+// object returns the object with the given number, or nil when it cannot be read.
+func (f *File) object(num int) object {
+	if obj, ok := f.cache[num]; ok {
+		return obj
+	}
+	if f.resolving[num] {
+		return nil
+	}
+	f.resolving[num] = true
+	defer delete(f.resolving, num)
+
+	entry, ok := f.xref[num]
+	if !ok {
+		return nil
+	}
+	var obj object
+	if entry.inStream {
+		obj = f.objectFromStream(entry.stream, entry.index)
+	} else {
+		n, _, o, err := f.parseIndirect(entry.offset)
+		if (err != nil || n != num) && !f.rebuilt {
+			// O deslocamento registrado não aponta para o objeto: a tabela está corrompida.
+			f.rebuild()
+			delete(f.resolving, num)
+			return f.object(num)
+		}
+		obj = o
+	}
+	f.cache[num] = obj
+	return obj
+}
+
+func (f *File) objectStream(num int) *objectStream {
+	if stm, ok := f.objStms[num]; ok {
+		return stm
+	}
+	f.objStms[num] = nil
+
+	s, ok := f.object(num).(*stream)
+	if !ok {
+		return nil
+	}
+	data, err := f.decodeStream(s)
+	if err != nil {
+		return nil
+	}
+	n, first := f.intValue(s.dict["N"], 0), f.intValue(s.dict["First"], 0)
+	p := &parser{data: data}
+	stm := &objectStream{data: data, first: first}
+	for i := 0; i < n; i++ {
+		_, ok1 := mustInt(p.parseObject(0))
+		off, ok2 := mustInt(p.parseObject(0))
+		if !ok1 || !ok2 {
+			break
+		}
+		stm.offsets = append(stm.offsets, off)
+	}
+	f.objStms[num] = stm
+	return stm
+}
+
+// objectStreamNumber returns the object number stored at position i of
+// the stream header.
+func (f *File) objectStreamNumber(stm *objectStream, i int) (int, bool) {
+	p := &parser{data: stm.data}
+	for j := 0; j <= i; j++ {
+		num, ok := mustInt(p.parseObject(0))
+		if _, ok2 := mustInt(p.parseObject(0)); !ok || !ok2 {
+			return 0, false
+		}
+		if j == i {
+			return num, true
+		}
+	}
+	return 0, false
+}
+
+func (f *File) objectFromStream(num, index int) object {
+	stm := f.objectStream(num)
+	if stm == nil || index < 0 || index >= len(stm.offsets) {
+		return nil
+	}
+	start := stm.first + stm.offsets[index]
+	if start < 0 || start >= len(stm.data) {
+		return nil
+	}
+	p := &parser{data: stm.data, pos: start}
+	obj, err := p.parseObject(0)
+	if err != nil {
+		return nil
+	}
+	return obj
+}
+
+// resolve follows indirect references.
+func (f *File) resolve(obj object) object {
+	for i := 0; i < maxDepth; i++ {
+		r, ok := obj.(ref)
+		if !ok {
+			return obj
+		}
+		obj = f.object(r.num)
+	}
+	return nil
+}
+
+func (f *File) intValue(obj object, fallback int) int {
+	switch v := f.resolve(obj).(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return fallback
+}
+
+func (f *File) dictValue(obj object) dict {
+	switch v := f.resolve(obj).(type) {
+	case dict:
+		return v
+	case *stream:
+		return v.dict
+	}
+	return nil
+}
+
+func (f *File) catalog() dict {
+	if f.trailer == nil {
+		return nil
+	}
+	return f.dictValue(f.trailer["Root"])
+}
+
+func mustInt(obj object, err error) (int, bool) {
+	n, ok := obj.(int)
+	return n, ok && err == nil
+}
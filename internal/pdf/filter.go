@@ -0,0 +1,200 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Limits on decoding, so small hostile files cannot exhaust memory.
+const (
+	// maxDecodedSize limits how much a single stream may expand when decoded.
+	maxDecodedSize = 16 << 20
+	// maxTotalDecoded limits the bytes decoded from all the streams of a file.
+	maxTotalDecoded = 128 << 20
+	// maxPredictorColumns and maxPredictorColors bound the rows of PNG
+	// predictors, far above what cross-reference and object streams use.
+	maxPredictorColumns = 1 << 16
+	maxPredictorColors  = 32
+)
+
+var (
+	errUnsupportedFilter = errors.New("unsupported stream filter")
+	errStreamTooLarge    = errors.New("decoded stream is too large")
+	errInvalidPredictor  = errors.New("invalid predictor parameters")
+)
+
+// decodeStream applies the filters listed in the stream dictionary.
+func (f *File) decodeStream(s *stream) ([]byte, error) {
+	filters := f.resolve(s.dict["Filter"])
+	parms := f.resolve(s.dict["DecodeParms"])
+
+	var names []name
+	var parmList []object
+	switch v := filters.(type) {
+	case nil:
+	case name:
+		names = []name{v}
+		parmList = []object{parms}
+	case array:
+		for _, item := range v {
+			n, _ := f.resolve(item).(name)
+			names = append(names, n)
+		}
+		parmList, _ = parms.(array)
+	default:
+		return nil, errUnsupportedFilter
+	}
+
+	data := s.raw
+	for i, filter := range names {
+		var p dict
+		if i < len(parmList) {
+			p, _ = f.resolve(parmList[i]).(dict)
+		}
+		var err error
+		switch filter {
+		case "FlateDecode", "Fl":
+			data, err = flateDecode(data)
+			if err == nil {
+				data, err = f.unpredict(data, p)
+			}
+		case "ASCIIHexDecode", "AHx":
+			data, err = asciiHexDecode(data)
+		case "ASCII85Decode", "A85":
+			data, err = ascii85Decode(data)
+		default:
+			return nil, fmt.Errorf("%w: %s", errUnsupportedFilter, filter)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if f.decoded += len(data); f.decoded > maxTotalDecoded {
+		return nil, errStreamTooLarge
+	}
+	return data, nil
+}
+
+// flateDecode inflates zlib data. Truncated streams, common in damaged
+// files, return whatever could be decompressed.
+func flateDecode(data []byte) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	out, err := io.ReadAll(io.LimitReader(r, maxDecodedSize+1))
+	if len(out) > maxDecodedSize {
+		return nil, errStreamTooLarge
+	}
+	if err != nil && len(out) == 0 {
+		return nil, err
+	}
+	return out, nil
+}
+
+func asciiHexDecode(data []byte) ([]byte, error) {
+	var digits []byte
+	for _, c := range data {
+		if c == '>' {
+			break
+		}
+		if !isWhite(c) {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, len(digits)/2)
+	_, err := hex.Decode(out, digits)
+	return out, err
+}
+
+func ascii85Decode(data []byte) ([]byte, error) {
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("<~"))
+	if i := bytes.Index(data, []byte("~>")); i >= 0 {
+		data = data[:i]
+	}
+	out := make([]byte, 4*len(data)/5+4)
+	n, _, err := ascii85.Decode(out, data, true)
+	return out[:n], err
+}
+
+// unpredict reverses the PNG predictors used by cross-reference streams.
+// TIFF predictors are rare in metadata and are left as they are.
+func (f *File) unpredict(data []byte, parms dict) ([]byte, error) {
+	predictor := f.intValue(parms["Predictor"], 1)
+	if predictor < 10 {
+		return data, nil
+	}
+	colors := f.intValue(parms["Colors"], 1)
+	bits := f.intValue(parms["BitsPerComponent"], 8)
+	columns := f.intValue(parms["Columns"], 1)
+	switch {
+	case colors < 1 || colors > maxPredictorColors,
+		columns < 1 || columns > maxPredictorColumns,
+		bits != 1 && bits != 2 && bits != 4 && bits != 8 && bits != 16:
+		return nil, errInvalidPredictor
+	}
+	bpp := max(1, colors*bits/8)
+	rowLen := (colors*bits*columns + 7) / 8
+	// Cada linha ocupa rowLen+1 bytes; a saída nunca passa de uma linha além da entrada.
+	if len(data)+rowLen > maxDecodedSize {
+		return nil, errStreamTooLarge
+	}
+
+	var out []byte
+	prev := make([]byte, rowLen)
+	for len(data) > 0 {
+		filterType := data[0]
+		row := make([]byte, rowLen)
+		copy(row, data[1:min(len(data), rowLen+1)])
+		data = data[min(len(data), rowLen+1):]
+
+		for i := range row {
+			var left, upLeft byte
+			if i >= bpp {
+				left, upLeft = row[i-bpp], prev[i-bpp]
+			}
+			up := prev[i]
+			switch filterType {
+			case 1:
+				row[i] += left
+			case 2:
+				row[i] += up
+			case 3:
+				row[i] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[i] += paeth(left, up, upLeft)
+			}
+		}
+		out = append(out, row...)
+		prev = row
+	}
+	return out, nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	}
+	return c
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
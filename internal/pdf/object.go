@@ -0,0 +1,326 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxDepth limits the nesting of arrays and dictionaries, so malformed
+// files cannot exhaust the stack.
+const maxDepth = 64
+
+var errMalformed = errors.New("malformed object")
+
+// PDF object types. Strings are kept as raw bytes in a Go string; names,
+// references, dictionaries, arrays and streams have their own types.
+type (
+	object  any
+	name    string
+	keyword string
+	array   []object
+	dict    map[name]object
+)
+
+type ref struct {
+	num, gen int
+}
+
+type stream struct {
+	dict dict
+	raw  []byte
+}
+
+// parser reads objects from a byte slice starting at pos.
+type parser struct {
+	data []byte
+	pos  int
+}
+
+func isWhite(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isDelim(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		switch {
+		case isWhite(c):
+			p.pos++
+		case c == '%':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' && p.data[p.pos] != '\r' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// regular reads a run of regular characters, such as a keyword or number.
+func (p *parser) regular() string {
+	start := p.pos
+	for p.pos < len(p.data) && !isWhite(p.data[p.pos]) && !isDelim(p.data[p.pos]) {
+		p.pos++
+	}
+	return string(p.data[start:p.pos])
+}
+
+func (p *parser) hasPrefix(s string) bool {
+	return bytes.HasPrefix(p.data[p.pos:], []byte(s))
+}
+
+// parseObject reads the next object. Keywords such as obj, stream or
+// endobj are returned as keyword values.
+func (p *parser) parseObject(depth int) (object, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%w: nesting too deep", errMalformed)
+	}
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, fmt.Errorf("%w: unexpected end of data", errMalformed)
+	}
+
+	c := p.data[p.pos]
+	switch {
+	case c == '/':
+		return p.parseName(), nil
+	case c == '(':
+		return p.parseLiteralString(), nil
+	case p.hasPrefix("<<"):
+		return p.parseDict(depth)
+	case c == '<':
+		return p.parseHexString(), nil
+	case c == '[':
+		return p.parseArray(depth)
+	case c == '+' || c == '-' || c == '.' || isDigit(c):
+		return p.parseNumber()
+	}
+
+	word := p.regular()
+	switch word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	case "":
+		// Delimitador solto, como ")" ou ">" fora de contexto.
+		p.pos++
+		return nil, fmt.Errorf("%w: unexpected %q", errMalformed, c)
+	}
+	return keyword(word), nil
+}
+
+func (p *parser) parseName() name {
+	p.pos++
+	raw := p.regular()
+	if !strings.Contains(raw, "#") {
+		return name(raw)
+	}
+	var b []byte
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '#' && i+2 < len(raw) {
+			if v, err := strconv.ParseUint(raw[i+1:i+3], 16, 8); err == nil {
+				b = append(b, byte(v))
+				i += 2
+				continue
+			}
+		}
+		b = append(b, raw[i])
+	}
+	return name(b)
+}
+
+func (p *parser) parseLiteralString() string {
+	p.pos++
+	var b []byte
+	nesting := 1
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		switch c {
+		case '(':
+			nesting++
+		case ')':
+			nesting--
+			if nesting == 0 {
+				return string(b)
+			}
+		case '\\':
+			if p.pos >= len(p.data) {
+				return string(b)
+			}
+			e := p.data[p.pos]
+			p.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				// Continuação de linha: a quebra é descartada.
+				if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+					p.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && p.pos < len(p.data) && p.data[p.pos] >= '0' && p.data[p.pos] <= '7'; i++ {
+						v = v*8 + int(p.data[p.pos]-'0')
+						p.pos++
+					}
+					c = byte(v)
+				} else {
+					c = e
+				}
+			}
+		}
+		b = append(b, c)
+	}
+	return string(b)
+}
+
+func (p *parser) parseHexString() string {
+	p.pos++
+	var digits []byte
+	for p.pos < len(p.data) && p.data[p.pos] != '>' {
+		c := p.data[p.pos]
+		p.pos++
+		if (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+			digits = append(digits, c)
+		}
+	}
+	p.pos++
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	b := make([]byte, len(digits)/2)
+	for i := range b {
+		v, _ := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		b[i] = byte(v)
+	}
+	return string(b)
+}
+
+func (p *parser) parseDict(depth int) (object, error) {
+	p.pos += 2
+	d := make(dict)
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return d, fmt.Errorf("%w: unterminated dictionary", errMalformed)
+		}
+		if p.hasPrefix(">>") {
+			p.pos += 2
+			return d, nil
+		}
+		key, err := p.parseObject(depth + 1)
+		if err != nil {
+			return d, err
+		}
+		k, ok := key.(name)
+		if !ok {
+			if _, isKeyword := key.(keyword); isKeyword {
+				return d, fmt.Errorf("%w: unterminated dictionary", errMalformed)
+			}
+			continue
+		}
+		value, err := p.parseObject(depth + 1)
+		if err != nil {
+			return d, err
+		}
+		if _, isKeyword := value.(keyword); isKeyword {
+			return d, fmt.Errorf("%w: unterminated dictionary", errMalformed)
+		}
+		d[k] = value
+	}
+}
+
+func (p *parser) parseArray(depth int) (object, error) {
+	p.pos++
+	var a array
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return a, fmt.Errorf("%w: unterminated array", errMalformed)
+		}
+		if p.data[p.pos] == ']' {
+			p.pos++
+			return a, nil
+		}
+		value, err := p.parseObject(depth + 1)
+		if err != nil {
+			return a, err
+		}
+		if _, isKeyword := value.(keyword); isKeyword {
+			return a, fmt.Errorf("%w: unterminated array", errMalformed)
+		}
+		a = append(a, value)
+	}
+}
+
+// parseNumber reads an integer or real number. An integer followed by a
+// generation number and R is an indirect reference.
+func (p *parser) parseNumber() (object, error) {
+	word := p.regular()
+	if word == "" {
+		p.pos++
+		return nil, fmt.Errorf("%w: invalid number", errMalformed)
+	}
+	n, err := strconv.Atoi(word)
+	if err != nil {
+		f, err := strconv.ParseFloat(word, 64)
+		if err != nil {
+			// Números malformados, como "0.0.1", são lidos como zero.
+			return 0.0, nil
+		}
+		return f, nil
+	}
+
+	save := p.pos
+	p.skipSpace()
+	genStart := p.pos
+	for p.pos < len(p.data) && isDigit(p.data[p.pos]) {
+		p.pos++
+	}
+	if p.pos > genStart && p.pos < len(p.data) && isWhite(p.data[p.pos]) {
+		gen, _ := strconv.Atoi(string(p.data[genStart:p.pos]))
+		p.skipSpace()
+		if p.pos < len(p.data) && p.data[p.pos] == 'R' &&
+			(p.pos+1 == len(p.data) || isWhite(p.data[p.pos+1]) || isDelim(p.data[p.pos+1])) {
+			p.pos++
+			return ref{num: n, gen: gen}, nil
+		}
+	}
+	p.pos = save
+	return n, nil
+}
@@ -0,0 +1,198 @@
+// Package pdf reads the document metadata and page count of PDF files. It
+// implements just enough of the file format to do so, in pure Go, and
+// tolerates damaged, incrementally updated and linearized files.
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// MediaType is the media type of PDF files.
+const MediaType = "application/pdf"
+
+var ErrInvalidPDF = errors.New("file is not a valid PDF")
+
+// Metadata is the information read from a PDF. Values from the XMP packet
+// take precedence over the document information dictionary, which PDF 2.0
+// deprecates.
+type Metadata struct {
+	Title    string
+	Authors  []string
+	Subject  string
+	Keywords string
+	// Creator is the application that created the original document, and
+	// Producer the one that converted it to PDF.
+	Creator   string
+	Producer  string
+	Pages     int
+	Version   string
+	Encrypted bool
+}
+
+// IsPDF reports whether data starts with a PDF header. Some producers write
+// a few bytes of garbage before it, so the first kilobyte is searched.
+func IsPDF(data []byte) bool {
+	return bytes.Contains(data[:min(len(data), 1024)], []byte("%PDF-"))
+}
+
+var versionPattern = regexp.MustCompile(`%PDF-(\d\.\d)`)
+
+// Read extracts the metadata and page count of a PDF. Damaged files yield
+// whatever could be read; only files without any recognizable structure
+// return an error.
+func Read(data []byte) (meta *Metadata, err error) {
+	if !IsPDF(data) {
+		return nil, ErrInvalidPDF
+	}
+	defer func() {
+		// Última defesa contra arquivos malformados que escapem às verificações.
+		if r := recover(); r != nil {
+			meta, err = nil, fmt.Errorf("%w: %v", ErrInvalidPDF, r)
+		}
+	}()
+
+	f := newFile(data)
+	meta = &Metadata{}
+	if m := versionPattern.FindSubmatch(data[:min(len(data), 1024)]); m != nil {
+		meta.Version = string(m[1])
+	}
+	meta.Encrypted = f.trailer["Encrypt"] != nil
+	meta.Pages = f.pageCount()
+
+	if meta.Pages == 0 && f.catalog() == nil {
+		return nil, ErrInvalidPDF
+	}
+	// Em arquivos criptografados as strings não podem ser lidas sem a senha.
+	if meta.Encrypted {
+		return meta, nil
+	}
+
+	if info := f.dictValue(f.trailer["Info"]); info != nil {
+		meta.Title = f.text(info["Title"])
+		if author := f.text(info["Author"]); author != "" {
+			meta.Authors = splitAuthors(author)
+		}
+		meta.Subject = f.text(info["Subject"])
+		meta.Keywords = f.text(info["Keywords"])
+		meta.Creator = f.text(info["Creator"])
+		meta.Producer = f.text(info["Producer"])
+	}
+	if catalog := f.catalog(); catalog != nil {
+		if s, ok := f.resolve(catalog["Metadata"]).(*stream); ok {
+			if packet, err := f.decodeStream(s); err == nil {
+				applyXMP(meta, packet)
+			}
+		}
+	}
+	return meta, nil
+}
+
+// pageCount counts the leaves of the page tree. When the tree cannot be
+// walked it falls back to the /Count of its root, and for files without a
+// usable catalog to the number of page objects in the file.
+func (f *File) pageCount() int {
+	if catalog := f.catalog(); catalog != nil {
+		root := catalog["Pages"]
+		if n := f.countPages(root, make(map[int]bool), 0); n > 0 {
+			return n
+		}
+		if n := f.intValue(f.dictValue(root)["Count"], 0); n > 0 {
+			return n
+		}
+	}
+	return len(pageObjectPattern.FindAllIndex(f.data, -1))
+}
+
+var pageObjectPattern = regexp.MustCompile(`/Type\s*/Page\b`)
+
+func (f *File) countPages(node object, visited map[int]bool, depth int) int {
+	if r, ok := node.(ref); ok {
+		if visited[r.num] {
+			return 0
+		}
+		visited[r.num] = true
+	}
+	if depth > maxDepth {
+		return 0
+	}
+	d := f.dictValue(node)
+	if d == nil {
+		return 0
+	}
+
+	if kids, ok := f.resolve(d["Kids"]).(array); ok {
+		count := 0
+		for _, kid := range kids {
+			count += f.countPages(kid, visited, depth+1)
+		}
+		return count
+	}
+	switch d["Type"] {
+	case name("Pages"):
+		return 0
+	default:
+		return 1
+	}
+}
+
+// text decodes a PDF text string: UTF-16BE or UTF-8 with a byte order
+// mark, or PDFDocEncoding otherwise.
+func (f *File) text(obj object) string {
+	s, ok := f.resolve(obj).(string)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(strings.ReplaceAll(decodeText(s), "\x00", ""))
+}
+
+func decodeText(s string) string {
+	b := []byte(s)
+	switch {
+	case bytes.HasPrefix(b, []byte{0xfe, 0xff}):
+		b = b[2:]
+		units := make([]uint16, len(b)/2)
+		for i := range units {
+			units[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+		}
+		return string(utf16.Decode(units))
+	case bytes.HasPrefix(b, []byte{0xef, 0xbb, 0xbf}):
+		return string(b[3:])
+	}
+
+	var sb strings.Builder
+	for _, c := range b {
+		if r, ok := pdfDocEncoding[c]; ok {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune(rune(c))
+		}
+	}
+	return sb.String()
+}
+
+// pdfDocEncoding lists the characters where PDFDocEncoding differs from Latin-1.
+var pdfDocEncoding = map[byte]rune{
+	0x80: '•', 0x81: '†', 0x82: '‡', 0x83: '…', 0x84: '—', 0x85: '–', 0x86: 'ƒ', 0x87: '⁄',
+	0x88: '‹', 0x89: '›', 0x8a: '−', 0x8b: '‰', 0x8c: '„', 0x8d: '“', 0x8e: '”', 0x8f: '‘',
+	0x90: '’', 0x91: '‚', 0x92: '™', 0x93: 'ﬁ', 0x94: 'ﬂ', 0x95: 'Ł', 0x96: 'Œ', 0x97: 'Š',
+	0x98: 'Ÿ', 0x99: 'Ž', 0x9a: 'ı', 0x9b: 'ł', 0x9c: 'œ', 0x9d: 'š', 0x9e: 'ž', 0xa0: '€',
+}
+
+// splitAuthors splits the free-text Author entry, which tools fill with
+// names separated by commas, semicolons or "and".
+func splitAuthors(s string) []string {
+	s = strings.NewReplacer(";", ",", " and ", ",", " & ", ",").Replace(s)
+	var authors []string
+	for _, a := range strings.Split(s, ",") {
+		if a = strings.TrimSpace(a); a != "" && utf8.ValidString(a) {
+			authors = append(authors, a)
+		}
+	}
+	return authors
+}
@@ -0,0 +1,144 @@
+package pdf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		file        string
+		wantPages   int
+		wantTitle   string
+		wantAuthors []string
+		// wantRebuilt tells whether the cross-reference table had to be
+		// rebuilt from the object headers.
+		wantRebuilt bool
+	}{
+		{file: "classic.pdf", wantPages: 3, wantTitle: "Dom Casmurro", wantAuthors: []string{"Machado de Assis"}},
+		{file: "xref-stream.pdf", wantPages: 2, wantTitle: "Dom Casmurro", wantAuthors: []string{"Machado de Assis"}},
+		{file: "linearized.pdf", wantPages: 2, wantTitle: "Vidas Secas", wantAuthors: []string{"Graciliano Ramos"}},
+		{file: "truncated.pdf", wantPages: 3, wantRebuilt: true},
+		{file: "missing-xref.pdf", wantPages: 3, wantTitle: "Dom Casmurro", wantAuthors: []string{"Machado de Assis"}, wantRebuilt: true},
+		{file: "bad-offsets.pdf", wantPages: 3, wantTitle: "Dom Casmurro", wantAuthors: []string{"Machado de Assis"}, wantRebuilt: true},
+		// Os parâmetros hostis impedem a leitura do fluxo xref e do fluxo com
+		// o dicionário de informações; as páginas soltas ainda são contadas.
+		{file: "hostile-predictor.pdf", wantPages: 2, wantRebuilt: true},
+		{file: "stream-bomb.pdf", wantPages: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data := readFixture(t, tt.file)
+			meta, err := Read(data)
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if meta.Pages != tt.wantPages || meta.Title != tt.wantTitle || !reflect.DeepEqual(meta.Authors, tt.wantAuthors) {
+				t.Errorf("Read = %d pages, %q by %q; want %d pages, %q by %q",
+					meta.Pages, meta.Title, meta.Authors, tt.wantPages, tt.wantTitle, tt.wantAuthors)
+			}
+			if f := newFile(data); f.rebuilt != tt.wantRebuilt {
+				t.Errorf("rebuilt = %v, want %v", f.rebuilt, tt.wantRebuilt)
+			}
+		})
+	}
+}
+
+func TestReadRejectsNonPDF(t *testing.T) {
+	for _, data := range [][]byte{nil, []byte("GIF89a"), []byte("%PDF-1.4\n")} {
+		if _, err := Read(data); !errors.Is(err, ErrInvalidPDF) {
+			t.Errorf("Read(%q) error = %v, want ErrInvalidPDF", data, err)
+		}
+	}
+}
+
+func TestReadTruncatedAnywhere(t *testing.T) {
+	// Nenhum corte do arquivo pode causar pânico ou erro diferente de ErrInvalidPDF.
+	for _, file := range []string{"classic.pdf", "xref-stream.pdf", "linearized.pdf"} {
+		data := readFixture(t, file)
+		for n := 0; n < len(data); n++ {
+			if _, err := Read(data[:n]); err != nil && !errors.Is(err, ErrInvalidPDF) {
+				t.Fatalf("%s cut at %d: %v", file, n, err)
+			}
+		}
+	}
+}
+
+func TestUnpredict(t *testing.T) {
+	// Duas linhas de três bytes com o preditor Up: a segunda soma a primeira.
+	data := []byte{2, 1, 2, 3, 2, 1, 1, 1}
+	tests := []struct {
+		name    string
+		parms   dict
+		want    []byte
+		wantErr error
+	}{
+		{name: "no predictor", parms: dict{}, want: data},
+		{name: "TIFF predictor left as is", parms: dict{"Predictor": 2}, want: data},
+		{name: "PNG up", parms: dict{"Predictor": 12, "Columns": 3}, want: []byte{1, 2, 3, 2, 3, 4}},
+		{name: "huge columns", parms: dict{"Predictor": 12, "Columns": 2147483647}, wantErr: errInvalidPredictor},
+		{name: "zero columns", parms: dict{"Predictor": 12, "Columns": 0}, wantErr: errInvalidPredictor},
+		{name: "negative colors", parms: dict{"Predictor": 12, "Colors": -4}, wantErr: errInvalidPredictor},
+		{name: "huge colors", parms: dict{"Predictor": 12, "Colors": 1 << 30}, wantErr: errInvalidPredictor},
+		{name: "odd bits per component", parms: dict{"Predictor": 12, "BitsPerComponent": 7}, wantErr: errInvalidPredictor},
+		{name: "widest accepted row", parms: dict{"Predictor": 12, "Colors": 32, "BitsPerComponent": 16, "Columns": 1 << 16}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFile(nil).unpredict(data, tt.parms)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unpredict error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.want != nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unpredict = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeStreamLimits(t *testing.T) {
+	data := readFixture(t, "stream-bomb.pdf")
+	f := newFile(data)
+	s, ok := f.object(20).(*stream)
+	if !ok {
+		t.Fatal("object stream not found")
+	}
+	if _, err := f.decodeStream(s); !errors.Is(err, errStreamTooLarge) {
+		t.Errorf("decodeStream error = %v, want errStreamTooLarge", err)
+	}
+
+	f = newFile(readFixture(t, "xref-stream.pdf"))
+	s = f.object(20).(*stream)
+	f.decoded = maxTotalDecoded
+	if _, err := f.decodeStream(s); !errors.Is(err, errStreamTooLarge) {
+		t.Errorf("decodeStream past the file budget = %v, want errStreamTooLarge", err)
+	}
+}
+
+func TestSplitAuthors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"Machado de Assis", []string{"Machado de Assis"}},
+		{"Ana; Bia, Caio and Duda & Eva", []string{"Ana", "Bia", "Caio", "Duda", "Eva"}},
+		{" , ", nil},
+	}
+	for _, tt := range tests {
+		if got := splitAuthors(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitAuthors(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
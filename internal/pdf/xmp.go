@@ -0,0 +1,123 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// XMP namespaces of the properties read from the metadata packet.
+const (
+	nsDC  = "http://purl.org/dc/elements/1.1/"
+	nsPDF = "http://ns.adobe.com/pdf/1.3/"
+	nsXMP = "http://ns.adobe.com/xap/1.0/"
+	nsRDF = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+)
+
+// applyXMP overrides the metadata with the values of an XMP packet. Errors
+// are ignored, keeping whatever was read before them.
+func applyXMP(meta *Metadata, packet []byte) {
+	decoder := xml.NewDecoder(bytes.NewReader(packet))
+	decoder.Strict = false
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	var property xml.Name
+	var title, description, producer, creatorTool, keywords string
+	var creators, subjects []string
+	var text strings.Builder
+	inItem := false
+	isDefault := false
+
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch {
+			case t.Name.Space == nsRDF && t.Name.Local == "li":
+				inItem = true
+				text.Reset()
+				isDefault = false
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "lang" && attr.Value == "x-default" {
+						isDefault = true
+					}
+				}
+			case t.Name.Space == nsRDF && t.Name.Local == "Description":
+				// As propriedades simples também podem vir como atributos.
+				for _, attr := range t.Attr {
+					switch attr.Name {
+					case xml.Name{Space: nsPDF, Local: "Producer"}:
+						producer = attr.Value
+					case xml.Name{Space: nsPDF, Local: "Keywords"}:
+						keywords = attr.Value
+					case xml.Name{Space: nsXMP, Local: "CreatorTool"}:
+						creatorTool = attr.Value
+					}
+				}
+			case t.Name.Space == nsDC || t.Name.Space == nsPDF || t.Name.Space == nsXMP:
+				property = t.Name
+				text.Reset()
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			value := strings.TrimSpace(text.String())
+			switch {
+			case t.Name.Space == nsRDF && t.Name.Local == "li" && inItem:
+				inItem = false
+				switch property {
+				case xml.Name{Space: nsDC, Local: "title"}:
+					if title == "" || isDefault {
+						title = value
+					}
+				case xml.Name{Space: nsDC, Local: "description"}:
+					if description == "" || isDefault {
+						description = value
+					}
+				case xml.Name{Space: nsDC, Local: "creator"}:
+					if value != "" {
+						creators = append(creators, value)
+					}
+				case xml.Name{Space: nsDC, Local: "subject"}:
+					if value != "" {
+						subjects = append(subjects, value)
+					}
+				}
+			case t.Name == property:
+				switch property {
+				case xml.Name{Space: nsPDF, Local: "Producer"}:
+					producer = value
+				case xml.Name{Space: nsPDF, Local: "Keywords"}:
+					keywords = value
+				case xml.Name{Space: nsXMP, Local: "CreatorTool"}:
+					creatorTool = value
+				}
+				property = xml.Name{}
+			}
+		}
+	}
+
+	setIfPresent(&meta.Title, title)
+	setIfPresent(&meta.Subject, description)
+	setIfPresent(&meta.Producer, producer)
+	setIfPresent(&meta.Creator, creatorTool)
+	setIfPresent(&meta.Keywords, keywords)
+	if len(subjects) > 0 && keywords == "" {
+		meta.Keywords = strings.Join(subjects, ", ")
+	}
+	if len(creators) > 0 {
+		meta.Authors = creators
+	}
+}
+
+func setIfPresent(dst *string, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		*dst = value
+	}
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var (
+	ErrAuditNotFound   = errors.New("audit session not found")
+	ErrAuditClosed     = errors.New("the audit session is closed")
+	ErrAuditInProgress = errors.New("an audit session is already open for this location")
+)
+
+type AuditRepository interface {
+	// Create saves a new session; a location has at most one open session.
+	Create(session *domain.AuditSession) error
+	GetByID(id string) (*domain.AuditSession, error)
+	// GetAll returns every session, the most recent first, optionally
+	// narrowed to a location.
+	GetAll(locationID string) ([]*domain.AuditSession, error)
+	// FindOpen returns the open session of a location.
+	FindOpen(locationID string) (*domain.AuditSession, error)
+	// AddScans appends scans to an open session.
+	AddScans(id string, scans []domain.AuditScan) error
+	// Close stores the report of an open session and closes it.
+	Close(id string, report *domain.AuditReport, closedAt time.Time) error
+}
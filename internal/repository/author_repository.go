@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var (
+	ErrAuthorNotFound = errors.New("author not found")
+)
+
+type AuthorRepository interface {
+	Create(author *domain.Author) error
+	GetByID(id string) (*domain.Author, error)
+	// FindByName returns the author whose name, alternate spelling or alias
+	// is name, ignoring case.
+	FindByName(name string) (*domain.Author, error)
+	Update(author *domain.Author) error
+	Delete(id string) error
+	// Search returns the authors whose names contain name, ignoring case; an
+	// empty name returns every author.
+	Search(name string) ([]*domain.Author, error)
+}
@@ -1,11 +1,66 @@
 package repository
 
-import "github.com/rfulgencio3/go-personal-library/internal/domain"
+import (
+	"errors"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var (
+	ErrBookNotFound  = errors.New("book not found")
+	ErrDuplicateISBN = errors.New("a book with this ISBN already exists")
+)
 
 type BookRepository interface {
 	Create(book *domain.Book) error
 	GetByID(id string) (*domain.Book, error)
+	// GetByIDs retrieves the books with the given IDs, skipping those not found.
+	GetByIDs(ids []string) ([]*domain.Book, error)
+	GetByIdentifier(scheme, value string) (*domain.Book, error)
+	// GetByISBN retrieves a book by its normalized ISBN-13.
+	GetByISBN(isbn13 string) (*domain.Book, error)
 	Update(book *domain.Book) error
 	Delete(id string) error
+	// SetCover replaces the cover of a book; a nil cover removes it.
+	SetCover(id string, cover *domain.Cover) error
+	AddAttachment(id string, attachment *domain.Attachment) error
+	// LinkAuthor links to authorID the contributors not yet linked to an
+	// author whose name is one of names, ignoring case.
+	LinkAuthor(authorID string, names []string) error
+	// RelinkAuthor moves the contributors linked to fromID over to toID; an
+	// empty toID unlinks them.
+	RelinkAuthor(fromID, toID string) error
+	// SetSeries places a book in a series at position; an empty seriesID
+	// removes it from its series.
+	SetSeries(id, seriesID, name string, position float64) error
+	// LinkSeries links to seriesID the books not yet linked to a series whose
+	// series name is name, ignoring case.
+	LinkSeries(seriesID, name string) error
+	// RenameSeries updates the series name of the books linked to seriesID.
+	RenameSeries(seriesID, name string) error
+	// UnlinkSeries removes the link of the books to seriesID, keeping their series name.
+	UnlinkSeries(seriesID string) error
+	// SetStatus changes the status of a book along with its disposal; a nil
+	// disposal removes it.
+	SetStatus(id, status string, disposal *domain.Disposal) error
+	// GetDisposed returns the books that left the library, the most recent
+	// departure first and those without a departure date last. A year other
+	// than zero keeps only the books that left in that year.
+	GetDisposed(year int) ([]*domain.Book, error)
+	AddTags(id string, tags []string) error
+	RemoveTags(id string, tags []string) error
+	// RetagBooks moves the tags at or below from to below to, or removes them
+	// when to is empty. It returns the IDs of the books it changed.
+	RetagBooks(from, to string) ([]string, error)
+	// TagCounts returns, for every tag path and its ancestors, the number of
+	// books tagged with it or with one of its descendants.
+	TagCounts() (map[string]int, error)
 	GetAll() ([]*domain.Book, error)
+	Search(filter domain.BookFilter) ([]*domain.Book, error)
+	// FindIncomplete returns up to limit books with an ISBN but a blank
+	// subtitle, publisher or page count, ordered by ID and starting after afterID.
+	FindIncomplete(afterID string, limit int) ([]*domain.Book, error)
+	// Stream iterates over every book straight from the database cursor,
+	// calling fn for each one. Iteration stops at the first error returned by fn.
+	Stream(fn func(book *domain.Book) error) error
 }
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var (
+	ErrCopyNotFound = errors.New("copy not found")
+)
+
+type CopyRepository interface {
+	Create(c *domain.Copy) error
+	GetByID(id string) (*domain.Copy, error)
+	// GetByBookIDs returns the copies of the given editions.
+	GetByBookIDs(bookIDs []string) ([]*domain.Copy, error)
+	// Update changes the condition, notes and acquisition of a copy.
+	Update(c *domain.Copy) error
+	Delete(id string) error
+	// DeleteByBookID removes every copy of an edition.
+	DeleteByBookID(bookID string) error
+	// SetLocation places a copy in a location; a nil location removes it.
+	SetLocation(id string, location *domain.BookLocation) error
+	// Unshelve takes a copy off its location, keeping it as the last location.
+	Unshelve(id string) error
+	// FindByLocations returns the copies kept in any of the given locations.
+	FindByLocations(locationIDs []string) ([]*domain.Copy, error)
+	// Relocate moves every copy kept in fromID to toID, keeping their
+	// positions, and returns the number of copies moved.
+	Relocate(fromID, toID string) (int, error)
+	// SpendingTotals sums the prices of the copies purchased by year, store
+	// and currency.
+	SpendingTotals() ([]domain.SpendingTotal, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var (
+	ErrExchangeRateNotFound = errors.New("exchange rate not found")
+)
+
+type ExchangeRateRepository interface {
+	// Set creates or replaces the rate of a currency.
+	Set(rate *domain.ExchangeRate) error
+	// GetAll returns every rate sorted by currency.
+	GetAll() ([]*domain.ExchangeRate, error)
+	Delete(currency string) error
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var (
+	ErrLoanNotFound = errors.New("loan not found")
+	ErrLoanReturned = errors.New("the loan has already been returned")
+	ErrCopyOnLoan   = errors.New("the copy is already lent")
+)
+
+type LoanRepository interface {
+	// Create saves a new active loan; a copy has at most one active loan.
+	Create(loan *domain.Loan) error
+	GetByID(id string) (*domain.Loan, error)
+	// GetActive returns the active loans, the soonest due first.
+	GetActive() ([]*domain.Loan, error)
+	// GetOverdue returns the active loans due before now, the most overdue first.
+	GetOverdue(now time.Time) ([]*domain.Loan, error)
+	// GetActiveByBookID returns the active loans of the copies of a book.
+	GetActiveByBookID(bookID string) ([]*domain.Loan, error)
+	// GetByBorrower returns every loan of a borrower, ignoring case, the
+	// most recent first.
+	GetByBorrower(borrower string) ([]*domain.Loan, error)
+	// Return marks an active loan as returned.
+	Return(id string, returnedAt time.Time) error
+	// DeleteByBookID removes every loan of the copies of a book.
+	DeleteByBookID(bookID string) error
+}
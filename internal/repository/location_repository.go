@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var (
+	ErrLocationNotFound = errors.New("location not found")
+)
+
+type LocationRepository interface {
+	Create(location *domain.Location) error
+	GetByID(id string) (*domain.Location, error)
+	// GetByIDs retrieves the locations with the given IDs, skipping those not found.
+	GetByIDs(ids []string) ([]*domain.Location, error)
+	GetAll() ([]*domain.Location, error)
+	// GetDescendants returns every location below the given one.
+	GetDescendants(id string) ([]*domain.Location, error)
+	Update(location *domain.Location) error
+	Delete(id string) error
+}
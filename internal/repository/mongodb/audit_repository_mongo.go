@@ -0,0 +1,154 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// auditRepositoryMongo implements repository.AuditRepository for MongoDB.
+type auditRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+// NewAuditRepository creates a new audit session repository using MongoDB.
+func NewAuditRepository(client *mongo.Client, config *configs.Config) *auditRepositoryMongo {
+	collection := client.Database(config.MongoDatabase).Collection(config.MongoAuditCollection)
+	repo := &auditRepositoryMongo{collection: collection}
+	if err := repo.ensureIndexes(); err != nil {
+		log.Printf("Erro ao criar os índices da coleção de inventários: %v", err)
+	}
+	return repo
+}
+
+// ensureIndexes indexes the history of each location and allows a single
+// open session per location.
+func (r *auditRepositoryMongo) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "location_id", Value: 1}, {Key: "started_at", Value: -1}},
+			Options: options.Index().SetName("location_started_at"),
+		},
+		{
+			Keys: bson.D{{Key: "location_id", Value: 1}},
+			Options: options.Index().SetName("location_open_unique").SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": domain.AuditOpen}),
+		},
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// Create inserts a new audit session.
+func (r *auditRepositoryMongo) Create(session *domain.AuditSession) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	session.ID = uuid.New().String()
+	// Um array nulo impediria o $push das leituras.
+	if session.Scans == nil {
+		session.Scans = []domain.AuditScan{}
+	}
+
+	_, err := r.collection.InsertOne(ctx, session)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository.ErrAuditInProgress
+	}
+	return err
+}
+
+// GetByID retrieves an audit session by its ID.
+func (r *auditRepositoryMongo) GetByID(id string) (*domain.AuditSession, error) {
+	return r.findOne(bson.M{"_id": id})
+}
+
+// FindOpen retrieves the open audit session of a location.
+func (r *auditRepositoryMongo) FindOpen(locationID string) (*domain.AuditSession, error) {
+	return r.findOne(bson.M{"location_id": locationID, "status": domain.AuditOpen})
+}
+
+func (r *auditRepositoryMongo) findOne(filter bson.M) (*domain.AuditSession, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var session domain.AuditSession
+	err := r.collection.FindOne(ctx, filter).Decode(&session)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrAuditNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetAll retrieves the audit sessions, the most recent first, leaving out
+// their scans and reports.
+func (r *auditRepositoryMongo) GetAll(locationID string) ([]*domain.AuditSession, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if locationID != "" {
+		filter["location_id"] = locationID
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "started_at", Value: -1}}).
+		SetProjection(bson.M{"scans": 0, "report": 0})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []*domain.AuditSession
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// AddScans appends scans to a session that is still open.
+func (r *auditRepositoryMongo) AddScans(id string, scans []domain.AuditScan) error {
+	return r.updateOpen(id, bson.M{"$push": bson.M{"scans": bson.M{"$each": scans}}})
+}
+
+// Close stores the report of an open session and marks it closed.
+func (r *auditRepositoryMongo) Close(id string, report *domain.AuditReport, closedAt time.Time) error {
+	return r.updateOpen(id, bson.M{"$set": bson.M{
+		"status":    domain.AuditClosed,
+		"report":    report,
+		"closed_at": closedAt,
+	}})
+}
+
+// updateOpen updates a session only while it is open.
+func (r *auditRepositoryMongo) updateOpen(id string, update bson.M) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "status": domain.AuditOpen}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		if _, err := r.GetByID(id); err != nil {
+			return err
+		}
+		return repository.ErrAuditClosed
+	}
+
+	return nil
+}
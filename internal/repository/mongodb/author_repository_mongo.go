@@ -0,0 +1,148 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// authorRepositoryMongo implements repository.AuthorRepository for MongoDB.
+type authorRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+// NewAuthorRepository creates a new author repository using MongoDB.
+func NewAuthorRepository(client *mongo.Client, config *configs.Config) *authorRepositoryMongo {
+	collection := client.Database(config.MongoDatabase).Collection(config.MongoAuthorCollection)
+	repo := &authorRepositoryMongo{collection: collection}
+	if err := repo.ensureIndexes(); err != nil {
+		log.Printf("Erro ao criar os índices da coleção de autores: %v", err)
+	}
+	return repo
+}
+
+// ensureIndexes indexes the names used to look authors up.
+func (r *authorRepositoryMongo) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetName("name")},
+		{Keys: bson.D{{Key: "alternate_spellings", Value: 1}}, Options: options.Index().SetName("alternate_spellings")},
+		{Keys: bson.D{{Key: "aliases", Value: 1}}, Options: options.Index().SetName("aliases")},
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// Create inserts a new author.
+func (r *authorRepositoryMongo) Create(author *domain.Author) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	author.ID = uuid.New().String()
+
+	_, err := r.collection.InsertOne(ctx, author)
+	return err
+}
+
+// GetByID retrieves an author by its ID.
+func (r *authorRepositoryMongo) GetByID(id string) (*domain.Author, error) {
+	return r.findOne(bson.M{"_id": id})
+}
+
+// FindByName retrieves the author known by name, comparing it with the main
+// name, the alternate spellings and the aliases.
+func (r *authorRepositoryMongo) FindByName(name string) (*domain.Author, error) {
+	pattern := equalFoldPattern(name)
+	return r.findOne(bson.M{"$or": bson.A{
+		bson.M{"name": pattern},
+		bson.M{"alternate_spellings": pattern},
+		bson.M{"aliases": pattern},
+	}})
+}
+
+func (r *authorRepositoryMongo) findOne(filter bson.M) (*domain.Author, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var author domain.Author
+	err := r.collection.FindOne(ctx, filter).Decode(&author)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrAuthorNotFound
+		}
+		return nil, err
+	}
+	return &author, nil
+}
+
+// Update replaces an existing author.
+func (r *authorRepositoryMongo) Update(author *domain.Author) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": author.ID}, author)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return repository.ErrAuthorNotFound
+	}
+
+	return nil
+}
+
+// Delete removes an author by its ID.
+func (r *authorRepositoryMongo) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return repository.ErrAuthorNotFound
+	}
+
+	return nil
+}
+
+// Search retrieves the authors with any name containing name, sorted by name.
+func (r *authorRepositoryMongo) Search(name string) ([]*domain.Author, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if name != "" {
+		pattern := containsPattern(name)
+		query["$or"] = bson.A{
+			bson.M{"name": pattern},
+			bson.M{"alternate_spellings": pattern},
+			bson.M{"aliases": pattern},
+		}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var authors []*domain.Author
+	if err := cursor.All(ctx, &authors); err != nil {
+		return nil, err
+	}
+	return authors, nil
+}
@@ -2,15 +2,18 @@ package mongodb
 
 import (
 	"context"
-	"errors"
+	"log"
+	"regexp"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rfulgencio3/go-personal-library/configs" // Atualizado para importar corretamente
 	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // bookRepositoryMongo is the struct that implements the repository.BookRepository interface for MongoDB.
@@ -18,12 +21,102 @@ type bookRepositoryMongo struct {
 	collection *mongo.Collection
 }
 
-// NewBookRepository creates a new book repository using MongoDB.
-func NewBookRepository(client *mongo.Client, config *configs.Config) *bookRepositoryMongo {
+// NewBookRepository creates a new book repository using MongoDB. It fails
+// when the indexes cannot be created, since without the unique ISBN indexes
+// duplicate books could be saved.
+func NewBookRepository(client *mongo.Client, config *configs.Config) (*bookRepositoryMongo, error) {
 	collection := client.Database(config.MongoDatabase).Collection(config.MongoCollection)
-	return &bookRepositoryMongo{
+	repo := &bookRepositoryMongo{
 		collection: collection,
 	}
+	if err := repo.ensureIndexes(); err != nil {
+		return nil, err
+	}
+	if err := repo.migrateContributors(); err != nil {
+		log.Printf("Erro ao migrar os autores para a lista de colaboradores: %v", err)
+	}
+	return repo, nil
+}
+
+// ensureIndexes creates the unique ISBN indexes. They are partial, so books
+// without an ISBN do not conflict with each other.
+func (r *bookRepositoryMongo) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "isbn13", Value: 1}},
+			Options: options.Index().SetName("isbn13_unique").SetUnique(true).
+				SetPartialFilterExpression(bson.M{"isbn13": bson.M{"$gt": ""}}),
+		},
+		{
+			Keys: bson.D{{Key: "isbn10", Value: 1}},
+			Options: options.Index().SetName("isbn10_unique").SetUnique(true).
+				SetPartialFilterExpression(bson.M{"isbn10": bson.M{"$gt": ""}}),
+		},
+		{
+			Keys:    bson.D{{Key: "contributors.role", Value: 1}, {Key: "contributors.name", Value: 1}},
+			Options: options.Index().SetName("contributors"),
+		},
+		{
+			Keys:    bson.D{{Key: "contributors.author_id", Value: 1}},
+			Options: options.Index().SetName("contributors_author_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "series_id", Value: 1}, {Key: "series_position", Value: 1}},
+			Options: options.Index().SetName("series_id_position"),
+		},
+		{
+			Keys:    bson.D{{Key: "work_id", Value: 1}},
+			Options: options.Index().SetName("work_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "disposal.date", Value: -1}},
+			Options: options.Index().SetName("status_disposal_date"),
+		},
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// migrateContributors fills the contributors of the books saved before they
+// existed, splitting the free-text author field into author credits.
+func (r *bookRepositoryMongo) migrateContributors() error {
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	query := bson.M{"contributors": bson.M{"$exists": false}, "author": bson.M{"$gt": ""}}
+	opts := options.Find().SetProjection(bson.M{"author": 1})
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var updates []mongo.WriteModel
+	for cursor.Next(ctx) {
+		var book domain.Book
+		if err := cursor.Decode(&book); err != nil {
+			return err
+		}
+		updates = append(updates, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": book.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"contributors": domain.ContributorsFromAuthor(book.Author)}}))
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	result, err := r.collection.BulkWrite(ctx, updates, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return err
+	}
+	log.Printf("Colaboradores preenchidos a partir do autor em %d livros", result.ModifiedCount)
+	return nil
 }
 
 // Create inserts a new book into the MongoDB collection.
@@ -35,6 +128,9 @@ func (r *bookRepositoryMongo) Create(book *domain.Book) error {
 	book.ID = uuid.New().String()
 
 	_, err := r.collection.InsertOne(ctx, book)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository.ErrDuplicateISBN
+	}
 	return err
 }
 
@@ -43,86 +139,395 @@ func (r *bookRepositoryMongo) GetByID(id string) (*domain.Book, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(id)
+	var book domain.Book
+	filter := bson.M{"_id": id}
+	err := r.collection.FindOne(ctx, filter).Decode(&book)
 	if err != nil {
-		return nil, errors.New("invalid book ID")
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrBookNotFound
+		}
+		return nil, err
 	}
+	return &book, nil
+}
+
+// GetByIdentifier retrieves a book by one of its external identifiers, such as a Calibre UUID.
+func (r *bookRepositoryMongo) GetByIdentifier(scheme, value string) (*domain.Book, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	var book domain.Book
-	filter := bson.M{"_id": objID}
-	err = r.collection.FindOne(ctx, filter).Decode(&book)
+	filter := bson.M{"identifiers." + scheme: value}
+	err := r.collection.FindOne(ctx, filter).Decode(&book)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("book not found")
+			return nil, repository.ErrBookNotFound
 		}
 		return nil, err
 	}
 	return &book, nil
 }
 
-// Update modifies an existing book in the MongoDB collection.
-func (r *bookRepositoryMongo) Update(book *domain.Book) error {
+// GetByISBN retrieves a book by its ISBN-13.
+func (r *bookRepositoryMongo) GetByISBN(isbn13 string) (*domain.Book, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(book.ID)
+	var book domain.Book
+	filter := bson.M{"isbn13": isbn13}
+	err := r.collection.FindOne(ctx, filter).Decode(&book)
 	if err != nil {
-		return errors.New("invalid book ID")
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrBookNotFound
+		}
+		return nil, err
 	}
+	return &book, nil
+}
+
+// Update modifies an existing book in the MongoDB collection.
+func (r *bookRepositoryMongo) Update(book *domain.Book) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	filter := bson.M{"_id": objID}
+	filter := bson.M{"_id": book.ID}
 	update := bson.M{
 		"$set": bson.M{
-			"title":     book.Title,
-			"subtitle":  book.Subtitle,
-			"author":    book.Author,
-			"pages":     book.Pages,
-			"publisher": book.Publisher,
-			"comments":  book.Comments,
+			"work_id":         book.WorkID,
+			"title":           book.Title,
+			"subtitle":        book.Subtitle,
+			"author":          book.Author,
+			"contributors":    book.Contributors,
+			"pages":           book.Pages,
+			"publisher":       book.Publisher,
+			"comments":        book.Comments,
+			"edition":         book.Edition,
+			"year":            book.Year,
+			"isbn10":          book.ISBN10,
+			"isbn13":          book.ISBN13,
+			"language":        book.Language,
+			"description":     book.Description,
+			"series":          book.Series,
+			"series_id":       book.SeriesID,
+			"series_position": book.SeriesPosition,
+			"tags":            book.Tags,
+			"identifiers":     book.Identifiers,
 		},
 	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository.ErrDuplicateISBN
+	}
 	if err != nil {
 		return err
 	}
 
 	if result.MatchedCount == 0 {
-		return errors.New("book not found")
+		return repository.ErrBookNotFound
 	}
 
 	return nil
 }
 
-// Delete removes a book from the MongoDB collection by its ID.
-func (r *bookRepositoryMongo) Delete(id string) error {
+// SetCover replaces the cover of a book, or removes it when cover is nil.
+func (r *bookRepositoryMongo) SetCover(id string, cover *domain.Cover) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"cover": cover}}
+	if cover == nil {
+		update = bson.M{"$unset": bson.M{"cover": ""}}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return repository.ErrBookNotFound
+	}
+
+	return nil
+}
+
+// AddAttachment appends an attachment to a book.
+func (r *bookRepositoryMongo) AddAttachment(id string, attachment *domain.Attachment) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(id)
+	update := bson.M{"$push": bson.M{"attachments": attachment}}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
 	if err != nil {
-		return errors.New("invalid book ID")
+		return err
 	}
 
-	filter := bson.M{"_id": objID}
+	if result.MatchedCount == 0 {
+		return repository.ErrBookNotFound
+	}
+
+	return nil
+}
+
+// LinkAuthor sets the author ID of the unlinked contributors named after the author.
+func (r *bookRepositoryMongo) LinkAuthor(authorID string, names []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	patterns := bson.A{}
+	for _, name := range names {
+		patterns = append(patterns, equalFoldPattern(name))
+	}
+	filter := bson.M{"contributors": bson.M{"$elemMatch": bson.M{
+		"name":      bson.M{"$in": patterns},
+		"author_id": bson.M{"$exists": false},
+	}}}
+	update := bson.M{"$set": bson.M{"contributors.$[c].author_id": authorID}}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{Filters: bson.A{
+		bson.M{"c.name": bson.M{"$in": patterns}, "c.author_id": bson.M{"$exists": false}},
+	}})
+	_, err := r.collection.UpdateMany(ctx, filter, update, opts)
+	return err
+}
+
+// RelinkAuthor moves the contributors of fromID to toID, or unlinks them when toID is empty.
+func (r *bookRepositoryMongo) RelinkAuthor(fromID, toID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"contributors.$[c].author_id": toID}}
+	if toID == "" {
+		update = bson.M{"$unset": bson.M{"contributors.$[c].author_id": ""}}
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{Filters: bson.A{
+		bson.M{"c.author_id": fromID},
+	}})
+	_, err := r.collection.UpdateMany(ctx, bson.M{"contributors.author_id": fromID}, update, opts)
+	return err
+}
+
+// SetSeries places a book in a series, or removes it from its series when seriesID is empty.
+func (r *bookRepositoryMongo) SetSeries(id, seriesID, name string, position float64) error {
+	update := bson.M{"$set": bson.M{"series_id": seriesID, "series": name, "series_position": position}}
+	if seriesID == "" {
+		update = bson.M{"$unset": bson.M{"series_id": "", "series": "", "series_position": ""}}
+	}
+	return r.updateOne(id, update)
+}
+
+// LinkSeries sets the series ID of the unlinked books whose series name is name.
+func (r *bookRepositoryMongo) LinkSeries(seriesID, name string) error {
+	filter := bson.M{"series": equalFoldPattern(name), "series_id": bson.M{"$in": bson.A{"", nil}}}
+	return r.updateMany(filter, bson.M{"$set": bson.M{"series_id": seriesID, "series": name}})
+}
+
+// RenameSeries updates the series name of the books in the series.
+func (r *bookRepositoryMongo) RenameSeries(seriesID, name string) error {
+	return r.updateMany(bson.M{"series_id": seriesID}, bson.M{"$set": bson.M{"series": name}})
+}
+
+// UnlinkSeries removes the series ID from the books in the series.
+func (r *bookRepositoryMongo) UnlinkSeries(seriesID string) error {
+	return r.updateMany(bson.M{"series_id": seriesID}, bson.M{"$unset": bson.M{"series_id": ""}})
+}
+
+// AddTags adds tags to a book, ignoring those it already has.
+func (r *bookRepositoryMongo) AddTags(id string, tags []string) error {
+	return r.updateOne(id, bson.M{"$addToSet": bson.M{"tags": bson.M{"$each": tags}}})
+}
+
+// RemoveTags removes tags from a book.
+func (r *bookRepositoryMongo) RemoveTags(id string, tags []string) error {
+	return r.updateOne(id, bson.M{"$pull": bson.M{"tags": bson.M{"$in": tags}}})
+}
+
+// RetagBooks rewrites the tags of the books tagged at or below from.
+func (r *bookRepositoryMongo) RetagBooks(from, to string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	opts := options.Find().SetProjection(bson.M{"tags": 1})
+	cursor, err := r.collection.Find(ctx, bson.M{"tags": tagPattern(from)}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ids []string
+	var updates []mongo.WriteModel
+	for cursor.Next(ctx) {
+		var book domain.Book
+		if err := cursor.Decode(&book); err != nil {
+			return nil, err
+		}
+		tags := make([]string, 0, len(book.Tags))
+		for _, tag := range book.Tags {
+			if !domain.IsTagWithin(tag, from) {
+				tags = append(tags, tag)
+			} else if to != "" {
+				tags = append(tags, domain.RebaseTag(tag, from, to))
+			}
+		}
+		ids = append(ids, book.ID)
+		updates = append(updates, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": book.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"tags": domain.NormalizeTags(tags)}}))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+
+	if _, err := r.collection.BulkWrite(ctx, updates, options.BulkWrite().SetOrdered(false)); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// TagCounts counts the books under each tag. A book tagged with two children
+// of the same tag is counted once for it.
+func (r *bookRepositoryMongo) TagCounts() (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	opts := options.Find().SetProjection(bson.M{"tags": 1})
+	cursor, err := r.collection.Find(ctx, bson.M{"tags.0": bson.M{"$exists": true}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := make(map[string]int)
+	for cursor.Next(ctx) {
+		var book domain.Book
+		if err := cursor.Decode(&book); err != nil {
+			return nil, err
+		}
+		seen := make(map[string]bool)
+		for _, tag := range book.Tags {
+			for _, path := range domain.TagAncestors(tag) {
+				if !seen[path] {
+					seen[path] = true
+					counts[path]++
+				}
+			}
+		}
+	}
+	return counts, cursor.Err()
+}
+
+// SetStatus changes the status of a book and sets or removes its disposal.
+func (r *bookRepositoryMongo) SetStatus(id, status string, disposal *domain.Disposal) error {
+	if disposal == nil {
+		return r.updateOne(id, bson.M{"$set": bson.M{"status": status}, "$unset": bson.M{"disposal": ""}})
+	}
+	return r.updateOne(id, bson.M{"$set": bson.M{"status": status, "disposal": disposal}})
+}
+
+// GetDisposed retrieves the books that left the library, the most recent
+// departure first.
+func (r *bookRepositoryMongo) GetDisposed(year int) ([]*domain.Book, error) {
+	filter := disposedQuery(year)
+	opts := options.Find().SetSort(bson.D{{Key: "disposal.date", Value: -1}})
+	return r.find(filter, opts)
+}
+
+// disposedQuery matches the books that left the library, in year when it
+// is not zero.
+func disposedQuery(year int) bson.M {
+	filter := bson.M{"status": bson.M{"$in": domain.DisposalStatuses}}
+	if year != 0 {
+		start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		filter["disposal.date"] = bson.M{"$gte": start, "$lt": start.AddDate(1, 0, 0)}
+	}
+	return filter
+}
+
+func (r *bookRepositoryMongo) updateOne(id string, update bson.M) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return repository.ErrBookNotFound
+	}
+
+	return nil
+}
+
+func (r *bookRepositoryMongo) updateMany(filter, update bson.M) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateMany(ctx, filter, update)
+	return err
+}
+
+// Delete removes a book from the MongoDB collection by its ID.
+func (r *bookRepositoryMongo) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": id}
 	result, err := r.collection.DeleteOne(ctx, filter)
 	if err != nil {
 		return err
 	}
 
 	if result.DeletedCount == 0 {
-		return errors.New("book not found")
+		return repository.ErrBookNotFound
 	}
 
 	return nil
 }
 
+// GetByIDs retrieves the books with the given IDs, in no particular order.
+func (r *bookRepositoryMongo) GetByIDs(ids []string) ([]*domain.Book, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.find(bson.M{"_id": bson.M{"$in": ids}})
+}
+
 // GetAll retrieves all books from the MongoDB collection.
 func (r *bookRepositoryMongo) GetAll() ([]*domain.Book, error) {
+	return r.find(bson.M{})
+}
+
+// Search retrieves the books that match the given filter.
+func (r *bookRepositoryMongo) Search(filter domain.BookFilter) ([]*domain.Book, error) {
+	return r.find(bookFilterQuery(filter))
+}
+
+// FindIncomplete retrieves a page of books missing subtitle, publisher or pages.
+func (r *bookRepositoryMongo) FindIncomplete(afterID string, limit int) ([]*domain.Book, error) {
+	query := bson.M{
+		"isbn13": bson.M{"$gt": ""},
+		"$or": bson.A{
+			bson.M{"subtitle": bson.M{"$in": bson.A{"", nil}}},
+			bson.M{"publisher": bson.M{"$in": bson.A{"", nil}}},
+			bson.M{"pages": bson.M{"$not": bson.M{"$gt": 0}}},
+		},
+	}
+	if afterID != "" {
+		query["_id"] = bson.M{"$gt": afterID}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit))
+	return r.find(query, opts)
+}
+
+func (r *bookRepositoryMongo) find(query bson.M, opts ...*options.FindOptions) ([]*domain.Book, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	cursor, err := r.collection.Find(ctx, bson.M{})
+	cursor, err := r.collection.Find(ctx, query, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -143,3 +548,93 @@ func (r *bookRepositoryMongo) GetAll() ([]*domain.Book, error) {
 
 	return books, nil
 }
+
+// Stream iterates over all books using the MongoDB cursor directly, without
+// loading the whole collection into memory.
+func (r *bookRepositoryMongo) Stream(fn func(book *domain.Book) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var book domain.Book
+		if err := cursor.Decode(&book); err != nil {
+			return err
+		}
+		if err := fn(&book); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// bookFilterQuery translates a BookFilter into a MongoDB query.
+func bookFilterQuery(filter domain.BookFilter) bson.M {
+	query := bson.M{}
+	if filter.Title != "" {
+		query["title"] = containsPattern(filter.Title)
+	}
+	if filter.Author != "" {
+		query["author"] = containsPattern(filter.Author)
+	}
+	if filter.Publisher != "" {
+		query["publisher"] = containsPattern(filter.Publisher)
+	}
+	if filter.Series != "" {
+		query["series"] = filter.Series
+	}
+	if filter.Tag != "" {
+		query["tags"] = tagPattern(filter.Tag)
+	}
+	if filter.Contributor != "" || filter.Role != "" {
+		match := bson.M{}
+		if filter.Contributor != "" {
+			match["name"] = containsPattern(filter.Contributor)
+		}
+		if filter.Role != "" {
+			match["role"] = filter.Role
+		}
+		query["contributors"] = bson.M{"$elemMatch": match}
+	}
+	if filter.AuthorID != "" {
+		query["contributors.author_id"] = filter.AuthorID
+	}
+	if filter.SeriesID != "" {
+		query["series_id"] = filter.SeriesID
+	}
+	if filter.WorkID != "" {
+		query["work_id"] = filter.WorkID
+	}
+	switch filter.Status {
+	case domain.BookStatusAll:
+	case "":
+		// Livros sem status são anteriores ao ciclo de vida e continuam na biblioteca.
+		query["status"] = bson.M{"$nin": domain.DisposalStatuses}
+	case domain.BookOwned:
+		query["status"] = bson.M{"$in": bson.A{domain.BookOwned, nil}}
+	default:
+		query["status"] = filter.Status
+	}
+	return query
+}
+
+// containsPattern matches values containing s, ignoring case.
+func containsPattern(s string) primitive.Regex {
+	return primitive.Regex{Pattern: regexp.QuoteMeta(s), Options: "i"}
+}
+
+// tagPattern matches the tag path and the paths of its descendants.
+func tagPattern(path string) primitive.Regex {
+	return primitive.Regex{Pattern: "^" + regexp.QuoteMeta(path) + "(" + regexp.QuoteMeta(domain.TagSeparator) + "|$)"}
+}
+
+// equalFoldPattern matches values equal to s, ignoring case.
+func equalFoldPattern(s string) primitive.Regex {
+	return primitive.Regex{Pattern: "^" + regexp.QuoteMeta(s) + "$", Options: "i"}
+}
@@ -0,0 +1,96 @@
+package mongodb
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestBookFilterQueryStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   bson.M
+	}{
+		{status: "", want: bson.M{"status": bson.M{"$nin": domain.DisposalStatuses}}},
+		{status: domain.BookStatusAll, want: bson.M{}},
+		// Livros anteriores ao ciclo de vida não têm status e são próprios.
+		{status: domain.BookOwned, want: bson.M{"status": bson.M{"$in": bson.A{domain.BookOwned, nil}}}},
+		{status: domain.BookDonated, want: bson.M{"status": domain.BookDonated}},
+	}
+	for _, tt := range tests {
+		got := bookFilterQuery(domain.BookFilter{Status: tt.status})
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("bookFilterQuery(status %q) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestDisposedQuery(t *testing.T) {
+	all := bson.M{"status": bson.M{"$in": domain.DisposalStatuses}}
+	if got := disposedQuery(0); !reflect.DeepEqual(got, all) {
+		t.Errorf("disposedQuery(0) = %v, want %v", got, all)
+	}
+
+	want := bson.M{
+		"status": bson.M{"$in": domain.DisposalStatuses},
+		"disposal.date": bson.M{
+			"$gte": time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			"$lt":  time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	if got := disposedQuery(2024); !reflect.DeepEqual(got, want) {
+		t.Errorf("disposedQuery(2024) = %v, want %v", got, want)
+	}
+}
+
+func TestMigrateContributors(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("nothing to migrate", func(mt *mtest.T) {
+		repo := &bookRepositoryMongo{collection: mt.DB.Collection("books")}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.books", mtest.FirstBatch))
+		if err := repo.migrateContributors(); err != nil {
+			mt.Fatalf("migrateContributors: %v", err)
+		}
+		if n := len(mt.GetAllStartedEvents()); n != 1 {
+			mt.Errorf("sent %d commands, want only the find", n)
+		}
+	})
+	mt.Run("splits the author field", func(mt *mtest.T) {
+		repo := &bookRepositoryMongo{collection: mt.DB.Collection("books")}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.books", mtest.FirstBatch,
+				bson.D{{Key: "_id", Value: "b1"}, {Key: "author", Value: "Neil Gaiman & Terry Pratchett"}},
+				bson.D{{Key: "_id", Value: "b2"}, {Key: "author", Value: "Assis, Machado de"}},
+			),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 2}, bson.E{Key: "nModified", Value: 2}),
+		)
+		if err := repo.migrateContributors(); err != nil {
+			mt.Fatalf("migrateContributors: %v", err)
+		}
+
+		events := mt.GetAllStartedEvents()
+		if len(events) != 2 || events[1].CommandName != "update" {
+			mt.Fatalf("commands = %d, want a find and an update", len(events))
+		}
+		updates, _ := events[1].Command.Lookup("updates").Array().Values()
+		var got []string
+		for _, u := range updates {
+			doc := u.Document()
+			line := doc.Lookup("q", "_id").StringValue()
+			contributors, _ := doc.Lookup("u", "$set", "contributors").Array().Values()
+			for _, c := range contributors {
+				line += fmt.Sprintf(" %d:%s", c.Document().Lookup("order").AsInt64(), c.Document().Lookup("name").StringValue())
+			}
+			got = append(got, line)
+		}
+		want := []string{"b1 1:Neil Gaiman 2:Terry Pratchett", "b2 1:Assis, Machado de"}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			mt.Errorf("updates = %q, want %q", got, want)
+		}
+	})
+}
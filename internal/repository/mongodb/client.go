@@ -9,6 +9,9 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// streamTimeout limits long-running cursor iterations such as exports.
+const streamTimeout = 10 * time.Minute
+
 func NewMongoClient(config *configs.Config) (*mongo.Client, error) {
 	clientOptions := options.Client().ApplyURI(config.MongoURI)
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -0,0 +1,304 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// copyMigrationID is the _id of the jobs document recording that every book
+// was given its first copy.
+const copyMigrationID = "copies"
+
+// copyRepositoryMongo implements repository.CopyRepository for MongoDB.
+type copyRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+// NewCopyRepository creates a new copy repository using MongoDB. The first
+// time it runs, every book gets one copy, which takes over its location.
+func NewCopyRepository(client *mongo.Client, config *configs.Config) *copyRepositoryMongo {
+	db := client.Database(config.MongoDatabase)
+	repo := &copyRepositoryMongo{collection: db.Collection(config.MongoCopyCollection)}
+	if err := repo.ensureIndexes(); err != nil {
+		log.Printf("Erro ao criar os índices da coleção de exemplares: %v", err)
+	}
+	if err := repo.migrateBooks(db.Collection(config.MongoCollection), db.Collection(config.MongoJobCollection)); err != nil {
+		log.Printf("Erro ao criar os exemplares dos livros: %v", err)
+	}
+	return repo
+}
+
+// ensureIndexes indexes copies by edition and by location.
+func (r *copyRepositoryMongo) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "book_id", Value: 1}}, Options: options.Index().SetName("book_id")},
+		{
+			Keys:    bson.D{{Key: "location.location_id", Value: 1}, {Key: "location.position", Value: 1}},
+			Options: options.Index().SetName("location"),
+		},
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// migrateBooks gives every book a copy, moving the location of the book to
+// it, and records in the jobs collection that it ran. Editions left without
+// copies later on, such as books given away, are not touched again, even
+// when every copy is gone.
+func (r *copyRepositoryMongo) migrateBooks(books, jobs *mongo.Collection) error {
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	done, err := jobs.CountDocuments(ctx, bson.M{"_id": copyMigrationID}, options.Count().SetLimit(1))
+	if err != nil || done > 0 {
+		return err
+	}
+	// Bases migradas antes do marcador já têm exemplares; só falta registrá-lo.
+	count, err := r.collection.CountDocuments(ctx, bson.M{}, options.Count().SetLimit(1))
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		if err := r.copyBooks(ctx, books); err != nil {
+			return err
+		}
+	}
+	_, err = jobs.InsertOne(ctx, bson.M{"_id": copyMigrationID, "completed_at": time.Now()})
+	return err
+}
+
+// copyBooks inserts a copy of every book, holding its location, and removes
+// the location from the books.
+func (r *copyRepositoryMongo) copyBooks(ctx context.Context, books *mongo.Collection) error {
+	cursor, err := books.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"location": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var copies []interface{}
+	now := time.Now()
+	for cursor.Next(ctx) {
+		var book struct {
+			ID       string               `bson:"_id"`
+			Location *domain.BookLocation `bson:"location"`
+		}
+		if err := cursor.Decode(&book); err != nil {
+			return err
+		}
+		copies = append(copies, &domain.Copy{
+			ID:        uuid.New().String(),
+			BookID:    book.ID,
+			Location:  book.Location,
+			CreatedAt: now,
+		})
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	if len(copies) > 0 {
+		if _, err := r.collection.InsertMany(ctx, copies); err != nil {
+			return err
+		}
+		log.Printf("%d exemplares criados a partir dos livros", len(copies))
+	}
+	_, err = books.UpdateMany(ctx, bson.M{"location": bson.M{"$exists": true}}, bson.M{"$unset": bson.M{"location": ""}})
+	return err
+}
+
+// Create inserts a new copy.
+func (r *copyRepositoryMongo) Create(c *domain.Copy) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c.ID = uuid.New().String()
+
+	_, err := r.collection.InsertOne(ctx, c)
+	return err
+}
+
+// GetByID retrieves a copy by its ID.
+func (r *copyRepositoryMongo) GetByID(id string) (*domain.Copy, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var c domain.Copy
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&c)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrCopyNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+// GetByBookIDs retrieves the copies of the given editions, oldest first.
+func (r *copyRepositoryMongo) GetByBookIDs(bookIDs []string) ([]*domain.Copy, error) {
+	if len(bookIDs) == 0 {
+		return nil, nil
+	}
+	return r.find(bson.M{"book_id": bson.M{"$in": bookIDs}})
+}
+
+// FindByLocations retrieves the copies kept in any of the given locations.
+func (r *copyRepositoryMongo) FindByLocations(locationIDs []string) ([]*domain.Copy, error) {
+	if len(locationIDs) == 0 {
+		return nil, nil
+	}
+	return r.find(bson.M{"location.location_id": bson.M{"$in": locationIDs}})
+}
+
+func (r *copyRepositoryMongo) find(filter bson.M) ([]*domain.Copy, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var copies []*domain.Copy
+	if err := cursor.All(ctx, &copies); err != nil {
+		return nil, err
+	}
+	return copies, nil
+}
+
+// Update sets the condition, notes and acquisition of a copy.
+func (r *copyRepositoryMongo) Update(c *domain.Copy) error {
+	return r.updateOne(c.ID, bson.M{"$set": bson.M{
+		"condition":   c.Condition,
+		"notes":       c.Notes,
+		"acquisition": c.Acquisition,
+	}})
+}
+
+// SpendingTotals sums the prices of the copies purchased, grouped by year of
+// purchase, store and currency.
+func (r *copyRepositoryMongo) SpendingTotals() ([]domain.SpendingTotal, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"acquisition.method": domain.AcquiredPurchase,
+			"acquisition.price":  bson.M{"$gt": 0},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				// Compras sem data ficam no ano zero.
+				"year":     bson.M{"$ifNull": bson.A{bson.M{"$year": "$acquisition.date"}, 0}},
+				"store":    bson.M{"$ifNull": bson.A{"$acquisition.store", ""}},
+				"currency": "$acquisition.currency",
+			},
+			"amount": bson.M{"$sum": "$acquisition.price"},
+			"count":  bson.M{"$sum": 1},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":      0,
+			"year":     "$_id.year",
+			"store":    "$_id.store",
+			"currency": "$_id.currency",
+			"amount":   1,
+			"count":    1,
+		}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var totals []domain.SpendingTotal
+	if err := cursor.All(ctx, &totals); err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// SetLocation sets where a copy is kept, or removes it when location is nil.
+// Placing a copy forgets its last location.
+func (r *copyRepositoryMongo) SetLocation(id string, location *domain.BookLocation) error {
+	if location == nil {
+		return r.updateOne(id, bson.M{"$unset": bson.M{"location": ""}})
+	}
+	return r.updateOne(id, bson.M{"$set": bson.M{"location": location}, "$unset": bson.M{"last_location": ""}})
+}
+
+// Unshelve moves the location of a copy to its last location.
+func (r *copyRepositoryMongo) Unshelve(id string) error {
+	return r.updateOne(id, bson.M{"$rename": bson.M{"location": "last_location"}})
+}
+
+func (r *copyRepositoryMongo) updateOne(id string, update bson.M) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return repository.ErrCopyNotFound
+	}
+
+	return nil
+}
+
+// Relocate moves the copies kept in fromID to toID.
+func (r *copyRepositoryMongo) Relocate(fromID, toID string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateMany(ctx,
+		bson.M{"location.location_id": fromID},
+		bson.M{"$set": bson.M{"location.location_id": toID}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.ModifiedCount), nil
+}
+
+// Delete removes a copy by its ID.
+func (r *copyRepositoryMongo) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return repository.ErrCopyNotFound
+	}
+
+	return nil
+}
+
+// DeleteByBookID removes every copy of an edition.
+func (r *copyRepositoryMongo) DeleteByBookID(bookID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"book_id": bookID})
+	return err
+}
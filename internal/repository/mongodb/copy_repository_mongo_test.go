@@ -0,0 +1,53 @@
+package mongodb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestSpendingTotals(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("grouped totals", func(mt *mtest.T) {
+		repo := &copyRepositoryMongo{collection: mt.DB.Collection("copies")}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.copies", mtest.FirstBatch,
+			bson.D{{Key: "year", Value: 2024}, {Key: "store", Value: "Cultura"}, {Key: "currency", Value: "BRL"}, {Key: "amount", Value: 89.8}, {Key: "count", Value: 2}},
+			bson.D{{Key: "year", Value: 0}, {Key: "store", Value: ""}, {Key: "currency", Value: "USD"}, {Key: "amount", Value: 12.5}, {Key: "count", Value: 1}},
+		))
+
+		totals, err := repo.SpendingTotals()
+		if err != nil {
+			mt.Fatalf("SpendingTotals: %v", err)
+		}
+		want := []domain.SpendingTotal{
+			{Year: 2024, Store: "Cultura", Currency: "BRL", Amount: 89.8, Count: 2},
+			{Year: 0, Store: "", Currency: "USD", Amount: 12.5, Count: 1},
+		}
+		if fmt.Sprint(totals) != fmt.Sprint(want) {
+			mt.Errorf("totals = %v, want %v", totals, want)
+		}
+
+		// Só compras com preço entram na soma.
+		event := mt.GetStartedEvent()
+		if event.CommandName != "aggregate" {
+			mt.Fatalf("command = %s, want aggregate", event.CommandName)
+		}
+		match := event.Command.Lookup("pipeline", "0", "$match")
+		if method := match.Document().Lookup("acquisition.method").StringValue(); method != domain.AcquiredPurchase {
+			mt.Errorf("matched method = %q, want %q", method, domain.AcquiredPurchase)
+		}
+		if _, err := match.Document().LookupErr("acquisition.price", "$gt"); err != nil {
+			mt.Errorf("pipeline does not skip copies without a price: %v", match)
+		}
+	})
+	mt.Run("failing aggregation", func(mt *mtest.T) {
+		repo := &copyRepositoryMongo{collection: mt.DB.Collection("copies")}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "boom"}))
+		if _, err := repo.SpendingTotals(); err == nil {
+			mt.Error("SpendingTotals succeeded with a failing aggregation")
+		}
+	})
+}
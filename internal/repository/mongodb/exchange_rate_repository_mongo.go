@@ -0,0 +1,69 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// exchangeRateRepositoryMongo implements repository.ExchangeRateRepository
+// for MongoDB, keyed by currency code.
+type exchangeRateRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+// NewExchangeRateRepository creates a new exchange rate repository using MongoDB.
+func NewExchangeRateRepository(client *mongo.Client, config *configs.Config) *exchangeRateRepositoryMongo {
+	collection := client.Database(config.MongoDatabase).Collection(config.MongoRateCollection)
+	return &exchangeRateRepositoryMongo{collection: collection}
+}
+
+// Set upserts the rate of a currency.
+func (r *exchangeRateRepositoryMongo) Set(rate *domain.ExchangeRate) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": rate.Currency}, rate, options.Replace().SetUpsert(true))
+	return err
+}
+
+// GetAll retrieves every rate sorted by currency.
+func (r *exchangeRateRepositoryMongo) GetAll() ([]*domain.ExchangeRate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rates []*domain.ExchangeRate
+	if err := cursor.All(ctx, &rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// Delete removes the rate of a currency.
+func (r *exchangeRateRepositoryMongo) Delete(currency string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": currency})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return repository.ErrExchangeRateNotFound
+	}
+
+	return nil
+}
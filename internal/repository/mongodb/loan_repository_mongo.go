@@ -0,0 +1,157 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// loanRepositoryMongo implements repository.LoanRepository for MongoDB.
+type loanRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+// NewLoanRepository creates a new loan repository using MongoDB.
+func NewLoanRepository(client *mongo.Client, config *configs.Config) *loanRepositoryMongo {
+	collection := client.Database(config.MongoDatabase).Collection(config.MongoLoanCollection)
+	repo := &loanRepositoryMongo{collection: collection}
+	if err := repo.ensureIndexes(); err != nil {
+		log.Printf("Erro ao criar os índices da coleção de empréstimos: %v", err)
+	}
+	return repo
+}
+
+// ensureIndexes indexes the active loans by due date and the history of
+// each borrower, and allows a single active loan per copy.
+func (r *loanRepositoryMongo) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "due_at", Value: 1}},
+			Options: options.Index().SetName("status_due_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "borrower", Value: 1}, {Key: "lent_at", Value: -1}},
+			Options: options.Index().SetName("borrower_lent_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "book_id", Value: 1}},
+			Options: options.Index().SetName("book_id"),
+		},
+		{
+			Keys: bson.D{{Key: "copy_id", Value: 1}},
+			Options: options.Index().SetName("copy_active_unique").SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": domain.LoanActive}),
+		},
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// Create inserts a new loan.
+func (r *loanRepositoryMongo) Create(loan *domain.Loan) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	loan.ID = uuid.New().String()
+	_, err := r.collection.InsertOne(ctx, loan)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository.ErrCopyOnLoan
+	}
+	return err
+}
+
+// GetByID retrieves a loan by its ID.
+func (r *loanRepositoryMongo) GetByID(id string) (*domain.Loan, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var loan domain.Loan
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&loan)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrLoanNotFound
+		}
+		return nil, err
+	}
+	return &loan, nil
+}
+
+// GetActive retrieves the active loans sorted by due date.
+func (r *loanRepositoryMongo) GetActive() ([]*domain.Loan, error) {
+	return r.find(bson.M{"status": domain.LoanActive}, bson.D{{Key: "due_at", Value: 1}})
+}
+
+// GetOverdue retrieves the active loans due before now sorted by due date.
+func (r *loanRepositoryMongo) GetOverdue(now time.Time) ([]*domain.Loan, error) {
+	filter := bson.M{"status": domain.LoanActive, "due_at": bson.M{"$lt": now}}
+	return r.find(filter, bson.D{{Key: "due_at", Value: 1}})
+}
+
+// GetActiveByBookID retrieves the active loans of a book sorted by due date.
+func (r *loanRepositoryMongo) GetActiveByBookID(bookID string) ([]*domain.Loan, error) {
+	filter := bson.M{"book_id": bookID, "status": domain.LoanActive}
+	return r.find(filter, bson.D{{Key: "due_at", Value: 1}})
+}
+
+// GetByBorrower retrieves the loans of a borrower, the most recent first.
+func (r *loanRepositoryMongo) GetByBorrower(borrower string) ([]*domain.Loan, error) {
+	return r.find(bson.M{"borrower": equalFoldPattern(borrower)}, bson.D{{Key: "lent_at", Value: -1}})
+}
+
+func (r *loanRepositoryMongo) find(filter bson.M, sort bson.D) ([]*domain.Loan, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(sort))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var loans []*domain.Loan
+	if err := cursor.All(ctx, &loans); err != nil {
+		return nil, err
+	}
+	return loans, nil
+}
+
+// Return marks a loan as returned while it is still active.
+func (r *loanRepositoryMongo) Return(id string, returnedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"status": domain.LoanReturned, "returned_at": returnedAt}}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "status": domain.LoanActive}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		if _, err := r.GetByID(id); err != nil {
+			return err
+		}
+		return repository.ErrLoanReturned
+	}
+
+	return nil
+}
+
+// DeleteByBookID removes every loan of the copies of a book.
+func (r *loanRepositoryMongo) DeleteByBookID(bookID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"book_id": bookID})
+	return err
+}
@@ -0,0 +1,139 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// locationRepositoryMongo implements repository.LocationRepository for MongoDB.
+type locationRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+// NewLocationRepository creates a new location repository using MongoDB.
+func NewLocationRepository(client *mongo.Client, config *configs.Config) *locationRepositoryMongo {
+	collection := client.Database(config.MongoDatabase).Collection(config.MongoLocationCollection)
+	repo := &locationRepositoryMongo{collection: collection}
+	if err := repo.ensureIndexes(); err != nil {
+		log.Printf("Erro ao criar os índices da coleção de localizações: %v", err)
+	}
+	return repo
+}
+
+// ensureIndexes indexes locations by their ancestors, to find everything below one.
+func (r *locationRepositoryMongo) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "ancestors", Value: 1}},
+		Options: options.Index().SetName("ancestors"),
+	})
+	return err
+}
+
+// Create inserts a new location.
+func (r *locationRepositoryMongo) Create(location *domain.Location) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	location.ID = uuid.New().String()
+
+	_, err := r.collection.InsertOne(ctx, location)
+	return err
+}
+
+// GetByID retrieves a location by its ID.
+func (r *locationRepositoryMongo) GetByID(id string) (*domain.Location, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var location domain.Location
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&location)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrLocationNotFound
+		}
+		return nil, err
+	}
+	return &location, nil
+}
+
+// GetByIDs retrieves the locations with the given IDs, in no particular order.
+func (r *locationRepositoryMongo) GetByIDs(ids []string) ([]*domain.Location, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.find(bson.M{"_id": bson.M{"$in": ids}})
+}
+
+// GetAll retrieves every location sorted by name.
+func (r *locationRepositoryMongo) GetAll() ([]*domain.Location, error) {
+	return r.find(bson.M{})
+}
+
+// GetDescendants retrieves the locations below the given one, sorted by name.
+func (r *locationRepositoryMongo) GetDescendants(id string) ([]*domain.Location, error) {
+	return r.find(bson.M{"ancestors": id})
+}
+
+func (r *locationRepositoryMongo) find(filter bson.M) ([]*domain.Location, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var locations []*domain.Location
+	if err := cursor.All(ctx, &locations); err != nil {
+		return nil, err
+	}
+	return locations, nil
+}
+
+// Update replaces an existing location.
+func (r *locationRepositoryMongo) Update(location *domain.Location) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": location.ID}, location)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return repository.ErrLocationNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a location by its ID.
+func (r *locationRepositoryMongo) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return repository.ErrLocationNotFound
+	}
+
+	return nil
+}
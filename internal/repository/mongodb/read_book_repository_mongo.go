@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"errors"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,8 +19,110 @@ type readBookRepositoryMongo struct {
 }
 
 func NewReadBookRepository(client *mongo.Client, config *configs.Config) *readBookRepositoryMongo {
-	collection := client.Database(config.MongoDatabase).Collection(config.MongoCollection)
-	return &readBookRepositoryMongo{collection: collection}
+	db := client.Database(config.MongoDatabase)
+	repo := &readBookRepositoryMongo{collection: db.Collection(config.MongoReadBookCollection)}
+	if err := repo.migrateLegacy(db.Collection(config.MongoCollection)); err != nil {
+		log.Printf("Erro ao migrar as leituras da coleção de livros: %v", err)
+	}
+	return repo
+}
+
+// legacyReadBook is a reading record as it was first stored: in the books
+// collection, under the default lower-case keys of the driver.
+type legacyReadBook struct {
+	ObjectID        interface{} `bson:"_id"`
+	ID              string      `bson:"id"`
+	BookID          string      `bson:"bookid"`
+	StartDate       time.Time   `bson:"startdate"`
+	ExpectedEndDate time.Time   `bson:"expectedenddate"`
+	ActualEndDate   *time.Time  `bson:"actualenddate"`
+	Comments        []string    `bson:"comments"`
+	Rating          *int        `bson:"rating"`
+}
+
+// migrateBatchSize is how many legacy records are moved per bulk write.
+const migrateBatchSize = 500
+
+// migrateLegacy moves the reading records kept in the books collection into
+// their own collection, renaming their keys. The records are streamed and
+// moved in batches; each batch is upserted by ID before its old documents
+// are removed, so an interrupted run is resumed on the next start.
+func (r *readBookRepositoryMongo) migrateLegacy(books *mongo.Collection) error {
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	cursor, err := books.Find(ctx, bson.M{"bookid": bson.M{"$exists": true}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	migrated := 0
+	batch := make([]legacyReadBook, 0, migrateBatchSize)
+	for cursor.Next(ctx) {
+		var old legacyReadBook
+		if err := cursor.Decode(&old); err != nil {
+			return err
+		}
+		batch = append(batch, old)
+		if len(batch) < migrateBatchSize {
+			continue
+		}
+		if err := r.migrateBatch(ctx, books, batch); err != nil {
+			return err
+		}
+		migrated += len(batch)
+		batch = batch[:0]
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		if err := r.migrateBatch(ctx, books, batch); err != nil {
+			return err
+		}
+		migrated += len(batch)
+	}
+
+	if migrated > 0 {
+		log.Printf("%d leituras migradas da coleção de livros", migrated)
+	}
+	return nil
+}
+
+// migrateBatch upserts one batch of legacy records into the reading records
+// collection and removes them from the books collection.
+func (r *readBookRepositoryMongo) migrateBatch(ctx context.Context, books *mongo.Collection, legacy []legacyReadBook) error {
+	updates := make([]mongo.WriteModel, 0, len(legacy))
+	objectIDs := make(bson.A, 0, len(legacy))
+	for _, old := range legacy {
+		rb := domain.ReadBook{
+			ID:              old.ID,
+			BookID:          old.BookID,
+			StartDate:       old.StartDate,
+			ExpectedEndDate: old.ExpectedEndDate,
+			ActualEndDate:   old.ActualEndDate,
+			Comments:        old.Comments,
+			Rating:          old.Rating,
+		}
+		if rb.ID == "" {
+			rb.ID = uuid.New().String()
+		}
+		updates = append(updates, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"id": rb.ID}).
+			SetReplacement(rb).
+			SetUpsert(true))
+		objectIDs = append(objectIDs, old.ObjectID)
+	}
+	if _, err := r.collection.BulkWrite(ctx, updates, options.BulkWrite().SetOrdered(false)); err != nil {
+		return err
+	}
+	// Com a mesma coleção, a substituição já trocou o documento antigo.
+	if books.Name() == r.collection.Name() {
+		return nil
+	}
+	_, err := books.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": objectIDs}, "bookid": bson.M{"$exists": true}})
+	return err
 }
 
 func (r *readBookRepositoryMongo) Create(readBook *domain.ReadBook) error {
@@ -74,6 +177,65 @@ func (r *readBookRepositoryMongo) GetAll() ([]*domain.ReadBook, error) {
 	return readBooks, nil
 }
 
+// GetByBookID retrieves every read book record that belongs to the given book.
+func (r *readBookRepositoryMongo) GetByBookID(bookID string) ([]*domain.ReadBook, error) {
+	return r.findSorted(bson.M{"book_id": bookID})
+}
+
+// GetByBookIDs retrieves the read book records of any of the given books.
+func (r *readBookRepositoryMongo) GetByBookIDs(bookIDs []string) ([]*domain.ReadBook, error) {
+	if len(bookIDs) == 0 {
+		return nil, nil
+	}
+	return r.findSorted(bson.M{"book_id": bson.M{"$in": bookIDs}})
+}
+
+// GetByWorkID retrieves every read book record of the given work, whichever
+// edition was read.
+func (r *readBookRepositoryMongo) GetByWorkID(workID string) ([]*domain.ReadBook, error) {
+	return r.findSorted(bson.M{"work_id": workID})
+}
+
+// GetByWorkOrBookIDs retrieves the read book records of any of the given
+// works or books.
+func (r *readBookRepositoryMongo) GetByWorkOrBookIDs(workIDs, bookIDs []string) ([]*domain.ReadBook, error) {
+	if len(workIDs) == 0 && len(bookIDs) == 0 {
+		return nil, nil
+	}
+	return r.findSorted(bson.M{"$or": bson.A{
+		bson.M{"work_id": bson.M{"$in": workIDs}},
+		bson.M{"book_id": bson.M{"$in": bookIDs}},
+	}})
+}
+
+// findSorted retrieves the read book records matching filter, oldest first.
+func (r *readBookRepositoryMongo) findSorted(filter bson.M) ([]*domain.ReadBook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "start_date", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var readBooks []*domain.ReadBook
+	for cursor.Next(ctx) {
+		var readBook domain.ReadBook
+		if err := cursor.Decode(&readBook); err != nil {
+			return nil, err
+		}
+		readBooks = append(readBooks, &readBook)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return readBooks, nil
+}
+
 func (r *readBookRepositoryMongo) Update(readBook *domain.ReadBook) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -81,6 +243,7 @@ func (r *readBookRepositoryMongo) Update(readBook *domain.ReadBook) error {
 	filter := bson.M{"id": readBook.ID}
 	update := bson.M{
 		"$set": bson.M{
+			"work_id":           readBook.WorkID,
 			"book_id":           readBook.BookID,
 			"start_date":        readBook.StartDate,
 			"expected_end_date": readBook.ExpectedEndDate,
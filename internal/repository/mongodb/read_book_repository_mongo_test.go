@@ -0,0 +1,60 @@
+package mongodb
+
+import (
+	"fmt"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestMigrateLegacy(t *testing.T) {
+	tests := []struct {
+		name        string
+		records     int
+		sameColl    bool
+		wantCommand []string
+	}{
+		{name: "nothing to migrate", records: 0, wantCommand: []string{"find"}},
+		{name: "one batch", records: 3, wantCommand: []string{"find", "update", "delete"}},
+		{name: "several batches", records: migrateBatchSize*2 + 1, wantCommand: []string{"find", "update", "delete", "update", "delete", "update", "delete"}},
+		// Na mesma coleção, a substituição já remove o documento antigo.
+		{name: "same collection", records: 3, sameColl: true, wantCommand: []string{"find", "update"}},
+	}
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			books := mt.DB.Collection("books")
+			readBooks := mt.DB.Collection("read_books")
+			if tt.sameColl {
+				readBooks = books
+			}
+			repo := &readBookRepositoryMongo{collection: readBooks}
+
+			docs := make([]bson.D, tt.records)
+			for i := range docs {
+				docs[i] = bson.D{
+					{Key: "_id", Value: primitive.NewObjectID()},
+					{Key: "id", Value: fmt.Sprintf("r%d", i)},
+					{Key: "bookid", Value: fmt.Sprintf("b%d", i)},
+				}
+			}
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.books", mtest.FirstBatch, docs...))
+			for range tt.wantCommand[1:] {
+				mt.AddMockResponses(mtest.CreateSuccessResponse())
+			}
+
+			if err := repo.migrateLegacy(books); err != nil {
+				mt.Fatalf("migrateLegacy: %v", err)
+			}
+			var got []string
+			for _, event := range mt.GetAllStartedEvents() {
+				got = append(got, event.CommandName)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.wantCommand) {
+				mt.Errorf("commands = %v, want %v", got, tt.wantCommand)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// seriesRepositoryMongo implements repository.SeriesRepository for MongoDB.
+type seriesRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+// NewSeriesRepository creates a new series repository using MongoDB.
+func NewSeriesRepository(client *mongo.Client, config *configs.Config) *seriesRepositoryMongo {
+	collection := client.Database(config.MongoDatabase).Collection(config.MongoSeriesCollection)
+	return &seriesRepositoryMongo{collection: collection}
+}
+
+// Create inserts a new series.
+func (r *seriesRepositoryMongo) Create(series *domain.Series) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	series.ID = uuid.New().String()
+
+	_, err := r.collection.InsertOne(ctx, series)
+	return err
+}
+
+// GetByID retrieves a series by its ID.
+func (r *seriesRepositoryMongo) GetByID(id string) (*domain.Series, error) {
+	return r.findOne(bson.M{"_id": id})
+}
+
+// FindByName retrieves a series by its name, ignoring case.
+func (r *seriesRepositoryMongo) FindByName(name string) (*domain.Series, error) {
+	return r.findOne(bson.M{"name": equalFoldPattern(name)})
+}
+
+func (r *seriesRepositoryMongo) findOne(filter bson.M) (*domain.Series, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var series domain.Series
+	err := r.collection.FindOne(ctx, filter).Decode(&series)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrSeriesNotFound
+		}
+		return nil, err
+	}
+	return &series, nil
+}
+
+// Update replaces an existing series.
+func (r *seriesRepositoryMongo) Update(series *domain.Series) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": series.ID}, series)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return repository.ErrSeriesNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a series by its ID.
+func (r *seriesRepositoryMongo) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return repository.ErrSeriesNotFound
+	}
+
+	return nil
+}
+
+// GetAll retrieves every series sorted by name.
+func (r *seriesRepositoryMongo) GetAll() ([]*domain.Series, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var series []*domain.Series
+	if err := cursor.All(ctx, &series); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
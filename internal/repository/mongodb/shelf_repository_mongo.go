@@ -0,0 +1,250 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// shelfRepositoryMongo implements repository.ShelfRepository for MongoDB.
+// Entries are embedded in the shelf document and changed with atomic array
+// operators, never by rewriting the whole list.
+type shelfRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+// NewShelfRepository creates a new shelf repository using MongoDB.
+func NewShelfRepository(client *mongo.Client, config *configs.Config) *shelfRepositoryMongo {
+	collection := client.Database(config.MongoDatabase).Collection(config.MongoShelfCollection)
+	repo := &shelfRepositoryMongo{collection: collection}
+	if err := repo.ensureIndexes(); err != nil {
+		log.Printf("Erro ao criar os índices da coleção de estantes: %v", err)
+	}
+	return repo
+}
+
+// ensureIndexes indexes shelves by name and by the books they hold.
+func (r *shelfRepositoryMongo) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetName("name")},
+		{Keys: bson.D{{Key: "entries.book_id", Value: 1}}, Options: options.Index().SetName("entries_book_id")},
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// Create inserts a new shelf.
+func (r *shelfRepositoryMongo) Create(shelf *domain.Shelf) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	shelf.ID = uuid.New().String()
+	// Um array nulo impediria o $push das entradas.
+	if shelf.Entries == nil {
+		shelf.Entries = []domain.ShelfEntry{}
+	}
+
+	_, err := r.collection.InsertOne(ctx, shelf)
+	return err
+}
+
+// GetByID retrieves a shelf by its ID, with its entries in order.
+func (r *shelfRepositoryMongo) GetByID(id string) (*domain.Shelf, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var shelf domain.Shelf
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&shelf)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrShelfNotFound
+		}
+		return nil, err
+	}
+	shelf.SortEntries()
+	return &shelf, nil
+}
+
+// GetAll retrieves every shelf sorted by name.
+func (r *shelfRepositoryMongo) GetAll() ([]*domain.Shelf, error) {
+	return r.find(bson.M{})
+}
+
+// GetByBookID retrieves the shelves holding a book, sorted by name.
+func (r *shelfRepositoryMongo) GetByBookID(bookID string) ([]*domain.Shelf, error) {
+	return r.find(bson.M{"entries.book_id": bookID})
+}
+
+func (r *shelfRepositoryMongo) find(filter bson.M) ([]*domain.Shelf, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var shelves []*domain.Shelf
+	if err := cursor.All(ctx, &shelves); err != nil {
+		return nil, err
+	}
+	for _, shelf := range shelves {
+		shelf.SortEntries()
+	}
+	return shelves, nil
+}
+
+// Update sets the name and description of a shelf.
+func (r *shelfRepositoryMongo) Update(shelf *domain.Shelf) error {
+	return r.updateOne(bson.M{"_id": shelf.ID}, bson.M{"$set": bson.M{
+		"name":        shelf.Name,
+		"description": shelf.Description,
+	}})
+}
+
+// Delete removes a shelf by its ID.
+func (r *shelfRepositoryMongo) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return repository.ErrShelfNotFound
+	}
+
+	return nil
+}
+
+// AddEntry appends an entry to a shelf unless the book is already on it or
+// the shelf is no longer at the given revision.
+func (r *shelfRepositoryMongo) AddEntry(shelfID string, revision int64, entry domain.ShelfEntry) error {
+	filter := bson.M{"_id": shelfID, "revision": revisionFilter(revision), "entries.book_id": bson.M{"$ne": entry.BookID}}
+	err := r.updateOne(filter, bson.M{"$push": bson.M{"entries": entry}, "$inc": bson.M{"revision": 1}})
+	if err == repository.ErrShelfNotFound {
+		return r.entryError(shelfID, entry.BookID, true)
+	}
+	return err
+}
+
+// RemoveEntry takes a book off a shelf.
+func (r *shelfRepositoryMongo) RemoveEntry(shelfID, bookID string) error {
+	filter := bson.M{"_id": shelfID, "entries.book_id": bookID}
+	err := r.updateOne(filter, bson.M{"$pull": bson.M{"entries": bson.M{"book_id": bookID}}, "$inc": bson.M{"revision": 1}})
+	if err == repository.ErrShelfNotFound {
+		return r.entryError(shelfID, bookID, false)
+	}
+	return err
+}
+
+// RemoveBook takes a book off every shelf holding it.
+func (r *shelfRepositoryMongo) RemoveBook(bookID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"entries.book_id": bookID}
+	_, err := r.collection.UpdateMany(ctx, filter, bson.M{"$pull": bson.M{"entries": bson.M{"book_id": bookID}}, "$inc": bson.M{"revision": 1}})
+	return err
+}
+
+// SetPositions updates the position of each listed entry in a single atomic
+// update, provided the shelf is still at the given revision.
+func (r *shelfRepositoryMongo) SetPositions(shelfID string, revision int64, positions map[string]float64) error {
+	if len(positions) == 0 {
+		return nil
+	}
+
+	set := bson.M{}
+	arrayFilters := bson.A{}
+	for bookID, position := range positions {
+		name := fmt.Sprintf("e%d", len(arrayFilters))
+		set["entries.$["+name+"].position"] = position
+		arrayFilters = append(arrayFilters, bson.M{name + ".book_id": bookID})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": shelfID, "revision": revisionFilter(revision)}
+	update := bson.M{"$set": set, "$inc": bson.M{"revision": 1}}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{Filters: arrayFilters})
+	result, err := r.collection.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return r.entryError(shelfID, "", false)
+	}
+
+	return nil
+}
+
+// revisionFilter matches the given revision. Shelves never changed, or
+// saved before revisions existed, have none stored and are at revision 0.
+func revisionFilter(revision int64) interface{} {
+	if revision == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+	return revision
+}
+
+func (r *shelfRepositoryMongo) updateOne(filter, update bson.M) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return repository.ErrShelfNotFound
+	}
+
+	return nil
+}
+
+// entryError tells why an update of the shelf matched nothing: the shelf
+// does not exist, the book is already on it (when adding) or missing from
+// it (when removing), or else the shelf changed since it was read.
+func (r *shelfRepositoryMongo) entryError(shelfID, bookID string, adding bool) error {
+	shelf, err := r.GetByID(shelfID)
+	if err != nil {
+		return err
+	}
+	if bookID == "" {
+		return repository.ErrShelfChanged
+	}
+	onShelf := false
+	for _, e := range shelf.Entries {
+		if e.BookID == bookID {
+			onShelf = true
+			break
+		}
+	}
+	switch {
+	case adding && onShelf:
+		return repository.ErrBookAlreadyOnShelf
+	case !adding && !onShelf:
+		return repository.ErrBookNotOnShelf
+	}
+	return repository.ErrShelfChanged
+}
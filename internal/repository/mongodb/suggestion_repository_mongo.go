@@ -0,0 +1,151 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// enrichmentProgressID is the _id of the single document holding the job progress.
+const enrichmentProgressID = "enrichment"
+
+// suggestionRepositoryMongo implements repository.SuggestionRepository and
+// repository.EnrichmentProgressRepository for MongoDB.
+type suggestionRepositoryMongo struct {
+	collection *mongo.Collection
+	jobs       *mongo.Collection
+}
+
+// NewSuggestionRepository creates a new suggestion repository using MongoDB.
+func NewSuggestionRepository(client *mongo.Client, config *configs.Config) *suggestionRepositoryMongo {
+	db := client.Database(config.MongoDatabase)
+	return &suggestionRepositoryMongo{
+		collection: db.Collection(config.MongoSuggestionCollection),
+		jobs:       db.Collection(config.MongoJobCollection),
+	}
+}
+
+// Create inserts a new suggestion.
+func (r *suggestionRepositoryMongo) Create(suggestion *domain.Suggestion) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	suggestion.ID = uuid.New().String()
+
+	_, err := r.collection.InsertOne(ctx, suggestion)
+	return err
+}
+
+// GetByID retrieves a suggestion by its ID.
+func (r *suggestionRepositoryMongo) GetByID(id string) (*domain.Suggestion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var suggestion domain.Suggestion
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&suggestion)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrSuggestionNotFound
+		}
+		return nil, err
+	}
+	return &suggestion, nil
+}
+
+// GetByBookID retrieves the suggestions of a book, newest first.
+func (r *suggestionRepositoryMongo) GetByBookID(bookID, status string) ([]*domain.Suggestion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"book_id": bookID}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	suggestions := []*domain.Suggestion{}
+	if err := cursor.All(ctx, &suggestions); err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
+
+// HasPending reports whether the book has suggestions waiting for review.
+func (r *suggestionRepositoryMongo) HasPending(bookID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"book_id": bookID, "status": domain.SuggestionPending}, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+// HasRejected reports whether a rejected suggestion of the book proposed
+// value for field.
+func (r *suggestionRepositoryMongo) HasRejected(bookID, field, value string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"book_id": bookID,
+		"status":  domain.SuggestionRejected,
+		"changes": bson.M{"$elemMatch": bson.M{"field": field, "proposed": value}},
+	}
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+// Resolve marks a pending suggestion as approved or rejected. Suggestions
+// that were already resolved are reported as not found.
+func (r *suggestionRepositoryMongo) Resolve(id, status string, resolvedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": id, "status": domain.SuggestionPending}
+	update := bson.M{"$set": bson.M{"status": status, "resolved_at": resolvedAt}}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrSuggestionNotFound
+	}
+	return nil
+}
+
+// GetProgress retrieves the progress of the enrichment job.
+func (r *suggestionRepositoryMongo) GetProgress() (*domain.EnrichmentProgress, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var progress domain.EnrichmentProgress
+	err := r.jobs.FindOne(ctx, bson.M{"_id": enrichmentProgressID}).Decode(&progress)
+	if err == mongo.ErrNoDocuments {
+		return &domain.EnrichmentProgress{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// SaveProgress stores the progress of the enrichment job.
+func (r *suggestionRepositoryMongo) SaveProgress(progress *domain.EnrichmentProgress) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.jobs.ReplaceOne(ctx, bson.M{"_id": enrichmentProgressID}, progress, opts)
+	return err
+}
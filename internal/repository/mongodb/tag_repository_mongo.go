@@ -0,0 +1,250 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// tagRepositoryMongo implements repository.TagRepository and
+// repository.TagHistoryRepository for MongoDB.
+type tagRepositoryMongo struct {
+	collection *mongo.Collection
+	history    *mongo.Collection
+}
+
+// NewTagRepository creates a new tag repository using MongoDB. The first
+// time it runs, the tag tree is built from the tags already used by books.
+func NewTagRepository(client *mongo.Client, config *configs.Config) *tagRepositoryMongo {
+	db := client.Database(config.MongoDatabase)
+	repo := &tagRepositoryMongo{
+		collection: db.Collection(config.MongoTagCollection),
+		history:    db.Collection(config.MongoTagHistoryCollection),
+	}
+	if err := repo.ensureIndexes(); err != nil {
+		log.Printf("Erro ao criar os índices da coleção de tags: %v", err)
+	}
+	if err := repo.migrateBookTags(db.Collection(config.MongoCollection)); err != nil {
+		log.Printf("Erro ao criar as tags a partir dos livros: %v", err)
+	}
+	return repo
+}
+
+// ensureIndexes makes tag paths unique.
+func (r *tagRepositoryMongo) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "path", Value: 1}},
+		Options: options.Index().SetName("path_unique").SetUnique(true),
+	})
+	return err
+}
+
+// migrateBookTags fills an empty tag tree with the tags found in books.
+func (r *tagRepositoryMongo) migrateBookTags(books *mongo.Collection) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{}, options.Count().SetLimit(1))
+	if err != nil || count > 0 {
+		return err
+	}
+
+	values, err := books.Distinct(ctx, "tags", bson.M{})
+	if err != nil {
+		return err
+	}
+	var paths []string
+	for _, v := range values {
+		if path, ok := v.(string); ok {
+			if path = domain.NormalizeTagPath(path); path != "" {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return r.EnsurePaths(paths)
+}
+
+// Create inserts a new tag.
+func (r *tagRepositoryMongo) Create(tag *domain.Tag) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tag.ID = uuid.New().String()
+
+	_, err := r.collection.InsertOne(ctx, tag)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository.ErrDuplicateTag
+	}
+	return err
+}
+
+// GetByID retrieves a tag by its ID.
+func (r *tagRepositoryMongo) GetByID(id string) (*domain.Tag, error) {
+	return r.findOne(bson.M{"_id": id})
+}
+
+// GetByPath retrieves a tag by its path.
+func (r *tagRepositoryMongo) GetByPath(path string) (*domain.Tag, error) {
+	return r.findOne(bson.M{"path": path})
+}
+
+func (r *tagRepositoryMongo) findOne(filter bson.M) (*domain.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var tag domain.Tag
+	err := r.collection.FindOne(ctx, filter).Decode(&tag)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrTagNotFound
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// GetAll retrieves every tag sorted by path.
+func (r *tagRepositoryMongo) GetAll() ([]*domain.Tag, error) {
+	return r.find(bson.M{})
+}
+
+// GetSubtree retrieves the tag at path and its descendants.
+func (r *tagRepositoryMongo) GetSubtree(path string) ([]*domain.Tag, error) {
+	return r.find(bson.M{"path": tagPattern(path)})
+}
+
+func (r *tagRepositoryMongo) find(query bson.M) ([]*domain.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "path", Value: 1}})
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tags []*domain.Tag
+	if err := cursor.All(ctx, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Update replaces an existing tag.
+func (r *tagRepositoryMongo) Update(tag *domain.Tag) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": tag.ID}, tag)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository.ErrDuplicateTag
+	}
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return repository.ErrTagNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a tag by its ID.
+func (r *tagRepositoryMongo) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return repository.ErrTagNotFound
+	}
+
+	return nil
+}
+
+// EnsurePaths upserts a tag for each path and ancestor that does not exist yet.
+func (r *tagRepositoryMongo) EnsurePaths(paths []string) error {
+	var models []mongo.WriteModel
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		for _, p := range domain.TagAncestors(path) {
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+			var tag domain.Tag
+			tag.SetPath(p)
+			insert := bson.M{"_id": uuid.New().String(), "name": tag.Name}
+			if tag.Parent != "" {
+				insert["parent"] = tag.Parent
+			}
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"path": p}).
+				SetUpdate(bson.M{"$setOnInsert": insert}).
+				SetUpsert(true))
+		}
+	}
+	if len(models) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	// Outra requisição pode ter criado a mesma tag ao mesmo tempo.
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// AddChange inserts an entry in the tag history.
+func (r *tagRepositoryMongo) AddChange(change *domain.TagChange) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	change.ID = uuid.New().String()
+
+	_, err := r.history.InsertOne(ctx, change)
+	return err
+}
+
+// GetChanges retrieves the tag history, most recent first.
+func (r *tagRepositoryMongo) GetChanges() ([]*domain.TagChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.history.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var changes []*domain.TagChange
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,198 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// wishlistRepositoryMongo implements repository.WishlistRepository for MongoDB.
+type wishlistRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+// NewWishlistRepository creates a new wishlist repository using MongoDB.
+func NewWishlistRepository(client *mongo.Client, config *configs.Config) *wishlistRepositoryMongo {
+	collection := client.Database(config.MongoDatabase).Collection(config.MongoWishlistCollection)
+	return &wishlistRepositoryMongo{collection: collection}
+}
+
+// Create inserts a new wishlist item.
+func (r *wishlistRepositoryMongo) Create(item *domain.WishlistItem) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	item.ID = uuid.New().String()
+
+	_, err := r.collection.InsertOne(ctx, item)
+	return err
+}
+
+// GetByID retrieves a wishlist item by its ID.
+func (r *wishlistRepositoryMongo) GetByID(id string) (*domain.WishlistItem, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var item domain.WishlistItem
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&item)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrWishlistItemNotFound
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetAll retrieves every wishlist item, oldest first.
+func (r *wishlistRepositoryMongo) GetAll() ([]*domain.WishlistItem, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var items []*domain.WishlistItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Update sets the fields of a wishlist item, leaving its reservation and
+// creation date untouched.
+func (r *wishlistRepositoryMongo) Update(item *domain.WishlistItem) error {
+	return r.updateOne(item.ID, nil, bson.M{"$set": bson.M{
+		"title":    item.Title,
+		"author":   item.Author,
+		"isbn":     item.ISBN,
+		"edition":  item.Edition,
+		"format":   item.Format,
+		"priority": item.Priority,
+		"notes":    item.Notes,
+	}}, repository.ErrWishlistItemNotFound)
+}
+
+// Reserve sets the reservation of an item that has none.
+func (r *wishlistRepositoryMongo) Reserve(id string, reservation domain.Reservation) error {
+	condition := bson.M{"reservation": bson.M{"$exists": false}}
+	return r.updateOne(id, condition, bson.M{"$set": bson.M{"reservation": reservation}}, repository.ErrAlreadyReserved)
+}
+
+// CancelReservation removes a reservation when the code matches.
+func (r *wishlistRepositoryMongo) CancelReservation(id, code string) error {
+	condition := bson.M{"reservation.code": code}
+	return r.updateOne(id, condition, bson.M{"$unset": bson.M{"reservation": ""}}, repository.ErrNotReserved)
+}
+
+// updateOne applies update to an item meeting condition. When nothing
+// matches, it reports a missing item or else unmatched.
+func (r *wishlistRepositoryMongo) updateOne(id string, condition, update bson.M, unmatched error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": id}
+	for key, value := range condition {
+		filter[key] = value
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		if _, err := r.GetByID(id); err != nil {
+			return err
+		}
+		return unmatched
+	}
+
+	return nil
+}
+
+// Delete removes a wishlist item by its ID.
+func (r *wishlistRepositoryMongo) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return repository.ErrWishlistItemNotFound
+	}
+
+	return nil
+}
+
+// wishlistShareRepositoryMongo implements repository.WishlistShareRepository
+// for MongoDB, keyed by token.
+type wishlistShareRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+// NewWishlistShareRepository creates a new share link repository using MongoDB.
+func NewWishlistShareRepository(client *mongo.Client, config *configs.Config) *wishlistShareRepositoryMongo {
+	collection := client.Database(config.MongoDatabase).Collection(config.MongoShareCollection)
+	return &wishlistShareRepositoryMongo{collection: collection}
+}
+
+// Replace inserts the new link and then removes the others, so a link
+// always exists while it is being rotated.
+func (r *wishlistShareRepositoryMongo) Replace(share *domain.WishlistShare) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := r.collection.InsertOne(ctx, share); err != nil {
+		return err
+	}
+	_, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$ne": share.Token}})
+	return err
+}
+
+// Get retrieves the most recent share link.
+func (r *wishlistShareRepositoryMongo) Get() (*domain.WishlistShare, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	var share domain.WishlistShare
+	err := r.collection.FindOne(ctx, bson.M{}, opts).Decode(&share)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrShareNotFound
+		}
+		return nil, err
+	}
+	return &share, nil
+}
+
+// Exists reports whether a share link has the given token.
+func (r *wishlistShareRepositoryMongo) Exists(token string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": token}, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+// DeleteAll revokes every share link.
+func (r *wishlistShareRepositoryMongo) DeleteAll() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{})
+	return err
+}
@@ -0,0 +1,275 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/configs"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// workRepositoryMongo implements repository.WorkRepository for MongoDB.
+type workRepositoryMongo struct {
+	collection *mongo.Collection
+}
+
+// NewWorkRepository creates a new work repository using MongoDB. Books saved
+// before works existed are linked to a work, editions sharing title and
+// author to the same one, and their reading records follow.
+func NewWorkRepository(client *mongo.Client, config *configs.Config) *workRepositoryMongo {
+	db := client.Database(config.MongoDatabase)
+	repo := &workRepositoryMongo{collection: db.Collection(config.MongoWorkCollection)}
+	if err := repo.ensureIndexes(); err != nil {
+		log.Printf("Erro ao criar os índices da coleção de obras: %v", err)
+	}
+	books := db.Collection(config.MongoCollection)
+	if err := repo.migrateBooks(books); err != nil {
+		log.Printf("Erro ao vincular os livros às obras: %v", err)
+	}
+	if err := repo.migrateReadBooks(books, db.Collection(config.MongoReadBookCollection)); err != nil {
+		log.Printf("Erro ao vincular as leituras às obras: %v", err)
+	}
+	return repo
+}
+
+// ensureIndexes indexes works by title and author.
+func (r *workRepositoryMongo) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "title", Value: 1}, {Key: "author", Value: 1}},
+		Options: options.Index().SetName("title_author"),
+	})
+	return err
+}
+
+// migrateBooks links the books without a work to the work with the same
+// title and author, creating it when missing.
+func (r *workRepositoryMongo) migrateBooks(books *mongo.Collection) error {
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	opts := options.Find().SetProjection(bson.M{"title": 1, "author": 1})
+	cursor, err := books.Find(ctx, bson.M{"work_id": bson.M{"$exists": false}}, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	workIDs := make(map[string]string)
+	var updates []mongo.WriteModel
+	for cursor.Next(ctx) {
+		var book domain.Book
+		if err := cursor.Decode(&book); err != nil {
+			return err
+		}
+		key := strings.ToLower(book.Title) + "\x00" + strings.ToLower(book.Author)
+		workID, ok := workIDs[key]
+		if !ok {
+			work, err := r.FindByTitleAuthor(book.Title, book.Author)
+			if err == repository.ErrWorkNotFound {
+				work = domain.WorkFromBook(&book)
+				err = r.Create(work)
+			}
+			if err != nil {
+				return err
+			}
+			workID = work.ID
+			workIDs[key] = workID
+		}
+		updates = append(updates, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": book.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"work_id": workID}}))
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	result, err := books.BulkWrite(ctx, updates, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return err
+	}
+	log.Printf("%d livros vinculados a %d obras", result.ModifiedCount, len(workIDs))
+	return nil
+}
+
+// migrateReadBooks links the reading records without a work to the work of
+// their book. The rating of the latest rated reading becomes the rating of
+// works that have none.
+func (r *workRepositoryMongo) migrateReadBooks(books, readBooks *mongo.Collection) error {
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "start_date", Value: 1}})
+	cursor, err := readBooks.Find(ctx, bson.M{"work_id": bson.M{"$exists": false}, "book_id": bson.M{"$gt": ""}}, opts)
+	if err != nil {
+		return err
+	}
+	var pending []*domain.ReadBook
+	if err := cursor.All(ctx, &pending); err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	var bookIDs []string
+	for _, rb := range pending {
+		bookIDs = append(bookIDs, rb.BookID)
+	}
+	cursor, err = books.Find(ctx, bson.M{"_id": bson.M{"$in": bookIDs}}, options.Find().SetProjection(bson.M{"work_id": 1}))
+	if err != nil {
+		return err
+	}
+	var linked []*domain.Book
+	if err := cursor.All(ctx, &linked); err != nil {
+		return err
+	}
+	workOf := make(map[string]string, len(linked))
+	for _, book := range linked {
+		workOf[book.ID] = book.WorkID
+	}
+
+	var updates []mongo.WriteModel
+	ratings := make(map[string]int)
+	for _, rb := range pending {
+		workID := workOf[rb.BookID]
+		if workID == "" {
+			continue
+		}
+		updates = append(updates, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"id": rb.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"work_id": workID}}))
+		if rb.Rating != nil {
+			ratings[workID] = *rb.Rating
+		}
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	if _, err := readBooks.BulkWrite(ctx, updates, options.BulkWrite().SetOrdered(false)); err != nil {
+		return err
+	}
+
+	var rated []mongo.WriteModel
+	for workID, rating := range ratings {
+		rated = append(rated, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": workID, "rating": bson.M{"$exists": false}}).
+			SetUpdate(bson.M{"$set": bson.M{"rating": rating}}))
+	}
+	if len(rated) > 0 {
+		if _, err := r.collection.BulkWrite(ctx, rated, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+	log.Printf("%d leituras vinculadas às obras", len(updates))
+	return nil
+}
+
+// Create inserts a new work.
+func (r *workRepositoryMongo) Create(work *domain.Work) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	work.ID = uuid.New().String()
+
+	_, err := r.collection.InsertOne(ctx, work)
+	return err
+}
+
+// GetByID retrieves a work by its ID.
+func (r *workRepositoryMongo) GetByID(id string) (*domain.Work, error) {
+	return r.findOne(bson.M{"_id": id})
+}
+
+// FindByTitleAuthor retrieves a work by its title and author, ignoring case.
+func (r *workRepositoryMongo) FindByTitleAuthor(title, author string) (*domain.Work, error) {
+	return r.findOne(bson.M{"title": equalFoldPattern(title), "author": equalFoldPattern(author)})
+}
+
+func (r *workRepositoryMongo) findOne(filter bson.M) (*domain.Work, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var work domain.Work
+	err := r.collection.FindOne(ctx, filter).Decode(&work)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrWorkNotFound
+		}
+		return nil, err
+	}
+	return &work, nil
+}
+
+// Update replaces an existing work.
+func (r *workRepositoryMongo) Update(work *domain.Work) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": work.ID}, work)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return repository.ErrWorkNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a work by its ID.
+func (r *workRepositoryMongo) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return repository.ErrWorkNotFound
+	}
+
+	return nil
+}
+
+// Search retrieves the works whose title or original title contains title,
+// sorted by title.
+func (r *workRepositoryMongo) Search(title string) ([]*domain.Work, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if title != "" {
+		filter["$or"] = bson.A{
+			bson.M{"title": containsPattern(title)},
+			bson.M{"original_title": containsPattern(title)},
+		}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "title", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var works []*domain.Work
+	if err := cursor.All(ctx, &works); err != nil {
+		return nil, err
+	}
+	return works, nil
+}
@@ -11,5 +11,12 @@ type ReadBookRepository interface {
 	Update(readBook *domain.ReadBook) error
 	Delete(id string) error
 	GetAll() ([]*domain.ReadBook, error)
+	GetByBookID(bookID string) ([]*domain.ReadBook, error)
+	// GetByBookIDs retrieves, in one query, the records of the given books.
+	GetByBookIDs(bookIDs []string) ([]*domain.ReadBook, error)
+	GetByWorkID(workID string) ([]*domain.ReadBook, error)
+	// GetByWorkOrBookIDs retrieves, in one query, the records of any of the
+	// given works or books.
+	GetByWorkOrBookIDs(workIDs, bookIDs []string) ([]*domain.ReadBook, error)
 	AddComment(id string, comment string) error
 }
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var (
+	ErrSeriesNotFound = errors.New("series not found")
+)
+
+type SeriesRepository interface {
+	Create(series *domain.Series) error
+	GetByID(id string) (*domain.Series, error)
+	// FindByName returns the series called name, ignoring case.
+	FindByName(name string) (*domain.Series, error)
+	Update(series *domain.Series) error
+	Delete(id string) error
+	GetAll() ([]*domain.Series, error)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var (
+	ErrShelfNotFound      = errors.New("shelf not found")
+	ErrBookAlreadyOnShelf = errors.New("the book is already on this shelf")
+	ErrBookNotOnShelf     = errors.New("the book is not on this shelf")
+	ErrShelfChanged       = errors.New("the shelf was changed by someone else")
+)
+
+// ShelfRepository stores shelves with their entries. Entries are changed one
+// at a time and every change bumps the shelf revision. Writes that depend on
+// the positions of the other entries take the revision they were computed
+// from and fail with ErrShelfChanged when the shelf moved on, so concurrent
+// edits of a shelf do not overwrite each other.
+type ShelfRepository interface {
+	Create(shelf *domain.Shelf) error
+	GetByID(id string) (*domain.Shelf, error)
+	GetAll() ([]*domain.Shelf, error)
+	// GetByBookID returns the shelves holding the book.
+	GetByBookID(bookID string) ([]*domain.Shelf, error)
+	// Update changes the name and description of a shelf, leaving its entries untouched.
+	Update(shelf *domain.Shelf) error
+	Delete(id string) error
+	AddEntry(shelfID string, revision int64, entry domain.ShelfEntry) error
+	RemoveEntry(shelfID, bookID string) error
+	// RemoveBook takes a book off every shelf holding it.
+	RemoveBook(bookID string) error
+	// SetPositions moves the entries of the books in positions, keyed by book ID.
+	SetPositions(shelfID string, revision int64, positions map[string]float64) error
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var (
+	ErrSuggestionNotFound = errors.New("suggestion not found")
+)
+
+// SuggestionRepository stores the changes proposed by the enrichment job.
+type SuggestionRepository interface {
+	Create(suggestion *domain.Suggestion) error
+	GetByID(id string) (*domain.Suggestion, error)
+	// GetByBookID returns the suggestions of a book, optionally filtered by status.
+	GetByBookID(bookID, status string) ([]*domain.Suggestion, error)
+	HasPending(bookID string) (bool, error)
+	// HasRejected reports whether a suggestion setting field to value was
+	// already rejected for the book.
+	HasRejected(bookID, field, value string) (bool, error)
+	Resolve(id, status string, resolvedAt time.Time) error
+}
+
+// EnrichmentProgressRepository keeps the progress of the enrichment job.
+type EnrichmentProgressRepository interface {
+	// GetProgress returns the saved progress, or a zero value when the job never ran.
+	GetProgress() (*domain.EnrichmentProgress, error)
+	SaveProgress(progress *domain.EnrichmentProgress) error
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var (
+	ErrTagNotFound  = errors.New("tag not found")
+	ErrDuplicateTag = errors.New("a tag with this path already exists")
+)
+
+type TagRepository interface {
+	Create(tag *domain.Tag) error
+	GetByID(id string) (*domain.Tag, error)
+	GetByPath(path string) (*domain.Tag, error)
+	// GetAll returns every tag sorted by path.
+	GetAll() ([]*domain.Tag, error)
+	// GetSubtree returns the tag at path and its descendants, sorted by path.
+	GetSubtree(path string) ([]*domain.Tag, error)
+	Update(tag *domain.Tag) error
+	Delete(id string) error
+	// EnsurePaths creates the tags missing for the given paths and their ancestors.
+	EnsurePaths(paths []string) error
+}
+
+// TagHistoryRepository keeps the log of operations that rewrote book tags.
+type TagHistoryRepository interface {
+	AddChange(change *domain.TagChange) error
+	// GetChanges returns the logged changes, most recent first.
+	GetChanges() ([]*domain.TagChange, error)
+}
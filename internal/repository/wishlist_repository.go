@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var (
+	ErrWishlistItemNotFound = errors.New("wishlist item not found")
+	ErrAlreadyReserved      = errors.New("the book has already been reserved")
+	ErrNotReserved          = errors.New("the book is not reserved with this code")
+	ErrShareNotFound        = errors.New("share link not found")
+)
+
+type WishlistRepository interface {
+	Create(item *domain.WishlistItem) error
+	GetByID(id string) (*domain.WishlistItem, error)
+	GetAll() ([]*domain.WishlistItem, error)
+	// Update replaces the item, keeping its reservation.
+	Update(item *domain.WishlistItem) error
+	Delete(id string) error
+	// Reserve reserves an item that is not reserved yet.
+	Reserve(id string, reservation domain.Reservation) error
+	// CancelReservation removes the reservation made with code.
+	CancelReservation(id, code string) error
+}
+
+type WishlistShareRepository interface {
+	// Replace makes share the only share link, revoking the previous ones.
+	Replace(share *domain.WishlistShare) error
+	Get() (*domain.WishlistShare, error)
+	// Exists reports whether token belongs to a live share link.
+	Exists(token string) (bool, error)
+	DeleteAll() error
+}
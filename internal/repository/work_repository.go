@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+var (
+	ErrWorkNotFound = errors.New("work not found")
+)
+
+type WorkRepository interface {
+	Create(work *domain.Work) error
+	GetByID(id string) (*domain.Work, error)
+	// FindByTitleAuthor returns the work with the given title and author,
+	// ignoring case.
+	FindByTitleAuthor(title, author string) (*domain.Work, error)
+	Update(work *domain.Work) error
+	Delete(id string) error
+	// Search returns the works whose title or original title contains
+	// title, ignoring case, or every work when title is empty.
+	Search(title string) ([]*domain.Work, error)
+}
@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// metaSuffix names the sidecar file holding an object's content type.
+const metaSuffix = ".meta.json"
+
+// FileSystemStore keeps objects as files under a root directory. Its signed
+// URLs point to the API's /blobs route and need a URLSigner.
+type FileSystemStore struct {
+	root   string
+	signer *URLSigner
+}
+
+type fileMeta struct {
+	ContentType string `json:"content_type"`
+}
+
+// NewFileSystemStore creates a store rooted at dir, creating it if needed.
+// signer may be nil, in which case SignedURL is not available.
+func NewFileSystemStore(dir string, signer *URLSigner) (*FileSystemStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileSystemStore{root: dir, signer: signer}, nil
+}
+
+func (s *FileSystemStore) path(key string) (string, error) {
+	if err := validateKey(key); err != nil || strings.HasSuffix(key, metaSuffix) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// Put writes the object to a temporary file and renames it into place, so
+// readers never see a partially written object. The sidecar is written only
+// once the object is in place, and the object is removed again if the
+// sidecar cannot be written, so neither is left without the other.
+func (s *FileSystemStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	meta, err := json.Marshal(fileMeta{ContentType: contentType})
+	if err != nil {
+		return err
+	}
+	if err := writeFile(path, r); err != nil {
+		return err
+	}
+	if err := writeFile(path+metaSuffix, strings.NewReader(string(meta))); err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+func writeFile(path string, r io.Reader) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FileSystemStore) Get(ctx context.Context, key string) (io.ReadCloser, *BlobInfo, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return f, s.info(key, path, stat), nil
+}
+
+func (s *FileSystemStore) Stat(ctx context.Context, key string) (*BlobInfo, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && stat.IsDir()) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.info(key, path, stat), nil
+}
+
+func (s *FileSystemStore) info(key, path string, stat fs.FileInfo) *BlobInfo {
+	info := &BlobInfo{Key: key, Size: stat.Size(), ModTime: stat.ModTime()}
+	if data, err := os.ReadFile(path + metaSuffix); err == nil {
+		var meta fileMeta
+		if json.Unmarshal(data, &meta) == nil {
+			info.ContentType = meta.ContentType
+		}
+	}
+	return info
+}
+
+// List walks the root directory, skipping metadata sidecars and temporary files.
+func (s *FileSystemStore) List(ctx context.Context, prefix string) ([]*BlobInfo, error) {
+	var blobs []*BlobInfo
+	err := filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(path, metaSuffix) || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		stat, err := d.Info()
+		if err != nil {
+			return err
+		}
+		blobs = append(blobs, s.info(key, path, stat))
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].Key < blobs[j].Key })
+	return blobs, nil
+}
+
+func (s *FileSystemStore) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if s.signer == nil {
+		return "", ErrSignedURLUnsupported
+	}
+	if _, err := s.Stat(ctx, key); err != nil {
+		return "", err
+	}
+	return s.signer.Sign(key, time.Now().Add(expiry)), nil
+}
+
+// VerifySignedURL checks the query of a link created by SignedURL.
+func (s *FileSystemStore) VerifySignedURL(key string, query url.Values) error {
+	if s.signer == nil {
+		return ErrSignedURLUnsupported
+	}
+	return s.signer.Verify(key, query)
+}
+
+func (s *FileSystemStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	for _, p := range []string{path, path + metaSuffix} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestFileSystemStorePut(t *testing.T) {
+	readErr := errors.New("connection reset")
+	tests := []struct {
+		name     string
+		body     io.Reader
+		wantErr  error
+		wantBody string
+	}{
+		{name: "new object", body: strings.NewReader("capa de Dom Casmurro"), wantBody: "capa de Dom Casmurro"},
+		// Um upload interrompido não deixa nem o objeto nem o arquivo de metadados.
+		{name: "broken upload", body: io.MultiReader(strings.NewReader("capa"), iotest.ErrReader(readErr)), wantErr: readErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			store, err := NewFileSystemStore(dir, nil)
+			if err != nil {
+				t.Fatalf("NewFileSystemStore: %v", err)
+			}
+			ctx := context.Background()
+
+			if err := store.Put(ctx, "covers/b1/original", tt.body, "image/jpeg"); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Put error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				entries, _ := os.ReadDir(filepath.Join(dir, "covers", "b1"))
+				if len(entries) != 0 {
+					t.Errorf("%d files left after a failed Put, want none", len(entries))
+				}
+				return
+			}
+
+			body, info, err := store.Get(ctx, "covers/b1/original")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			got, _ := io.ReadAll(body)
+			body.Close()
+			if string(got) != tt.wantBody || info.ContentType != "image/jpeg" {
+				t.Errorf("Get = %q, %+v; want %q as image/jpeg", got, info, tt.wantBody)
+			}
+		})
+	}
+}
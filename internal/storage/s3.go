@@ -0,0 +1,293 @@
+package storage
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSignedURLExpiry is the longest validity S3 accepts for presigned URLs.
+const maxSignedURLExpiry = 7 * 24 * time.Hour
+
+// S3Options configures an S3Store.
+type S3Options struct {
+	// Endpoint is the service URL, e.g. "https://s3.amazonaws.com" or
+	// "http://localhost:9000" for MinIO.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle addresses the bucket as http://host/bucket/key instead of
+	// http://bucket.host/key. MinIO and most local stand-ins need it.
+	PathStyle bool
+}
+
+// S3Store keeps objects in a bucket of an S3-compatible service, talking
+// to its REST API directly.
+type S3Store struct {
+	endpoint  *url.URL
+	bucket    string
+	pathStyle bool
+	signer    *sigV4Signer
+	client    *http.Client
+}
+
+// NewS3Store creates a store for the bucket described by opts.
+func NewS3Store(opts S3Options) (*S3Store, error) {
+	if opts.Endpoint == "" || opts.Bucket == "" {
+		return nil, errors.New("s3 blob store needs an endpoint and a bucket")
+	}
+	endpoint, err := url.Parse(strings.TrimRight(opts.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid s3 endpoint: %w", err)
+	}
+	region := opts.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &S3Store{
+		endpoint:  endpoint,
+		bucket:    opts.Bucket,
+		pathStyle: opts.PathStyle,
+		signer:    &sigV4Signer{accessKey: opts.AccessKey, secretKey: opts.SecretKey, region: region},
+		client:    &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+// objectURL returns the URL of key, or of the bucket itself when key is empty.
+func (s *S3Store) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	path := "/" + key
+	if s.pathStyle {
+		path = "/" + s.bucket + path
+	} else {
+		u.Host = s.bucket + "." + u.Host
+	}
+	u.Path = path
+	u.RawPath = uriEncode(path, false)
+	return &u
+}
+
+// do sends a signed request without a body.
+func (s *S3Store) do(ctx context.Context, method string, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	s.signer.signRequest(req, emptyPayloadHash, time.Now())
+	return s.client.Do(req)
+}
+
+// Put streams the object in a single request. The payload is sent unsigned,
+// so it is never hashed ahead of time; S3 still needs its length, and
+// readers that cannot tell it are first copied to a temporary file.
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	body, length, cleanup, err := sizedBody(r)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = length
+	if length == 0 {
+		// Com corpo vazio o cliente HTTP omitiria o Content-Length exigido pelo S3.
+		req.Body = http.NoBody
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.signer.signRequest(req, unsignedPayload, time.Now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+// sizedBody returns r along with the number of bytes left in it. Readers
+// that report their length or can seek are used as they are; others are
+// spooled to a temporary file, removed by cleanup.
+func sizedBody(r io.Reader) (io.Reader, int64, func(), error) {
+	noop := func() {}
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return r, int64(v.Len()), noop, nil
+	case io.Seeker:
+		cur, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			break
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			break
+		}
+		if _, err := v.Seek(cur, io.SeekStart); err != nil {
+			return nil, 0, nil, err
+		}
+		return r, end - cur, noop, nil
+	}
+
+	f, err := os.CreateTemp("", "s3-put-*")
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	cleanup := func() {
+		f.Close()
+		os.Remove(f.Name())
+	}
+	length, err := io.Copy(f, r)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		cleanup()
+		return nil, 0, nil, err
+	}
+	return f, length, cleanup, nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, *BlobInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, s.objectURL(key))
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, nil, err
+	}
+	return resp.Body, blobInfoFromHeader(key, resp.Header), nil
+}
+
+func (s *S3Store) Stat(ctx context.Context, key string) (*BlobInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodHead, s.objectURL(key))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+	return blobInfoFromHeader(key, resp.Header), nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, s.objectURL(key))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List pages through ListObjectsV2. The listing does not include content
+// types; use Stat for those.
+func (s *S3Store) List(ctx context.Context, prefix string) ([]*BlobInfo, error) {
+	var blobs []*BlobInfo
+	token := ""
+	for {
+		u := s.objectURL("")
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		u.RawQuery = canonicalQuery(query)
+
+		resp, err := s.do(ctx, http.MethodGet, u)
+		if err != nil {
+			return nil, err
+		}
+		var result listBucketResult
+		err = checkResponse(resp)
+		if err == nil {
+			err = xml.NewDecoder(resp.Body).Decode(&result)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range result.Contents {
+			blobs = append(blobs, &BlobInfo{Key: c.Key, Size: c.Size, ModTime: c.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return blobs, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// SignedURL presigns a GET request, so the link is served by the storage
+// service itself.
+func (s *S3Store) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	expiry = min(max(expiry, time.Second), maxSignedURLExpiry)
+	return s.signer.presign(s.objectURL(key), expiry, time.Now()), nil
+}
+
+func blobInfoFromHeader(key string, header http.Header) *BlobInfo {
+	info := &BlobInfo{Key: key, ContentType: header.Get("Content-Type")}
+	info.Size, _ = strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	info.ModTime, _ = http.ParseTime(header.Get("Last-Modified"))
+	return info
+}
+
+// checkResponse turns S3 error responses into Go errors, mapping a missing
+// key to ErrNotFound.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	var s3Err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if xml.Unmarshal(body, &s3Err) == nil && s3Err.Code != "" {
+		return fmt.Errorf("s3: %s: %s", s3Err.Code, s3Err.Message)
+	}
+	return fmt.Errorf("s3: unexpected status %s", resp.Status)
+}
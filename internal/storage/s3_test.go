@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is an in-memory stand-in for the object endpoints of S3, with
+// path-style addressing.
+type fakeS3 struct {
+	t       *testing.T
+	bucket  string
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	data        []byte
+	contentType string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), sigAlgorithm+" Credential=minio/") {
+		http.Error(w, "unsigned request", http.StatusForbidden)
+		return
+	}
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+f.bucket+"/")
+	if !ok {
+		http.Error(w, "unknown bucket", http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		if r.ContentLength < 0 {
+			http.Error(w, "missing Content-Length", http.StatusLengthRequired)
+			return
+		}
+		if got := r.Header.Get("X-Amz-Content-Sha256"); got != unsignedPayload {
+			f.t.Errorf("PUT payload hash = %q, want %q", got, unsignedPayload)
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = fakeObject{data: data, contentType: r.Header.Get("Content-Type")}
+	case http.MethodGet, http.MethodHead:
+		obj, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, "<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>")
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		http.ServeContent(w, r, key, time.Time{}, bytes.NewReader(obj.data))
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newFakeS3Store(t *testing.T) (*S3Store, *fakeS3) {
+	t.Helper()
+	fake := &fakeS3{t: t, bucket: "library", objects: make(map[string]fakeObject)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	store, err := NewS3Store(S3Options{
+		Endpoint:  server.URL,
+		Bucket:    "library",
+		AccessKey: "minio",
+		SecretKey: "minio123",
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store, fake
+}
+
+// onlyReader hides the length and seeking of the wrapped reader.
+type onlyReader struct{ io.Reader }
+
+func TestS3StorePutGetDelete(t *testing.T) {
+	tests := []struct {
+		name string
+		body func(data []byte) io.Reader
+	}{
+		{"sized reader", func(data []byte) io.Reader { return bytes.NewReader(data) }},
+		{"stream of unknown length", func(data []byte) io.Reader { return onlyReader{bytes.NewReader(data)} }},
+		{"empty body", func([]byte) io.Reader { return strings.NewReader("") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, fake := newFakeS3Store(t)
+			ctx := context.Background()
+			data := []byte("capa de Dom Casmurro")
+			if tt.name == "empty body" {
+				data = []byte{}
+			}
+
+			if err := store.Put(ctx, "covers/b1/original", tt.body(data), "image/jpeg"); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			if got := fake.objects["covers/b1/original"].data; !bytes.Equal(got, data) {
+				t.Fatalf("stored %q, want %q", got, data)
+			}
+
+			body, info, err := store.Get(ctx, "covers/b1/original")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			got, _ := io.ReadAll(body)
+			body.Close()
+			if !bytes.Equal(got, data) || info.ContentType != "image/jpeg" || info.Size != int64(len(data)) {
+				t.Errorf("Get = %q, %+v", got, info)
+			}
+
+			stat, err := store.Stat(ctx, "covers/b1/original")
+			if err != nil || stat.Size != int64(len(data)) {
+				t.Errorf("Stat = %+v, %v", stat, err)
+			}
+
+			if err := store.Delete(ctx, "covers/b1/original"); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			if _, ok := fake.objects["covers/b1/original"]; ok {
+				t.Error("object still stored after Delete")
+			}
+		})
+	}
+}
+
+func TestS3StoreNotFound(t *testing.T) {
+	store, _ := newFakeS3Store(t)
+	ctx := context.Background()
+
+	if _, _, err := store.Get(ctx, "covers/missing/original"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get error = %v, want ErrNotFound", err)
+	}
+	if _, err := store.Stat(ctx, "covers/missing/original"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Stat error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, "covers/missing/original"); err != nil {
+		t.Errorf("Delete of a missing key = %v, want nil", err)
+	}
+}
+
+func TestS3StoreRejectsInvalidKeys(t *testing.T) {
+	store, _ := newFakeS3Store(t)
+	for _, key := range []string{"", "/abs", "a/../b", "a//b"} {
+		if err := store.Put(context.Background(), key, strings.NewReader("x"), ""); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
+
+func TestS3StoreReportsServiceErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, "<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>")
+	}))
+	defer server.Close()
+	store, err := NewS3Store(S3Options{Endpoint: server.URL, Bucket: "library", PathStyle: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.Put(context.Background(), "covers/b1/original", strings.NewReader("x"), "")
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Put error = %v, want AccessDenied", err)
+	}
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/storage"
+)
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+)
+
+type AttachmentUseCase interface {
+	AddAttachment(bookID, name, contentType string, data []byte, metadata map[string]string) (*domain.Attachment, error)
+	// GetAttachment opens the content of an attachment. The caller must close it.
+	GetAttachment(bookID, attachmentID string) (io.ReadCloser, *domain.Attachment, error)
+}
+
+type attachmentUseCase struct {
+	bookRepo repository.BookRepository
+	store    storage.BlobStore
+}
+
+func NewAttachmentUseCase(br repository.BookRepository, store storage.BlobStore) AttachmentUseCase {
+	return &attachmentUseCase{
+		bookRepo: br,
+		store:    store,
+	}
+}
+
+func attachmentKey(bookID, attachmentID string) string {
+	return "attachments/" + bookID + "/" + attachmentID
+}
+
+func (uc *attachmentUseCase) AddAttachment(bookID, name, contentType string, data []byte, metadata map[string]string) (*domain.Attachment, error) {
+	if _, err := uc.bookRepo.GetByID(bookID); err != nil {
+		return nil, err
+	}
+
+	attachment := &domain.Attachment{
+		ID:          uuid.New().String(),
+		Name:        name,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Metadata:    metadata,
+		CreatedAt:   time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	key := attachmentKey(bookID, attachment.ID)
+	if err := uc.store.Put(ctx, key, bytes.NewReader(data), contentType); err != nil {
+		return nil, err
+	}
+	if err := uc.bookRepo.AddAttachment(bookID, attachment); err != nil {
+		uc.store.Delete(ctx, key)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (uc *attachmentUseCase) GetAttachment(bookID, attachmentID string) (io.ReadCloser, *domain.Attachment, error) {
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, attachment := range book.Attachments {
+		if attachment.ID != attachmentID {
+			continue
+		}
+		// Sem prazo: o conteúdo é lido pelo chamador depois do retorno.
+		body, _, err := uc.store.Get(context.Background(), attachmentKey(bookID, attachmentID))
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return body, &attachment, nil
+	}
+	return nil, nil, ErrAttachmentNotFound
+}
@@ -0,0 +1,332 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/isbn"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+)
+
+var (
+	ErrInvalidScan = errors.New("at least one scanned code is required")
+)
+
+type AuditUseCase interface {
+	// StartAudit opens a session for a location. A location has at most one
+	// open session, which can be resumed until it is closed: when one is
+	// already open, it is returned along with ErrAuditInProgress.
+	StartAudit(locationID, notes string) (*domain.AuditSession, error)
+	// GetAudit returns a session. Open sessions carry a provisional report.
+	GetAudit(id string) (*domain.AuditSession, error)
+	// GetAudits returns the history of sessions, optionally for a location.
+	GetAudits(locationID string) ([]*domain.AuditSession, error)
+	// Scan records scanned copy IDs, ISBNs or book IDs and tells the outcome
+	// of each one.
+	Scan(id string, codes []string) ([]domain.AuditScan, error)
+	// CloseAudit closes a session and stores its report.
+	CloseAudit(id string) (*domain.AuditSession, error)
+}
+
+type auditUseCase struct {
+	auditRepo    repository.AuditRepository
+	locationRepo repository.LocationRepository
+	copyRepo     repository.CopyRepository
+	bookRepo     repository.BookRepository
+}
+
+func NewAuditUseCase(ar repository.AuditRepository, lr repository.LocationRepository, cr repository.CopyRepository, br repository.BookRepository) AuditUseCase {
+	return &auditUseCase{
+		auditRepo:    ar,
+		locationRepo: lr,
+		copyRepo:     cr,
+		bookRepo:     br,
+	}
+}
+
+func (uc *auditUseCase) StartAudit(locationID, notes string) (*domain.AuditSession, error) {
+	if _, err := uc.locationRepo.GetByID(locationID); err != nil {
+		return nil, err
+	}
+	session := &domain.AuditSession{
+		LocationID: locationID,
+		Status:     domain.AuditOpen,
+		Notes:      strings.TrimSpace(notes),
+		StartedAt:  time.Now(),
+	}
+	err := uc.auditRepo.Create(session)
+	if errors.Is(err, repository.ErrAuditInProgress) {
+		open, findErr := uc.auditRepo.FindOpen(locationID)
+		if findErr != nil {
+			return nil, err
+		}
+		return open, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (uc *auditUseCase) GetAudit(id string) (*domain.AuditSession, error) {
+	session, err := uc.auditRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status == domain.AuditOpen {
+		if session.Report, err = uc.report(session); err != nil {
+			return nil, err
+		}
+	}
+	return session, nil
+}
+
+func (uc *auditUseCase) GetAudits(locationID string) ([]*domain.AuditSession, error) {
+	return uc.auditRepo.GetAll(locationID)
+}
+
+func (uc *auditUseCase) Scan(id string, codes []string) ([]domain.AuditScan, error) {
+	session, err := uc.auditRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != domain.AuditOpen {
+		return nil, repository.ErrAuditClosed
+	}
+	audited, err := uc.auditedLocations(session.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	scanned := make(map[string]bool)
+	for _, s := range session.Scans {
+		if s.CopyID != "" {
+			scanned[s.CopyID] = true
+		}
+	}
+
+	var scans []domain.AuditScan
+	now := time.Now()
+	for _, code := range codes {
+		if code = strings.TrimSpace(code); code == "" {
+			continue
+		}
+		scan, err := uc.scan(code, audited, scanned)
+		if err != nil {
+			return nil, err
+		}
+		scan.ScannedAt = now
+		if scan.CopyID != "" {
+			scanned[scan.CopyID] = true
+		}
+		scans = append(scans, scan)
+	}
+	if len(scans) == 0 {
+		return nil, ErrInvalidScan
+	}
+
+	if err := uc.auditRepo.AddScans(id, scans); err != nil {
+		return nil, err
+	}
+	return scans, nil
+}
+
+// scan matches a code with a copy. A copy ID names the copy itself; an ISBN
+// or a book ID stands for any copy of the edition not scanned yet, preferring
+// one the catalogue places in the audited location.
+func (uc *auditUseCase) scan(code string, audited, scanned map[string]bool) (domain.AuditScan, error) {
+	scan := domain.AuditScan{Code: code}
+	c, err := uc.copyRepo.GetByID(code)
+	switch {
+	case err == nil:
+		scan.BookID, scan.CopyID = c.BookID, c.ID
+		switch {
+		case scanned[c.ID]:
+			scan.Result = domain.ScanDuplicate
+		case c.Location != nil && audited[c.Location.LocationID]:
+			scan.Result = domain.ScanFound
+		default:
+			scan.Result = domain.ScanMisplaced
+		}
+		return scan, nil
+	case !errors.Is(err, repository.ErrCopyNotFound):
+		return scan, err
+	}
+
+	book, err := uc.findBook(code)
+	if errors.Is(err, repository.ErrBookNotFound) {
+		scan.Result = domain.ScanUnknown
+		return scan, nil
+	}
+	if err != nil {
+		return scan, err
+	}
+	scan.BookID = book.ID
+	copies, err := uc.copyRepo.GetByBookIDs([]string{book.ID})
+	if err != nil {
+		return scan, err
+	}
+
+	var elsewhere *domain.Copy
+	for _, c := range copies {
+		switch {
+		case scanned[c.ID]:
+		case c.Location != nil && audited[c.Location.LocationID]:
+			scan.CopyID, scan.Result = c.ID, domain.ScanFound
+			return scan, nil
+		case elsewhere == nil:
+			elsewhere = c
+		}
+	}
+	switch {
+	case elsewhere != nil:
+		scan.CopyID, scan.Result = elsewhere.ID, domain.ScanMisplaced
+	case len(copies) > 0:
+		scan.Result = domain.ScanDuplicate
+	default:
+		scan.Result = domain.ScanMisplaced
+	}
+	return scan, nil
+}
+
+// findBook looks a scanned code up as an ISBN and then as a book ID.
+func (uc *auditUseCase) findBook(code string) (*domain.Book, error) {
+	if _, isbn13, err := isbn.Parse(code); err == nil {
+		return uc.bookRepo.GetByISBN(isbn13)
+	}
+	return uc.bookRepo.GetByID(code)
+}
+
+func (uc *auditUseCase) CloseAudit(id string) (*domain.AuditSession, error) {
+	session, err := uc.auditRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != domain.AuditOpen {
+		return nil, repository.ErrAuditClosed
+	}
+	report, err := uc.report(session)
+	if err != nil {
+		return nil, err
+	}
+	closedAt := time.Now()
+	if err := uc.auditRepo.Close(id, report, closedAt); err != nil {
+		return nil, err
+	}
+
+	session.Status, session.Report, session.ClosedAt = domain.AuditClosed, report, &closedAt
+	return session, nil
+}
+
+// auditedLocations returns the IDs of the audited location and of every
+// location inside it.
+func (uc *auditUseCase) auditedLocations(locationID string) (map[string]bool, error) {
+	descendants, err := uc.locationRepo.GetDescendants(locationID)
+	if err != nil {
+		return nil, err
+	}
+	audited := map[string]bool{locationID: true}
+	for _, d := range descendants {
+		audited[d.ID] = true
+	}
+	return audited, nil
+}
+
+// report compares the scanned copies with the copies the catalogue places
+// in the audited location as it stands now, so copies moved during the audit
+// are judged by their current location.
+func (uc *auditUseCase) report(session *domain.AuditSession) (*domain.AuditReport, error) {
+	audited, err := uc.auditedLocations(session.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(audited))
+	for id := range audited {
+		ids = append(ids, id)
+	}
+	expected, err := uc.copyRepo.FindByLocations(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.AuditReport{
+		Found:     []domain.AuditItem{},
+		Missing:   []domain.AuditItem{},
+		Misplaced: []domain.AuditItem{},
+		Unknown:   []string{},
+	}
+	expectedIDs := make(map[string]bool, len(expected))
+	for _, c := range expected {
+		expectedIDs[c.ID] = true
+	}
+	scanned := make(map[string]bool)
+	seenCodes := make(map[string]bool)
+	var misplaced []*domain.Copy
+	// Livros sem exemplares lidos no local entram como fora do lugar.
+	var uncopied []string
+	for _, s := range session.Scans {
+		switch {
+		case s.BookID == "" && !seenCodes[s.Code]:
+			seenCodes[s.Code] = true
+			report.Unknown = append(report.Unknown, s.Code)
+		case s.CopyID == "" && s.BookID != "" && !scanned[s.BookID]:
+			scanned[s.BookID] = true
+			uncopied = append(uncopied, s.BookID)
+		case s.CopyID != "" && !scanned[s.CopyID]:
+			scanned[s.CopyID] = true
+			if expectedIDs[s.CopyID] {
+				continue
+			}
+			// Exemplares lidos que foram excluídos do catálogo depois não entram no relatório.
+			c, err := uc.copyRepo.GetByID(s.CopyID)
+			if errors.Is(err, repository.ErrCopyNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			misplaced = append(misplaced, c)
+		}
+	}
+
+	items, err := copyWhereabouts(uc.locationRepo, uc.bookRepo, append(expected, misplaced...))
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range items {
+		item := domain.AuditItem{CopyID: w.Copy.ID, BookID: w.Book.ID, Title: w.Book.Title, Author: w.Book.Author, Location: w.Label}
+		switch {
+		case !expectedIDs[w.Copy.ID]:
+			report.Misplaced = append(report.Misplaced, item)
+		case scanned[w.Copy.ID]:
+			report.Found = append(report.Found, item)
+		default:
+			report.Missing = append(report.Missing, item)
+		}
+	}
+	books, err := uc.bookRepo.GetByIDs(uncopied)
+	if err != nil {
+		return nil, err
+	}
+	for _, book := range books {
+		report.Misplaced = append(report.Misplaced, domain.AuditItem{BookID: book.ID, Title: book.Title, Author: book.Author})
+	}
+	for _, items := range [][]domain.AuditItem{report.Found, report.Missing, report.Misplaced} {
+		sortAuditItems(items)
+	}
+	return report, nil
+}
+
+// sortAuditItems orders the items of a report by location and title, the
+// order in which they are looked for on the shelves.
+func sortAuditItems(items []domain.AuditItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Location != items[j].Location {
+			return items[i].Location < items[j].Location
+		}
+		return items[i].Title < items[j].Title
+	})
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+)
+
+func TestStartAudit(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		open     *domain.AuditSession
+		wantErr  error
+		wantID   string
+	}{
+		{name: "new session", location: "l1"},
+		{
+			name:     "session already open",
+			location: "l1",
+			open:     &domain.AuditSession{ID: "a1", LocationID: "l1", Status: domain.AuditOpen},
+			wantErr:  repository.ErrAuditInProgress,
+			wantID:   "a1",
+		},
+		{
+			name:     "only another location is open",
+			location: "l1",
+			open:     &domain.AuditSession{ID: "a2", LocationID: "l2", Status: domain.AuditOpen},
+		},
+		{name: "unknown location", location: "l9", wantErr: repository.ErrLocationNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audits := &fakeAuditRepo{}
+			if tt.open != nil {
+				audits.sessions = append(audits.sessions, tt.open)
+			}
+			locations := &fakeLocationRepo{locations: []*domain.Location{{ID: "l1"}, {ID: "l2"}}}
+			uc := NewAuditUseCase(audits, locations, &fakeCopyRepo{}, newFakeBookRepo())
+
+			session, err := uc.StartAudit(tt.location, " inventário ")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("StartAudit error = %v, want %v", err, tt.wantErr)
+			}
+			switch {
+			case tt.wantID != "":
+				if session == nil || session.ID != tt.wantID {
+					t.Errorf("StartAudit returned %+v, want the open session %s", session, tt.wantID)
+				}
+			case tt.wantErr == nil:
+				if session.ID == "" || session.Status != domain.AuditOpen || session.Notes != "inventário" {
+					t.Errorf("StartAudit returned %+v, want a new open session", session)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+var (
+	ErrMergeIntoSelf = errors.New("an author cannot be merged into itself")
+)
+
+type AuthorUseCase interface {
+	CreateAuthor(author *domain.Author) error
+	GetAuthorByID(id string) (*domain.Author, error)
+	SearchAuthors(name string) ([]*domain.Author, error)
+	UpdateAuthor(author *domain.Author) error
+	DeleteAuthor(id string) error
+	GetAuthorBooks(id string) ([]*domain.Book, error)
+	// MergeAuthors folds the authors in sourceIDs into targetID. Their names
+	// become alternate spellings of the target, their books are relinked to
+	// it and they are deleted.
+	MergeAuthors(targetID string, sourceIDs []string) (*domain.Author, error)
+}
+
+type authorUseCase struct {
+	authorRepo repository.AuthorRepository
+	bookRepo   repository.BookRepository
+}
+
+func NewAuthorUseCase(ar repository.AuthorRepository, br repository.BookRepository) AuthorUseCase {
+	return &authorUseCase{
+		authorRepo: ar,
+		bookRepo:   br,
+	}
+}
+
+// CreateAuthor saves the author and links the books crediting any of its names.
+func (uc *authorUseCase) CreateAuthor(author *domain.Author) error {
+	if err := validator.ValidateAuthor(author); err != nil {
+		return err
+	}
+	if err := uc.authorRepo.Create(author); err != nil {
+		return err
+	}
+	return uc.bookRepo.LinkAuthor(author.ID, author.Names())
+}
+
+func (uc *authorUseCase) GetAuthorByID(id string) (*domain.Author, error) {
+	return uc.authorRepo.GetByID(id)
+}
+
+func (uc *authorUseCase) SearchAuthors(name string) ([]*domain.Author, error) {
+	return uc.authorRepo.Search(name)
+}
+
+// UpdateAuthor replaces the author. Books crediting a newly added spelling
+// or alias are linked to it; books already linked stay linked.
+func (uc *authorUseCase) UpdateAuthor(author *domain.Author) error {
+	if err := validator.ValidateAuthor(author); err != nil {
+		return err
+	}
+	if err := uc.authorRepo.Update(author); err != nil {
+		return err
+	}
+	return uc.bookRepo.LinkAuthor(author.ID, author.Names())
+}
+
+// DeleteAuthor removes the author and unlinks its books, which keep the
+// contributor names.
+func (uc *authorUseCase) DeleteAuthor(id string) error {
+	if err := uc.authorRepo.Delete(id); err != nil {
+		return err
+	}
+	return uc.bookRepo.RelinkAuthor(id, "")
+}
+
+func (uc *authorUseCase) GetAuthorBooks(id string) ([]*domain.Book, error) {
+	if _, err := uc.authorRepo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return uc.bookRepo.Search(domain.BookFilter{AuthorID: id})
+}
+
+func (uc *authorUseCase) MergeAuthors(targetID string, sourceIDs []string) (*domain.Author, error) {
+	target, err := uc.authorRepo.GetByID(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []*domain.Author
+	seen := make(map[string]bool)
+	for _, id := range sourceIDs {
+		if id == targetID {
+			return nil, ErrMergeIntoSelf
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		source, err := uc.authorRepo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+
+	for _, source := range sources {
+		target.AlternateSpellings = append(target.AlternateSpellings, source.Name)
+		target.AlternateSpellings = append(target.AlternateSpellings, source.AlternateSpellings...)
+		target.Aliases = append(target.Aliases, source.Aliases...)
+		if target.BirthYear == 0 {
+			target.BirthYear = source.BirthYear
+		}
+		if target.DeathYear == 0 {
+			target.DeathYear = source.DeathYear
+		}
+		if target.Nationality == "" {
+			target.Nationality = source.Nationality
+		}
+	}
+	if err := validator.ValidateAuthor(target); err != nil {
+		return nil, err
+	}
+	if err := uc.authorRepo.Update(target); err != nil {
+		return nil, err
+	}
+
+	for _, source := range sources {
+		if err := uc.bookRepo.RelinkAuthor(source.ID, target.ID); err != nil {
+			return nil, err
+		}
+		if err := uc.authorRepo.Delete(source.ID); err != nil {
+			return nil, err
+		}
+	}
+	return target, uc.bookRepo.LinkAuthor(target.ID, target.Names())
+}
+
+// linkContributors links the contributors of a book to the author records
+// known by their names. Contributors already carrying an author ID must
+// point to an existing author.
+func linkContributors(authors repository.AuthorRepository, book *domain.Book) error {
+	for i := range book.Contributors {
+		c := &book.Contributors[i]
+		if c.AuthorID != "" {
+			if _, err := authors.GetByID(c.AuthorID); errors.Is(err, repository.ErrAuthorNotFound) {
+				return fmt.Errorf("%w: author %s not found", validator.ErrInvalidBookData, c.AuthorID)
+			} else if err != nil {
+				return err
+			}
+			continue
+		}
+
+		author, err := authors.FindByName(c.Name)
+		if errors.Is(err, repository.ErrAuthorNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		c.AuthorID = author.ID
+	}
+	return nil
+}
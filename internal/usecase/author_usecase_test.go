@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+func credited(id string, contributors ...domain.Contributor) *domain.Book {
+	return &domain.Book{ID: id, Title: id, Contributors: contributors}
+}
+
+func TestCreateAuthorLinksBooks(t *testing.T) {
+	books := newFakeBookRepo(
+		credited("b1", domain.Contributor{Name: "J. R. R. Tolkien", Role: domain.RoleAuthor}),
+		credited("b2", domain.Contributor{Name: "tolkien, j.r.r.", Role: domain.RoleAuthor}),
+		// Já vinculado a outro autor, não muda.
+		credited("b3", domain.Contributor{Name: "J. R. R. Tolkien", Role: domain.RoleAuthor, AuthorID: "other"}),
+		credited("b4", domain.Contributor{Name: "Christopher Tolkien", Role: domain.RoleEditor}),
+	)
+	uc := NewAuthorUseCase(&fakeAuthorRepo{}, books)
+
+	author := &domain.Author{Name: "  J. R. R.   Tolkien ", AlternateSpellings: []string{"Tolkien, J.R.R.", "j. r. r. tolkien"}}
+	if err := uc.CreateAuthor(author); err != nil {
+		t.Fatalf("CreateAuthor: %v", err)
+	}
+	if author.Name != "J. R. R. Tolkien" || len(author.AlternateSpellings) != 1 {
+		t.Errorf("author = %q %q, want the name tidied and the repeated spelling dropped", author.Name, author.AlternateSpellings)
+	}
+
+	want := map[string]string{"b1": author.ID, "b2": author.ID, "b3": "other", "b4": ""}
+	for id, authorID := range want {
+		if got := books.books[id].Contributors[0].AuthorID; got != authorID {
+			t.Errorf("book %s linked to %q, want %q", id, got, authorID)
+		}
+	}
+
+	if err := uc.CreateAuthor(&domain.Author{Name: " "}); !errors.Is(err, validator.ErrInvalidAuthorData) {
+		t.Errorf("CreateAuthor without name error = %v, want %v", err, validator.ErrInvalidAuthorData)
+	}
+}
+
+func TestMergeAuthors(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []string
+		wantErr error
+		// wantNames are the names the target is known by after the merge.
+		wantNames string
+		wantLeft  int
+	}{
+		{
+			name:      "merges spellings, aliases and missing details",
+			sources:   []string{"a2", "a3", "a2"},
+			wantNames: "Fernando Pessoa|F. Pessoa|Fernando António Nogueira Pessoa|Alberto Caeiro|Álvaro de Campos|Ricardo Reis",
+			wantLeft:  1,
+		},
+		{name: "into itself", sources: []string{"a2", "a1"}, wantErr: ErrMergeIntoSelf, wantLeft: 3},
+		{name: "unknown source", sources: []string{"a9"}, wantErr: repository.ErrAuthorNotFound, wantLeft: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authors := &fakeAuthorRepo{authors: []*domain.Author{
+				{ID: "a1", Name: "Fernando Pessoa", Aliases: []string{"Alberto Caeiro"}},
+				{ID: "a2", Name: "F. Pessoa", BirthYear: 1888, Nationality: "Portuguese", Aliases: []string{"Álvaro de Campos", "alberto caeiro"}},
+				{ID: "a3", Name: "Fernando António Nogueira Pessoa", BirthYear: 1900, DeathYear: 1935, Aliases: []string{"Ricardo Reis"}},
+			}}
+			books := newFakeBookRepo(
+				credited("b1", domain.Contributor{Name: "F. Pessoa", Role: domain.RoleAuthor, AuthorID: "a2"}),
+				credited("b2", domain.Contributor{Name: "Ricardo Reis", Role: domain.RoleAuthor, AuthorID: "a3"}),
+				// Grafia da origem ainda sem vínculo.
+				credited("b3", domain.Contributor{Name: "f. pessoa", Role: domain.RoleAuthor}),
+			)
+			uc := NewAuthorUseCase(authors, books)
+
+			target, err := uc.MergeAuthors("a1", tt.sources)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MergeAuthors error = %v, want %v", err, tt.wantErr)
+			}
+			if len(authors.authors) != tt.wantLeft {
+				t.Errorf("%d authors left, want %d", len(authors.authors), tt.wantLeft)
+			}
+			if tt.wantErr != nil {
+				if books.books["b1"].Contributors[0].AuthorID != "a2" {
+					t.Error("a failed merge relinked books")
+				}
+				return
+			}
+
+			if got := strings.Join(target.Names(), "|"); got != tt.wantNames {
+				t.Errorf("names = %s\nwant %s", got, tt.wantNames)
+			}
+			// Os dados que faltam vêm da primeira origem que os tiver.
+			if target.BirthYear != 1888 || target.DeathYear != 1935 || target.Nationality != "Portuguese" {
+				t.Errorf("details = %d-%d %s, want 1888-1935 Portuguese", target.BirthYear, target.DeathYear, target.Nationality)
+			}
+			for id, book := range books.books {
+				if got := book.Contributors[0].AuthorID; got != "a1" {
+					t.Errorf("book %s linked to %q, want a1", id, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteAuthorUnlinksBooks(t *testing.T) {
+	authors := &fakeAuthorRepo{authors: []*domain.Author{{ID: "a1", Name: "Clarice Lispector"}}}
+	books := newFakeBookRepo(credited("b1", domain.Contributor{Name: "Clarice Lispector", Role: domain.RoleAuthor, AuthorID: "a1"}))
+	uc := NewAuthorUseCase(authors, books)
+
+	if err := uc.DeleteAuthor("a1"); err != nil {
+		t.Fatalf("DeleteAuthor: %v", err)
+	}
+	if c := books.books["b1"].Contributors[0]; c.AuthorID != "" || c.Name != "Clarice Lispector" {
+		t.Errorf("contributor = %+v, want the name kept and the link removed", c)
+	}
+	if err := uc.DeleteAuthor("a1"); !errors.Is(err, repository.ErrAuthorNotFound) {
+		t.Errorf("DeleteAuthor twice error = %v, want %v", err, repository.ErrAuthorNotFound)
+	}
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/epub"
+	"github.com/rfulgencio3/go-personal-library/internal/metadata"
+	"github.com/rfulgencio3/go-personal-library/internal/pdf"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+)
+
+var (
+	ErrUnsupportedFile = errors.New("unsupported file format")
+)
+
+type BookFileUseCase interface {
+	// CreateBookFromFile creates a book from the metadata embedded in an
+	// EPUB or PDF file. Fields already filled in draft take precedence over
+	// the file's metadata. The file is kept as an attachment of the new
+	// book, and an embedded cover, if any, becomes the book cover.
+	CreateBookFromFile(name string, data []byte, draft *domain.Book) (*domain.Book, error)
+}
+
+type bookFileUseCase struct {
+	bookRepo          repository.BookRepository
+	bookUseCase       BookUseCase
+	coverUseCase      CoverUseCase
+	attachmentUseCase AttachmentUseCase
+}
+
+func NewBookFileUseCase(br repository.BookRepository, bu BookUseCase, cu CoverUseCase, au AttachmentUseCase) BookFileUseCase {
+	return &bookFileUseCase{
+		bookRepo:          br,
+		bookUseCase:       bu,
+		coverUseCase:      cu,
+		attachmentUseCase: au,
+	}
+}
+
+// bookFile is the metadata read from an uploaded file.
+type bookFile struct {
+	book        *domain.Book
+	cover       []byte
+	contentType string
+	// details are kept in the attachment metadata.
+	details map[string]string
+}
+
+func (uc *bookFileUseCase) CreateBookFromFile(name string, data []byte, draft *domain.Book) (*domain.Book, error) {
+	var file *bookFile
+	var err error
+	switch {
+	case epub.IsEPUB(data):
+		file, err = readEPUB(data)
+	case pdf.IsPDF(data):
+		file, err = readPDF(data)
+	default:
+		return nil, ErrUnsupportedFile
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	book := draft
+	if book == nil {
+		book = &domain.Book{}
+	}
+	book.ID = ""
+	metadata.Merge(book, file.book)
+	if book.Title == "" {
+		// Sem título nos metadados, o nome do arquivo é o melhor palpite.
+		book.Title = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	if err := uc.bookUseCase.CreateBook(book); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.attachmentUseCase.AddAttachment(book.ID, name, file.contentType, data, file.details); err != nil {
+		// Sem o arquivo original o cadastro fica incompleto; desfaz a criação do
+		// livro junto com o exemplar criado com ele.
+		if delErr := uc.bookUseCase.DeleteBook(book.ID); delErr != nil {
+			log.Printf("Erro ao remover o livro %s após falha no anexo: %v", book.ID, delErr)
+		}
+		return nil, err
+	}
+
+	if file.cover != nil {
+		if _, err := uc.coverUseCase.SetCover(book.ID, file.cover); err != nil {
+			// Uma capa inválida não impede o cadastro do livro.
+			log.Printf("Erro ao salvar a capa do livro %s: %v", book.ID, err)
+		}
+	}
+
+	return uc.bookRepo.GetByID(book.ID)
+}
+
+func readEPUB(data []byte) (*bookFile, error) {
+	f, err := epub.Open(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	file := &bookFile{book: f.Book(), contentType: epub.MediaType}
+	if cover, _, err := f.Cover(); err == nil {
+		file.cover = cover
+	}
+	return file, nil
+}
+
+// readPDF maps the PDF metadata to a book. Keywords become tags; the
+// producing applications are technical details kept with the attachment.
+func readPDF(data []byte) (*bookFile, error) {
+	meta, err := pdf.Read(data)
+	if err != nil {
+		return nil, err
+	}
+	book := &domain.Book{
+		Title:       meta.Title,
+		Author:      domain.JoinAuthorNames(meta.Authors),
+		Pages:       meta.Pages,
+		Description: meta.Subject,
+	}
+	for _, keyword := range strings.FieldsFunc(meta.Keywords, func(r rune) bool { return r == ',' || r == ';' }) {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			book.Tags = append(book.Tags, keyword)
+		}
+	}
+
+	details := make(map[string]string)
+	for key, value := range map[string]string{
+		"pdf_version": meta.Version,
+		"creator":     meta.Creator,
+		"producer":    meta.Producer,
+	} {
+		if value != "" {
+			details[key] = value
+		}
+	}
+	if meta.Encrypted {
+		details["encrypted"] = "true"
+	}
+	return &bookFile{book: book, contentType: pdf.MediaType, details: details}, nil
+}
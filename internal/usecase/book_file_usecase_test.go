@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/storage"
+)
+
+func TestCreateBookFromFile(t *testing.T) {
+	pdfData, err := os.ReadFile("../pdf/testdata/classic.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		data      []byte
+		failStore bool
+		wantErr   error
+		wantBooks int
+	}{
+		{name: "PDF", data: pdfData, wantBooks: 1},
+		// Sem o anexo, o livro e o exemplar criados com ele são desfeitos.
+		{name: "attachment fails", data: pdfData, failStore: true, wantErr: errDiskFull},
+		{name: "not a book file", data: []byte("plain text"), wantErr: ErrUnsupportedFile},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			books := newFakeBookRepo()
+			bookUC, copies := newTestBookUseCase(books, nil)
+			var store storage.BlobStore = newTestStore(t)
+			if tt.failStore {
+				store = &failingStore{BlobStore: store}
+			}
+			uc := NewBookFileUseCase(books, bookUC, NewCoverUseCase(books, store), NewAttachmentUseCase(books, store))
+
+			book, err := uc.CreateBookFromFile("dom-casmurro.pdf", tt.data, &domain.Book{Pages: 256})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateBookFromFile error = %v, want %v", err, tt.wantErr)
+			}
+			if len(books.books) != tt.wantBooks || len(copies.copies) != tt.wantBooks {
+				t.Errorf("%d books and %d copies left, want %d of each", len(books.books), len(copies.copies), tt.wantBooks)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if book.Title != "Dom Casmurro" || book.Author != "Machado de Assis" || len(book.Attachments) != 1 {
+				t.Errorf("book = %+v, want Dom Casmurro with its PDF attached", book)
+			}
+		})
+	}
+}
@@ -1,51 +1,303 @@
 package usecase
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/isbn"
+	"github.com/rfulgencio3/go-personal-library/internal/metadata"
 	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/storage"
 	"github.com/rfulgencio3/go-personal-library/internal/validator"
 )
 
+var (
+	ErrMetadataUnavailable = errors.New("metadata lookup is not configured")
+	ErrBookLent            = errors.New("the book has copies on loan")
+	ErrBookDisposed        = errors.New("the book is no longer in the library")
+)
+
 type BookUseCase interface {
 	CreateBook(book *domain.Book) error
+	CreateBookFromISBN(code string, draft *domain.Book) error
 	GetBookByID(id string) (*domain.Book, error)
+	GetBookByISBN(code string) (*domain.Book, error)
 	UpdateBook(book *domain.Book) error
 	DeleteBook(id string) error
 	GetAllBooks() ([]*domain.Book, error)
+	// SearchBooks returns the books matching the filter; unless it asks for
+	// a status, only the books still in the library.
+	SearchBooks(filter domain.BookFilter) ([]*domain.Book, error)
+	// DisposeBook records that a book left the library as sold, donated,
+	// lost or discarded. Its reading records are kept.
+	DisposeBook(id, status string, disposal *domain.Disposal) (*domain.Book, error)
+	// RestoreBook brings a book that left back into the library.
+	RestoreBook(id string) (*domain.Book, error)
 }
 
 type bookUseCase struct {
-	bookRepo repository.BookRepository
+	bookRepo   repository.BookRepository
+	authorRepo repository.AuthorRepository
+	seriesRepo repository.SeriesRepository
+	tagRepo    repository.TagRepository
+	workRepo   repository.WorkRepository
+	copyRepo   repository.CopyRepository
+	loanRepo   repository.LoanRepository
+	shelfRepo  repository.ShelfRepository
+	store      storage.BlobStore
+	metadata   metadata.Provider
 }
 
-func NewBookUseCase(br repository.BookRepository) BookUseCase {
+// NewBookUseCase creates the book use case. The metadata provider may be nil,
+// in which case books cannot be created from an ISBN.
+func NewBookUseCase(br repository.BookRepository, ar repository.AuthorRepository, sr repository.SeriesRepository, tr repository.TagRepository, wr repository.WorkRepository, cr repository.CopyRepository, lr repository.LoanRepository, shr repository.ShelfRepository, store storage.BlobStore, mp metadata.Provider) BookUseCase {
 	return &bookUseCase{
-		bookRepo: br,
+		bookRepo:   br,
+		authorRepo: ar,
+		seriesRepo: sr,
+		tagRepo:    tr,
+		workRepo:   wr,
+		copyRepo:   cr,
+		loanRepo:   lr,
+		shelfRepo:  shr,
+		store:      store,
+		metadata:   mp,
 	}
 }
 
+// CreateBook saves an edition, linked to its work, along with a first copy.
 func (uc *bookUseCase) CreateBook(book *domain.Book) error {
 	if err := validator.ValidateBook(book); err != nil {
 		return err
 	}
-	return uc.bookRepo.Create(book)
+	if err := linkContributors(uc.authorRepo, book); err != nil {
+		return err
+	}
+	if err := linkSeries(uc.seriesRepo, book); err != nil {
+		return err
+	}
+	if err := uc.tagRepo.EnsurePaths(book.Tags); err != nil {
+		return err
+	}
+	if err := linkWork(uc.workRepo, book); err != nil {
+		return err
+	}
+	// Capa e anexos só são definidos pelos uploads, nunca pelo corpo da requisição.
+	book.Cover = nil
+	book.Attachments = nil
+	book.Status, book.Disposal = domain.BookOwned, nil
+	return createWithCopy(uc.bookRepo, uc.copyRepo, book)
+}
+
+// createWithCopy saves a book along with its first copy. When the copy
+// cannot be saved the book is deleted again, so no edition is left without
+// copies.
+func createWithCopy(bookRepo repository.BookRepository, copyRepo repository.CopyRepository, book *domain.Book) error {
+	if err := bookRepo.Create(book); err != nil {
+		return err
+	}
+	if err := copyRepo.Create(&domain.Copy{BookID: book.ID, CreatedAt: time.Now()}); err != nil {
+		if deleteErr := bookRepo.Delete(book.ID); deleteErr != nil {
+			return errors.Join(err, deleteErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// CreateBookFromISBN looks the ISBN up in the metadata provider and creates
+// the book. Fields already filled in draft take precedence over the
+// provider's data.
+func (uc *bookUseCase) CreateBookFromISBN(code string, draft *domain.Book) error {
+	if uc.metadata == nil {
+		return ErrMetadataUnavailable
+	}
+	isbn10, isbn13, err := isbn.Parse(code)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.bookRepo.GetByISBN(isbn13); err == nil {
+		return repository.ErrDuplicateISBN
+	} else if !errors.Is(err, repository.ErrBookNotFound) {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	found, err := uc.metadata.LookupISBN(ctx, isbn13)
+	if err != nil {
+		return err
+	}
+
+	draft.ID = ""
+	draft.ISBN10, draft.ISBN13 = isbn10, isbn13
+	metadata.Merge(draft, found)
+	return uc.CreateBook(draft)
 }
 
+// GetBookByID returns a book with its availability, so a book whose copies
+// are all lent shows as unavailable. Books that left the library have none.
 func (uc *bookUseCase) GetBookByID(id string) (*domain.Book, error) {
-	return uc.bookRepo.GetByID(id)
+	book, err := uc.bookRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !book.InLibrary() {
+		return book, nil
+	}
+	if book.Availability, err = availability(uc.loanRepo, uc.copyRepo, book.ID); err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+// GetBookByISBN accepts either an ISBN-10 or an ISBN-13, with or without hyphens.
+func (uc *bookUseCase) GetBookByISBN(code string) (*domain.Book, error) {
+	_, isbn13, err := isbn.Parse(code)
+	if err != nil {
+		return nil, err
+	}
+	return uc.bookRepo.GetByISBN(isbn13)
 }
 
+// UpdateBook replaces a book. When the request only carries the author
+// field, the other contributors already credited, such as translators, are
+// kept, and without a work ID the edition stays linked to its work.
 func (uc *bookUseCase) UpdateBook(book *domain.Book) error {
+	existing, err := uc.bookRepo.GetByID(book.ID)
+	if err != nil {
+		return err
+	}
+	if len(book.Contributors) == 0 {
+		book.Contributors = existing.Contributors
+		if book.Author != existing.Author {
+			book.Contributors = domain.ReplaceAuthors(existing.Contributors, book.Author)
+		}
+	}
+	if book.WorkID == "" {
+		book.WorkID = existing.WorkID
+	}
+	book.Status, book.Disposal = existing.Status, existing.Disposal
 	if err := validator.ValidateBook(book); err != nil {
 		return err
 	}
+	if err := linkContributors(uc.authorRepo, book); err != nil {
+		return err
+	}
+	if err := linkSeries(uc.seriesRepo, book); err != nil {
+		return err
+	}
+	if err := uc.tagRepo.EnsurePaths(book.Tags); err != nil {
+		return err
+	}
+	if err := linkWork(uc.workRepo, book); err != nil {
+		return err
+	}
 	return uc.bookRepo.Update(book)
 }
 
+// DeleteBook removes an edition with its copies, loan history and cover
+// images and takes it off every shelf. Books with copies on loan are
+// refused, like in DisposeBook. The dependents go first and the book last,
+// so a failed deletion can be retried. The work is kept, along with its
+// reading records.
 func (uc *bookUseCase) DeleteBook(id string) error {
+	book, err := uc.bookRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	loans, err := uc.loanRepo.GetActiveByBookID(id)
+	if err != nil {
+		return err
+	}
+	if len(loans) > 0 {
+		return ErrBookLent
+	}
+
+	if err := uc.loanRepo.DeleteByBookID(id); err != nil {
+		return err
+	}
+	if err := uc.shelfRepo.RemoveBook(id); err != nil {
+		return err
+	}
+	if err := uc.copyRepo.DeleteByBookID(id); err != nil {
+		return err
+	}
+	if book.Cover != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := deleteCoverBlobs(ctx, uc.store, id, book.Cover); err != nil {
+			return err
+		}
+	}
 	return uc.bookRepo.Delete(id)
 }
 
+// GetAllBooks returns the books still in the library, like SearchBooks
+// without a filter.
 func (uc *bookUseCase) GetAllBooks() ([]*domain.Book, error) {
-	return uc.bookRepo.GetAll()
+	return uc.SearchBooks(domain.BookFilter{})
+}
+
+func (uc *bookUseCase) SearchBooks(filter domain.BookFilter) ([]*domain.Book, error) {
+	return uc.bookRepo.Search(filter)
+}
+
+// DisposeBook refuses books with copies on loan, which must come back
+// first. The copies are taken off their locations, so audits no longer
+// expect them, and remember them as their last location.
+func (uc *bookUseCase) DisposeBook(id, status string, disposal *domain.Disposal) (*domain.Book, error) {
+	if disposal.Date.IsZero() {
+		disposal.Date = time.Now()
+	}
+	if err := validator.ValidateDisposal(status, disposal); err != nil {
+		return nil, err
+	}
+	if _, err := uc.bookRepo.GetByID(id); err != nil {
+		return nil, err
+	}
+	loans, err := uc.loanRepo.GetActiveByBookID(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(loans) > 0 {
+		return nil, ErrBookLent
+	}
+
+	copies, err := uc.copyRepo.GetByBookIDs([]string{id})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range copies {
+		if c.Location == nil {
+			continue
+		}
+		if err := uc.copyRepo.Unshelve(c.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.bookRepo.SetStatus(id, status, disposal); err != nil {
+		return nil, err
+	}
+	return uc.bookRepo.GetByID(id)
+}
+
+// RestoreBook leaves the copies without a location: the place named by their
+// last location may have been taken or removed meanwhile, so they are put
+// back on a shelf through the location endpoints.
+func (uc *bookUseCase) RestoreBook(id string) (*domain.Book, error) {
+	book, err := uc.bookRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if book.InLibrary() {
+		return book, nil
+	}
+	// Livros emprestados não podem sair da biblioteca, então ele volta como próprio.
+	if err := uc.bookRepo.SetStatus(id, domain.BookOwned, nil); err != nil {
+		return nil, err
+	}
+	return uc.GetBookByID(id)
 }
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/isbn"
+	"github.com/rfulgencio3/go-personal-library/internal/metadata"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+)
+
+func newTestBookUseCase(books *fakeBookRepo, mp metadata.Provider) (BookUseCase, *fakeCopyRepo) {
+	copies := &fakeCopyRepo{}
+	return NewBookUseCase(books, &fakeAuthorRepo{}, &fakeSeriesRepo{}, &fakeTagRepo{}, &fakeWorkRepo{}, copies, &fakeLoanRepo{}, &fakeShelfRepo{}, nil, mp), copies
+}
+
+func TestCreateBookFromISBN(t *testing.T) {
+	fixtures := metadata.NewFixtureProvider("../../fixtures/metadata")
+	tests := []struct {
+		name      string
+		code      string
+		draft     *domain.Book
+		existing  []*domain.Book
+		provider  metadata.Provider
+		wantErr   error
+		wantTitle string
+		wantPages int
+	}{
+		{
+			name:      "ISBN-10 with hyphens",
+			code:      "85-359-0277-5",
+			draft:     &domain.Book{},
+			provider:  fixtures,
+			wantTitle: "Dom Casmurro",
+			wantPages: 256,
+		},
+		{
+			name:      "draft fields win",
+			code:      "9788535902778",
+			draft:     &domain.Book{ID: "ignored", Pages: 208, Comments: "Edição de bolso"},
+			provider:  fixtures,
+			wantTitle: "Dom Casmurro",
+			wantPages: 208,
+		},
+		{
+			name:     "invalid check digit",
+			code:     "9788535902779",
+			draft:    &domain.Book{},
+			provider: fixtures,
+			wantErr:  isbn.ErrInvalidISBN,
+		},
+		{
+			name:     "already in the library",
+			code:     "9788535902778",
+			draft:    &domain.Book{},
+			existing: []*domain.Book{{ID: "b1", Title: "Dom Casmurro", ISBN13: "9788535902778"}},
+			provider: fixtures,
+			wantErr:  repository.ErrDuplicateISBN,
+		},
+		{
+			name:     "unknown to the provider",
+			code:     "9780000000002",
+			draft:    &domain.Book{},
+			provider: fixtures,
+			wantErr:  metadata.ErrNotFound,
+		},
+		{
+			name:    "no provider",
+			code:    "9788535902778",
+			draft:   &domain.Book{},
+			wantErr: ErrMetadataUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			books := newFakeBookRepo(tt.existing...)
+			uc, copies := newTestBookUseCase(books, tt.provider)
+
+			err := uc.CreateBookFromISBN(tt.code, tt.draft)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateBookFromISBN error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			saved, err := books.GetByID(tt.draft.ID)
+			if err != nil {
+				t.Fatalf("book not saved: %v", err)
+			}
+			if saved.Title != tt.wantTitle || saved.Pages != tt.wantPages {
+				t.Errorf("saved %q with %d pages, want %q with %d", saved.Title, saved.Pages, tt.wantTitle, tt.wantPages)
+			}
+			if saved.ISBN10 != "8535902775" || saved.ISBN13 != "9788535902778" || saved.WorkID == "" {
+				t.Errorf("saved book = %+v, want both ISBNs and a work", saved)
+			}
+			if len(copies.copies) != 1 {
+				t.Errorf("%d copies created, want 1", len(copies.copies))
+			}
+		})
+	}
+}
+
+func TestDisposeAndRestoreBook(t *testing.T) {
+	tests := []struct {
+		name    string
+		loans   []*domain.Loan
+		wantErr error
+	}{
+		{name: "on the shelf"},
+		{name: "copy on loan", loans: []*domain.Loan{{ID: "l1", BookID: "b1", CopyID: "c1"}}, wantErr: ErrBookLent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			books := newFakeBookRepo(&domain.Book{ID: "b1", Title: "Dom Casmurro", Status: domain.BookOwned})
+			shelf := &domain.BookLocation{LocationID: "shelf-3", Position: 14}
+			copies := &fakeCopyRepo{copies: []*domain.Copy{{ID: "c1", BookID: "b1", Location: shelf}}}
+			uc := NewBookUseCase(books, &fakeAuthorRepo{}, &fakeSeriesRepo{}, &fakeTagRepo{}, &fakeWorkRepo{}, copies, &fakeLoanRepo{loans: tt.loans}, &fakeShelfRepo{}, nil, nil)
+
+			book, err := uc.DisposeBook("b1", domain.BookDonated, &domain.Disposal{Recipient: "Biblioteca do bairro"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DisposeBook error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if copies.copies[0].Location != shelf {
+					t.Errorf("copy moved off its shelf although the book stayed")
+				}
+				return
+			}
+			if book.Status != domain.BookDonated || book.Disposal.Date.IsZero() {
+				t.Errorf("disposed book = %+v, want donated with a date", book)
+			}
+			if c := copies.copies[0]; c.Location != nil || c.LastLocation != shelf {
+				t.Errorf("copy location = %+v, last = %+v; want none, last %+v", c.Location, c.LastLocation, shelf)
+			}
+
+			book, err = uc.RestoreBook("b1")
+			if err != nil {
+				t.Fatalf("RestoreBook: %v", err)
+			}
+			if book.Status != domain.BookOwned || book.Disposal != nil {
+				t.Errorf("restored book = %+v, want owned", book)
+			}
+			// A volta não recoloca o exemplar; o último lugar continua registrado.
+			if c := copies.copies[0]; c.Location != nil || c.LastLocation != shelf {
+				t.Errorf("restored copy location = %+v, last = %+v", c.Location, c.LastLocation)
+			}
+		})
+	}
+}
+
+func TestCreateBookRollsBack(t *testing.T) {
+	copyErr := errors.New("connection refused")
+	books := newFakeBookRepo()
+	uc, copies := newTestBookUseCase(books, nil)
+	copies.err = copyErr
+
+	book := &domain.Book{Title: "Dom Casmurro", Author: "Machado de Assis", Pages: 256}
+	if err := uc.CreateBook(book); !errors.Is(err, copyErr) {
+		t.Fatalf("CreateBook error = %v, want %v", err, copyErr)
+	}
+	if len(books.books) != 0 {
+		t.Errorf("%d books left without a copy, want none", len(books.books))
+	}
+}
+
+func TestDeleteBook(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		loans   []*domain.Loan
+		wantErr error
+	}{
+		{name: "returned loans", id: "b1", loans: []*domain.Loan{{ID: "l1", BookID: "b1", CopyID: "c1", Status: domain.LoanReturned, ReturnedAt: &time.Time{}}}},
+		{name: "copy on loan", id: "b1", loans: []*domain.Loan{{ID: "l1", BookID: "b1", CopyID: "c1", Status: domain.LoanActive}}, wantErr: ErrBookLent},
+		{name: "missing book", id: "b9", wantErr: repository.ErrBookNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			books := newFakeBookRepo(&domain.Book{ID: "b1", Title: "Dom Casmurro"})
+			copies := &fakeCopyRepo{copies: []*domain.Copy{{ID: "c1", BookID: "b1"}}}
+			loans := &fakeLoanRepo{loans: tt.loans}
+			shelves := newFakeShelfRepo(shelfOf(map[string]float64{"b1": 1}))
+			uc := NewBookUseCase(books, &fakeAuthorRepo{}, &fakeSeriesRepo{}, &fakeTagRepo{}, &fakeWorkRepo{}, copies, loans, shelves, nil, nil)
+
+			if err := uc.DeleteBook(tt.id); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteBook error = %v, want %v", err, tt.wantErr)
+			}
+			deleted := tt.wantErr == nil
+			if _, err := books.GetByID("b1"); (err != nil) != deleted {
+				t.Errorf("book deleted = %v, want %v", err != nil, deleted)
+			}
+			if (len(copies.copies) == 0) != deleted || (len(loans.loans) == 0 && len(tt.loans) > 0) != deleted {
+				t.Errorf("%d copies and %d loans left, want them deleted only with the book", len(copies.copies), len(loans.loans))
+			}
+			if (len(shelves.shelves["s1"].Entries) == 0) != deleted {
+				t.Errorf("shelf entries = %+v, want the book taken off only when deleted", shelves.shelves["s1"].Entries)
+			}
+		})
+	}
+}
+
+func TestUpdateBookErrors(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	tests := []struct {
+		name    string
+		id      string
+		repoErr error
+		wantErr error
+	}{
+		{name: "missing book", id: "b9", wantErr: repository.ErrBookNotFound},
+		{name: "database error", id: "b1", repoErr: dbErr, wantErr: dbErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			books := newFakeBookRepo(&domain.Book{ID: "b1", Title: "Dom Casmurro", Author: "Machado de Assis", Pages: 256})
+			books.err = tt.repoErr
+			tags := &fakeTagRepo{}
+			uc := NewBookUseCase(books, &fakeAuthorRepo{}, &fakeSeriesRepo{}, tags, &fakeWorkRepo{}, &fakeCopyRepo{}, &fakeLoanRepo{}, &fakeShelfRepo{}, nil, nil)
+
+			book := &domain.Book{ID: tt.id, Title: "Dom Casmurro", Author: "Machado de Assis", Pages: 256, Tags: []string{"romance"}}
+			if err := uc.UpdateBook(book); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateBook error = %v, want %v", err, tt.wantErr)
+			}
+			// Nada é criado para um livro que não pôde ser lido.
+			if len(tags.tags) != 0 {
+				t.Errorf("%d tags created, want none", len(tags.tags))
+			}
+		})
+	}
+}
+
+func TestGetAllBooksLeavesOutDisposed(t *testing.T) {
+	books := newFakeBookRepo(
+		&domain.Book{ID: "b1", Title: "Dom Casmurro", Status: domain.BookOwned},
+		&domain.Book{ID: "b2", Title: "Quincas Borba", Status: domain.BookDonated},
+		&domain.Book{ID: "b3", Title: "Helena"},
+	)
+	uc, _ := newTestBookUseCase(books, nil)
+
+	all, err := uc.GetAllBooks()
+	if err != nil {
+		t.Fatalf("GetAllBooks: %v", err)
+	}
+	var ids []string
+	for _, book := range all {
+		ids = append(ids, book.ID)
+	}
+	sort.Strings(ids)
+	if strings.Join(ids, " ") != "b1 b3" {
+		t.Errorf("books = %v, want b1 b3", ids)
+	}
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"io"
+
+	"github.com/rfulgencio3/go-personal-library/internal/citation"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+)
+
+type CitationUseCase interface {
+	CiteBook(id string, format citation.Format, w io.Writer) error
+	CiteBooks(filter domain.BookFilter, format citation.Format, w io.Writer) error
+	FormatReference(id, style string, markup citation.Markup) (string, error)
+}
+
+type citationUseCase struct {
+	bookRepo repository.BookRepository
+}
+
+func NewCitationUseCase(br repository.BookRepository) CitationUseCase {
+	return &citationUseCase{
+		bookRepo: br,
+	}
+}
+
+func (uc *citationUseCase) CiteBook(id string, format citation.Format, w io.Writer) error {
+	book, err := uc.bookRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	return citation.Write(format, w, []*domain.Book{book})
+}
+
+func (uc *citationUseCase) CiteBooks(filter domain.BookFilter, format citation.Format, w io.Writer) error {
+	books, err := uc.bookRepo.Search(filter)
+	if err != nil {
+		return err
+	}
+	return citation.Write(format, w, books)
+}
+
+// FormatReference renders a ready-to-paste reference for the book in the given style.
+func (uc *citationUseCase) FormatReference(id, style string, markup citation.Markup) (string, error) {
+	formatter, err := citation.LookupStyle(style)
+	if err != nil {
+		return "", err
+	}
+	book, err := uc.bookRepo.GetByID(id)
+	if err != nil {
+		return "", err
+	}
+	return formatter.Format(citation.NewReference(book), markup), nil
+}
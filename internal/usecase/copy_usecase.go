@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+type CopyUseCase interface {
+	// AddCopy registers another physical copy of an edition.
+	AddCopy(bookID string, c *domain.Copy) error
+	GetCopies(bookID string) ([]*domain.Copy, error)
+	GetCopy(id string) (*domain.Copy, error)
+	// UpdateCopy changes the condition, notes and acquisition of a copy.
+	UpdateCopy(c *domain.Copy) error
+	DeleteCopy(id string) error
+}
+
+type copyUseCase struct {
+	copyRepo repository.CopyRepository
+	bookRepo repository.BookRepository
+}
+
+func NewCopyUseCase(cr repository.CopyRepository, br repository.BookRepository) CopyUseCase {
+	return &copyUseCase{
+		copyRepo: cr,
+		bookRepo: br,
+	}
+}
+
+func (uc *copyUseCase) AddCopy(bookID string, c *domain.Copy) error {
+	if _, err := uc.bookRepo.GetByID(bookID); err != nil {
+		return err
+	}
+	if err := validator.ValidateCopy(c); err != nil {
+		return err
+	}
+	// A localização só é definida pelos seus próprios endpoints.
+	c.BookID, c.Location, c.CreatedAt = bookID, nil, time.Now()
+	return uc.copyRepo.Create(c)
+}
+
+func (uc *copyUseCase) GetCopies(bookID string) ([]*domain.Copy, error) {
+	if _, err := uc.bookRepo.GetByID(bookID); err != nil {
+		return nil, err
+	}
+	copies, err := uc.copyRepo.GetByBookIDs([]string{bookID})
+	if err != nil {
+		return nil, err
+	}
+	if copies == nil {
+		copies = []*domain.Copy{}
+	}
+	return copies, nil
+}
+
+func (uc *copyUseCase) GetCopy(id string) (*domain.Copy, error) {
+	return uc.copyRepo.GetByID(id)
+}
+
+func (uc *copyUseCase) UpdateCopy(c *domain.Copy) error {
+	if err := validator.ValidateCopy(c); err != nil {
+		return err
+	}
+	if err := uc.copyRepo.Update(c); err != nil {
+		return err
+	}
+
+	updated, err := uc.copyRepo.GetByID(c.ID)
+	if err != nil {
+		return err
+	}
+	*c = *updated
+	return nil
+}
+
+func (uc *copyUseCase) DeleteCopy(id string) error {
+	return uc.copyRepo.Delete(id)
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+func TestAddCopy(t *testing.T) {
+	tests := []struct {
+		name    string
+		bookID  string
+		copy    domain.Copy
+		wantErr error
+	}{
+		{
+			name:   "with acquisition",
+			bookID: "b1",
+			copy: domain.Copy{
+				Condition:   domain.ConditionGood,
+				Notes:       "  dedicatória na folha de rosto ",
+				Acquisition: &domain.Acquisition{Method: domain.AcquiredPurchase, Store: " Sebo  do Centro ", Price: 25, Currency: "brl"},
+				// A localização é ignorada; só os endpoints de localização a definem.
+				Location: &domain.BookLocation{LocationID: "shelf"},
+			},
+		},
+		{name: "unknown book", bookID: "b9", wantErr: repository.ErrBookNotFound},
+		{name: "unknown condition", bookID: "b1", copy: domain.Copy{Condition: "mint"}, wantErr: validator.ErrInvalidCopyData},
+		{
+			name:    "price without currency",
+			bookID:  "b1",
+			copy:    domain.Copy{Acquisition: &domain.Acquisition{Method: domain.AcquiredPurchase, Price: 25}},
+			wantErr: validator.ErrInvalidCopyData,
+		},
+		{
+			name:    "unknown acquisition method",
+			bookID:  "b1",
+			copy:    domain.Copy{Acquisition: &domain.Acquisition{Method: "found"}},
+			wantErr: validator.ErrInvalidCopyData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			copies := &fakeCopyRepo{}
+			uc := NewCopyUseCase(copies, newFakeBookRepo(&domain.Book{ID: "b1"}))
+
+			c := tt.copy
+			if err := uc.AddCopy(tt.bookID, &c); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddCopy error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(copies.copies) != 0 {
+					t.Error("a refused copy was saved")
+				}
+				return
+			}
+
+			if c.BookID != "b1" || c.Location != nil || c.CreatedAt.IsZero() {
+				t.Errorf("copy = %+v, want it on b1, without location and with a creation time", c)
+			}
+			if c.Notes != "dedicatória na folha de rosto" || c.Acquisition.Store != "Sebo do Centro" || c.Acquisition.Currency != "BRL" {
+				t.Errorf("copy = %q, %+v; want the notes, store and currency tidied", c.Notes, c.Acquisition)
+			}
+		})
+	}
+}
+
+func TestGetCopies(t *testing.T) {
+	copies := &fakeCopyRepo{copies: []*domain.Copy{{ID: "c1", BookID: "b1"}, {ID: "c2", BookID: "b2"}}}
+	uc := NewCopyUseCase(copies, newFakeBookRepo(&domain.Book{ID: "b1"}, &domain.Book{ID: "b3"}))
+
+	tests := []struct {
+		bookID  string
+		want    int
+		wantErr error
+	}{
+		{bookID: "b1", want: 1},
+		{bookID: "b3", want: 0},
+		{bookID: "b2", wantErr: repository.ErrBookNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.bookID, func(t *testing.T) {
+			got, err := uc.GetCopies(tt.bookID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetCopies error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (got == nil || len(got) != tt.want) {
+				t.Errorf("GetCopies = %v, want %d copies in a non-nil list", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateCopy(t *testing.T) {
+	shelf := &domain.BookLocation{LocationID: "shelf", Position: 3}
+	copies := &fakeCopyRepo{copies: []*domain.Copy{{ID: "c1", BookID: "b1", Condition: domain.ConditionNew, Location: shelf}}}
+	uc := NewCopyUseCase(copies, newFakeBookRepo(&domain.Book{ID: "b1"}))
+
+	c := &domain.Copy{ID: "c1", Condition: domain.ConditionFair, Notes: "lombada solta"}
+	if err := uc.UpdateCopy(c); err != nil {
+		t.Fatalf("UpdateCopy: %v", err)
+	}
+	// O exemplar devolvido é o salvo, com o livro e a localização que já tinha.
+	if c.Condition != domain.ConditionFair || c.BookID != "b1" || c.Location != shelf {
+		t.Errorf("copy = %+v, want the new condition with the book and location kept", c)
+	}
+
+	if err := uc.UpdateCopy(&domain.Copy{ID: "c9"}); !errors.Is(err, repository.ErrCopyNotFound) {
+		t.Errorf("UpdateCopy(c9) error = %v, want %v", err, repository.ErrCopyNotFound)
+	}
+}
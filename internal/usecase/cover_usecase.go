@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/imaging"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/storage"
+	_ "golang.org/x/image/webp"
+)
+
+// maxImagePixels guards against images that are small on disk but huge
+// once decoded.
+const maxImagePixels = 40_000_000
+
+// coverSizes are the thumbnail widths generated for each cover.
+var coverSizes = []struct {
+	Name  string
+	Width int
+}{
+	{"small", 128},
+	{"medium", 320},
+	{"large", 640},
+}
+
+var (
+	ErrUnsupportedImage = errors.New("cover must be a JPEG, PNG or WebP image")
+	ErrInvalidCoverSize = errors.New("unknown cover size")
+	ErrCoverNotFound    = errors.New("book has no cover")
+)
+
+type CoverUseCase interface {
+	// SetCover stores the image as the cover of the book and generates its
+	// thumbnails. The previous cover is only removed once the new one is saved.
+	SetCover(bookID string, data []byte) (*domain.Cover, error)
+	// GetCover opens the cover in the requested size. Sizes without a
+	// thumbnail fall back to the original image.
+	GetCover(bookID, size string) (io.ReadCloser, *domain.Cover, error)
+	DeleteCover(bookID string) error
+	// CoverSizes lists the sizes accepted by GetCover.
+	CoverSizes() []string
+}
+
+type coverUseCase struct {
+	bookRepo repository.BookRepository
+	store    storage.BlobStore
+}
+
+func NewCoverUseCase(br repository.BookRepository, store storage.BlobStore) CoverUseCase {
+	return &coverUseCase{
+		bookRepo: br,
+		store:    store,
+	}
+}
+
+// coverKey names the blob holding a size of the cover.
+func coverKey(bookID string, cover *domain.Cover, size string) string {
+	if cover.Version == "" {
+		return "covers/" + bookID + "/" + size
+	}
+	return "covers/" + bookID + "/" + cover.Version + "/" + size
+}
+
+func (uc *coverUseCase) CoverSizes() []string {
+	sizes := []string{domain.CoverSizeOriginal}
+	for _, s := range coverSizes {
+		sizes = append(sizes, s.Name)
+	}
+	return sizes
+}
+
+func (uc *coverUseCase) SetCover(bookID string, data []byte) (*domain.Cover, error) {
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	// O tipo é detectado pelo conteúdo, ignorando o Content-Type informado pelo cliente.
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg", "image/png", "image/webp":
+	default:
+		return nil, ErrUnsupportedImage
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width == 0 || config.Height == 0 || config.Width*config.Height > maxImagePixels {
+		return nil, ErrUnsupportedImage
+	}
+
+	sum := sha256.Sum256(data)
+	cover := &domain.Cover{
+		ContentType: contentType,
+		Width:       config.Width,
+		Height:      config.Height,
+		Size:        int64(len(data)),
+		Checksum:    hex.EncodeToString(sum[:]),
+		UpdatedAt:   time.Now(),
+		Version:     uuid.New().String(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// A capa nova é gravada ao lado da antiga, que continua servida se algo falhar.
+	old := book.Cover
+	if err := uc.putBlobs(ctx, bookID, cover, data); err != nil {
+		uc.discardBlobs(ctx, bookID, cover)
+		return nil, err
+	}
+	if err := uc.bookRepo.SetCover(bookID, cover); err != nil {
+		uc.discardBlobs(ctx, bookID, cover)
+		return nil, err
+	}
+	if old != nil {
+		uc.discardBlobs(ctx, bookID, old)
+	}
+	return cover, nil
+}
+
+// putBlobs stores the uploaded image and its JPEG thumbnails, recording the
+// thumbnails in cover.
+func (uc *coverUseCase) putBlobs(ctx context.Context, bookID string, cover *domain.Cover, data []byte) error {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return ErrUnsupportedImage
+	}
+	if err := uc.store.Put(ctx, coverKey(bookID, cover, domain.CoverSizeOriginal), bytes.NewReader(data), cover.ContentType); err != nil {
+		return err
+	}
+	for _, size := range coverSizes {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, imaging.Thumbnail(img, size.Width), &jpeg.Options{Quality: 85}); err != nil {
+			return err
+		}
+		if err := uc.store.Put(ctx, coverKey(bookID, cover, size.Name), &buf, "image/jpeg"); err != nil {
+			return err
+		}
+		cover.Thumbnails = append(cover.Thumbnails, size.Name)
+	}
+	return nil
+}
+
+func (uc *coverUseCase) GetCover(bookID, size string) (io.ReadCloser, *domain.Cover, error) {
+	if size == "" {
+		size = domain.CoverSizeOriginal
+	}
+	if !slices.Contains(uc.CoverSizes(), size) {
+		return nil, nil, ErrInvalidCoverSize
+	}
+
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if book.Cover == nil {
+		return nil, nil, ErrCoverNotFound
+	}
+
+	cover := *book.Cover
+	if size != domain.CoverSizeOriginal && slices.Contains(cover.Thumbnails, size) {
+		cover.ContentType = "image/jpeg"
+	} else {
+		size = domain.CoverSizeOriginal
+	}
+
+	// Sem prazo: o conteúdo é lido pelo chamador depois do retorno.
+	body, info, err := uc.store.Get(context.Background(), coverKey(bookID, &cover, size))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, ErrCoverNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	cover.Size = info.Size
+	return body, &cover, nil
+}
+
+func (uc *coverUseCase) DeleteCover(bookID string) error {
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return err
+	}
+	if book.Cover == nil {
+		return ErrCoverNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := deleteCoverBlobs(ctx, uc.store, bookID, book.Cover); err != nil {
+		return err
+	}
+	return uc.bookRepo.SetCover(bookID, nil)
+}
+
+// discardBlobs removes the blobs of a cover no longer referenced by the book.
+// Failures only leave unused files behind, so they are logged.
+func (uc *coverUseCase) discardBlobs(ctx context.Context, bookID string, cover *domain.Cover) {
+	if err := deleteCoverBlobs(ctx, uc.store, bookID, cover); err != nil {
+		log.Printf("Erro ao remover a capa antiga do livro %s: %v", bookID, err)
+	}
+}
+
+// deleteCoverBlobs removes every stored size of a cover.
+func deleteCoverBlobs(ctx context.Context, store storage.BlobStore, bookID string, cover *domain.Cover) error {
+	if err := store.Delete(ctx, coverKey(bookID, cover, domain.CoverSizeOriginal)); err != nil {
+		return err
+	}
+	for _, size := range coverSizes {
+		if err := store.Delete(ctx, coverKey(bookID, cover, size.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
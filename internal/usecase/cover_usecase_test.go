@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/storage"
+)
+
+var errDiskFull = errors.New("disk full")
+
+// failingStore fails every Put after the first allowed ones.
+type failingStore struct {
+	storage.BlobStore
+	allowed int
+}
+
+func (s *failingStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	if s.allowed == 0 {
+		return errDiskFull
+	}
+	s.allowed--
+	return s.BlobStore.Put(ctx, key, r, contentType)
+}
+
+func testImage(t *testing.T, encode func(io.Writer, image.Image) error) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 400, 600))
+	for y := 0; y < 600; y++ {
+		for x := 0; x < 400; x++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newTestStore(t *testing.T) *storage.FileSystemStore {
+	t.Helper()
+	store, err := storage.NewFileSystemStore(t.TempDir(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestSetCover(t *testing.T) {
+	webp, err := os.ReadFile(filepath.Join("testdata", "cover.webp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name           string
+		data           []byte
+		wantType       string
+		wantWidth      int
+		wantErr        error
+		wantThumbnails int
+	}{
+		{
+			name:           "JPEG",
+			data:           testImage(t, func(w io.Writer, img image.Image) error { return jpeg.Encode(w, img, nil) }),
+			wantType:       "image/jpeg",
+			wantWidth:      400,
+			wantThumbnails: 3,
+		},
+		{
+			name:           "PNG",
+			data:           testImage(t, png.Encode),
+			wantType:       "image/png",
+			wantWidth:      400,
+			wantThumbnails: 3,
+		},
+		{name: "WebP", data: webp, wantType: "image/webp", wantWidth: 200, wantThumbnails: 3},
+		{name: "not an image", data: []byte("GIF89a"), wantErr: ErrUnsupportedImage},
+		{name: "truncated PNG", data: testImage(t, png.Encode)[:40], wantErr: ErrUnsupportedImage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := &domain.Book{ID: "b1", Title: "Dom Casmurro"}
+			uc := NewCoverUseCase(newFakeBookRepo(book), newTestStore(t))
+
+			cover, err := uc.SetCover(book.ID, tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetCover error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if book.Cover != nil {
+					t.Errorf("book cover = %+v, want none", book.Cover)
+				}
+				return
+			}
+			if cover.ContentType != tt.wantType || cover.Width != tt.wantWidth || len(cover.Thumbnails) != tt.wantThumbnails {
+				t.Errorf("cover = %+v, want %s %d px wide with %d thumbnails", cover, tt.wantType, tt.wantWidth, tt.wantThumbnails)
+			}
+
+			body, served, err := uc.GetCover(book.ID, "small")
+			if err != nil {
+				t.Fatalf("GetCover: %v", err)
+			}
+			defer body.Close()
+			thumb, _, err := image.DecodeConfig(body)
+			if err != nil || thumb.Width != 128 || served.ContentType != "image/jpeg" {
+				t.Errorf("small thumbnail is %+v (%s), want a 128 px JPEG: %v", thumb, served.ContentType, err)
+			}
+		})
+	}
+}
+
+func TestSetCoverReplacesBlobsAfterWriting(t *testing.T) {
+	jpegData := testImage(t, func(w io.Writer, img image.Image) error { return jpeg.Encode(w, img, nil) })
+	book := &domain.Book{ID: "b1", Title: "Dom Casmurro"}
+	books := newFakeBookRepo(book)
+	store := newTestStore(t)
+	uc := NewCoverUseCase(books, store)
+
+	old, err := uc.SetCover(book.ID, jpegData)
+	if err != nil {
+		t.Fatalf("SetCover: %v", err)
+	}
+
+	// A gravação falha no meio das miniaturas: a capa antiga continua intacta.
+	failing := NewCoverUseCase(books, &failingStore{BlobStore: store, allowed: 2})
+	if _, err := failing.SetCover(book.ID, testImage(t, png.Encode)); err == nil {
+		t.Fatal("SetCover succeeded with a failing store")
+	}
+	if book.Cover != old {
+		t.Fatalf("book cover = %+v, want the old one kept", book.Cover)
+	}
+	for _, size := range uc.CoverSizes() {
+		body, _, err := uc.GetCover(book.ID, size)
+		if err != nil {
+			t.Fatalf("GetCover(%s) after failed upload: %v", size, err)
+		}
+		body.Close()
+	}
+
+	// Uma troca bem-sucedida remove os arquivos da capa antiga.
+	if _, err := uc.SetCover(book.ID, testImage(t, png.Encode)); err != nil {
+		t.Fatalf("SetCover: %v", err)
+	}
+	for _, size := range uc.CoverSizes() {
+		if _, err := store.Stat(context.Background(), coverKey(book.ID, old, size)); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("old %s blob still stored: %v", size, err)
+		}
+	}
+}
+
+func TestDeleteBookRemovesCover(t *testing.T) {
+	book := &domain.Book{ID: "b1", Title: "Dom Casmurro"}
+	books := newFakeBookRepo(book)
+	store := newTestStore(t)
+	cover, err := NewCoverUseCase(books, store).SetCover(book.ID, testImage(t, png.Encode))
+	if err != nil {
+		t.Fatalf("SetCover: %v", err)
+	}
+
+	copies := &fakeCopyRepo{}
+	uc := NewBookUseCase(books, &fakeAuthorRepo{}, &fakeSeriesRepo{}, &fakeTagRepo{}, &fakeWorkRepo{}, copies, &fakeLoanRepo{}, &fakeShelfRepo{}, store, nil)
+	if err := uc.DeleteBook(book.ID); err != nil {
+		t.Fatalf("DeleteBook: %v", err)
+	}
+	for _, size := range []string{domain.CoverSizeOriginal, "small", "medium", "large"} {
+		if _, err := store.Stat(context.Background(), coverKey(book.ID, cover, size)); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("%s blob still stored after DeleteBook: %v", size, err)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/metadata"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+)
+
+// enrichmentBatchSize is how many incomplete books are loaded at a time.
+const enrichmentBatchSize = 50
+
+var (
+	ErrSuggestionOutdated = errors.New("the book changed after the suggestion was made")
+)
+
+type EnrichmentUseCase interface {
+	// Run scans incomplete books, resuming after the last processed one, and
+	// stores pending suggestions for the fields the metadata source can fill.
+	Run(ctx context.Context) (*domain.EnrichmentProgress, error)
+	GetSuggestions(bookID, status string) ([]*domain.Suggestion, error)
+	// ResolveSuggestion approves or rejects a pending suggestion. Approving
+	// applies its changes and returns the updated book.
+	ResolveSuggestion(bookID, suggestionID string, approve bool) (*domain.Book, error)
+}
+
+type enrichmentUseCase struct {
+	bookRepo       repository.BookRepository
+	books          BookUseCase
+	suggestionRepo repository.SuggestionRepository
+	progressRepo   repository.EnrichmentProgressRepository
+	metadata       metadata.Provider
+	interval       time.Duration
+}
+
+// NewEnrichmentUseCase creates the enrichment use case. Approved changes are
+// saved through books, so they are validated like any other update; it may
+// be nil when suggestions are only generated, as in the CLI. ratePerMinute
+// limits the lookups made against the metadata provider.
+func NewEnrichmentUseCase(br repository.BookRepository, books BookUseCase, sr repository.SuggestionRepository, pr repository.EnrichmentProgressRepository, mp metadata.Provider, ratePerMinute int) EnrichmentUseCase {
+	if ratePerMinute <= 0 {
+		ratePerMinute = 1
+	}
+	return &enrichmentUseCase{
+		bookRepo:       br,
+		books:          books,
+		suggestionRepo: sr,
+		progressRepo:   pr,
+		metadata:       mp,
+		interval:       time.Minute / time.Duration(ratePerMinute),
+	}
+}
+
+func (uc *enrichmentUseCase) Run(ctx context.Context) (*domain.EnrichmentProgress, error) {
+	if uc.metadata == nil {
+		return nil, ErrMetadataUnavailable
+	}
+
+	progress, err := uc.progressRepo.GetProgress()
+	if err != nil {
+		return nil, err
+	}
+	if progress.CompletedAt != nil || progress.StartedAt.IsZero() {
+		progress = &domain.EnrichmentProgress{StartedAt: time.Now()}
+	}
+
+	limiter := time.NewTicker(uc.interval)
+	defer limiter.Stop()
+
+	for {
+		books, err := uc.bookRepo.FindIncomplete(progress.LastBookID, enrichmentBatchSize)
+		if err != nil {
+			return progress, err
+		}
+		if len(books) == 0 {
+			break
+		}
+
+		for _, book := range books {
+			select {
+			case <-ctx.Done():
+				return progress, ctx.Err()
+			case <-limiter.C:
+			}
+
+			suggested, err := uc.enrich(ctx, book)
+			if err != nil {
+				// Uma falha isolada não interrompe o job; o livro é tentado na próxima execução completa.
+				log.Printf("Erro ao enriquecer o livro %s: %v", book.ID, err)
+			}
+			if suggested {
+				progress.Suggested++
+			}
+			progress.LastBookID = book.ID
+			progress.Processed++
+			progress.UpdatedAt = time.Now()
+			if err := uc.progressRepo.SaveProgress(progress); err != nil {
+				return progress, err
+			}
+		}
+	}
+
+	now := time.Now()
+	progress.CompletedAt = &now
+	progress.UpdatedAt = now
+	return progress, uc.progressRepo.SaveProgress(progress)
+}
+
+// enrich looks the book up and stores a suggestion when the metadata source
+// has values for its blank fields. Values the user already rejected for a
+// field are not proposed again. It reports whether a suggestion was made.
+func (uc *enrichmentUseCase) enrich(ctx context.Context, book *domain.Book) (bool, error) {
+	pending, err := uc.suggestionRepo.HasPending(book.ID)
+	if err != nil || pending {
+		return false, err
+	}
+
+	found, err := uc.metadata.LookupISBN(ctx, book.ISBN13)
+	if errors.Is(err, metadata.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	changes, err := uc.withoutRejected(book.ID, proposeChanges(book, found))
+	if err != nil {
+		return false, err
+	}
+	if len(changes) == 0 {
+		return false, nil
+	}
+	suggestion := &domain.Suggestion{
+		BookID:    book.ID,
+		Source:    uc.metadata.Name(),
+		Changes:   changes,
+		Status:    domain.SuggestionPending,
+		CreatedAt: time.Now(),
+	}
+	return true, uc.suggestionRepo.Create(suggestion)
+}
+
+// proposeChanges lists the blank fields of book that found can fill.
+func proposeChanges(book, found *domain.Book) []domain.FieldChange {
+	var changes []domain.FieldChange
+	if book.Subtitle == "" && found.Subtitle != "" {
+		changes = append(changes, domain.FieldChange{Field: "subtitle", Proposed: found.Subtitle})
+	}
+	if book.Publisher == "" && found.Publisher != "" {
+		changes = append(changes, domain.FieldChange{Field: "publisher", Proposed: found.Publisher})
+	}
+	if book.Pages <= 0 && found.Pages > 0 {
+		changes = append(changes, domain.FieldChange{
+			Field:    "pages",
+			Current:  strconv.Itoa(book.Pages),
+			Proposed: strconv.Itoa(found.Pages),
+		})
+	}
+	return changes
+}
+
+// withoutRejected drops the changes whose value was rejected before for the
+// same book and field.
+func (uc *enrichmentUseCase) withoutRejected(bookID string, changes []domain.FieldChange) ([]domain.FieldChange, error) {
+	var kept []domain.FieldChange
+	for _, c := range changes {
+		rejected, err := uc.suggestionRepo.HasRejected(bookID, c.Field, c.Proposed)
+		if err != nil {
+			return nil, err
+		}
+		if !rejected {
+			kept = append(kept, c)
+		}
+	}
+	return kept, nil
+}
+
+func (uc *enrichmentUseCase) GetSuggestions(bookID, status string) ([]*domain.Suggestion, error) {
+	if _, err := uc.bookRepo.GetByID(bookID); err != nil {
+		return nil, err
+	}
+	return uc.suggestionRepo.GetByBookID(bookID, status)
+}
+
+func (uc *enrichmentUseCase) ResolveSuggestion(bookID, suggestionID string, approve bool) (*domain.Book, error) {
+	suggestion, err := uc.suggestionRepo.GetByID(suggestionID)
+	if err != nil {
+		return nil, err
+	}
+	if suggestion.BookID != bookID || suggestion.Status != domain.SuggestionPending {
+		return nil, repository.ErrSuggestionNotFound
+	}
+
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !approve {
+		return book, uc.suggestionRepo.Resolve(suggestionID, domain.SuggestionRejected, time.Now())
+	}
+
+	if err := applyChanges(book, suggestion.Changes); err != nil {
+		return nil, err
+	}
+	if err := uc.books.UpdateBook(book); err != nil {
+		return nil, err
+	}
+	return book, uc.suggestionRepo.Resolve(suggestionID, domain.SuggestionApproved, time.Now())
+}
+
+// applyChanges sets the proposed values, refusing to do so if any field no
+// longer holds the value it had when the suggestion was made.
+func applyChanges(book *domain.Book, changes []domain.FieldChange) error {
+	for _, c := range changes {
+		switch c.Field {
+		case "subtitle":
+			if book.Subtitle != c.Current {
+				return ErrSuggestionOutdated
+			}
+		case "publisher":
+			if book.Publisher != c.Current {
+				return ErrSuggestionOutdated
+			}
+		case "pages":
+			if strconv.Itoa(book.Pages) != c.Current {
+				return ErrSuggestionOutdated
+			}
+		}
+	}
+
+	for _, c := range changes {
+		switch c.Field {
+		case "subtitle":
+			book.Subtitle = c.Proposed
+		case "publisher":
+			book.Publisher = c.Proposed
+		case "pages":
+			pages, err := strconv.Atoi(c.Proposed)
+			if err != nil {
+				return err
+			}
+			book.Pages = pages
+		}
+	}
+	return nil
+}
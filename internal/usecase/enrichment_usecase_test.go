@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+// enrichmentTestRate keeps the rate limiter out of the way in tests.
+const enrichmentTestRate = 600000
+
+func TestEnrichmentSkipsRejectedValues(t *testing.T) {
+	book := &domain.Book{ID: "b1", Title: "Dom Casmurro", ISBN13: "9788535902778"}
+	books := newFakeBookRepo(book)
+	suggestions := &fakeSuggestionRepo{}
+	provider := fakeProvider{"9788535902778": {Subtitle: "Romance", Publisher: "Garnier", Pages: 256}}
+	bookUseCase, _ := newTestBookUseCase(books, nil)
+	uc := NewEnrichmentUseCase(books, bookUseCase, suggestions, suggestions, provider, enrichmentTestRate)
+
+	progress, err := uc.Run(context.Background())
+	if err != nil {
+		t.Fatalf("first Run: %v", err)
+	}
+	if progress.Suggested != 1 || len(suggestions.suggestions) != 1 {
+		t.Fatalf("first run made %d suggestions, want 1", len(suggestions.suggestions))
+	}
+	first := suggestions.suggestions[0]
+	if _, err := uc.ResolveSuggestion(book.ID, first.ID, false); err != nil {
+		t.Fatalf("ResolveSuggestion: %v", err)
+	}
+
+	// A fonte agora traz outra editora; só esse valor ainda não foi recusado.
+	provider["9788535902778"].Publisher = "Penguin-Companhia"
+	progress, err = uc.Run(context.Background())
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if progress.Suggested != 1 || len(suggestions.suggestions) != 2 {
+		t.Fatalf("second run made %d suggestions, want 1", len(suggestions.suggestions)-1)
+	}
+	second := suggestions.suggestions[1]
+	want := []domain.FieldChange{{Field: "publisher", Proposed: "Penguin-Companhia"}}
+	if len(second.Changes) != 1 || second.Changes[0] != want[0] {
+		t.Errorf("second suggestion changes = %+v, want %+v", second.Changes, want)
+	}
+	if _, err := uc.ResolveSuggestion(book.ID, second.ID, false); err != nil {
+		t.Fatalf("ResolveSuggestion: %v", err)
+	}
+
+	progress, err = uc.Run(context.Background())
+	if err != nil {
+		t.Fatalf("third Run: %v", err)
+	}
+	if progress.Suggested != 0 || len(suggestions.suggestions) != 2 {
+		t.Errorf("third run made %d suggestions, want none", len(suggestions.suggestions)-2)
+	}
+}
+
+func TestResolveSuggestion(t *testing.T) {
+	tests := []struct {
+		name      string
+		approve   bool
+		pages     int
+		proposed  string
+		wantErr   error
+		wantPages int
+	}{
+		{name: "approve", approve: true, wantPages: 256},
+		{name: "reject", approve: false, wantPages: 0},
+		{name: "book changed meanwhile", approve: true, pages: 300, wantErr: ErrSuggestionOutdated, wantPages: 300},
+		// A aprovação passa pela mesma validação de uma atualização.
+		{name: "invalid value", approve: true, proposed: "-5", wantErr: validator.ErrInvalidBookData, wantPages: -5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := &domain.Book{ID: "b1", Title: "Dom Casmurro", Author: "Machado de Assis", ISBN13: "9788535902778", Pages: tt.pages}
+			proposed := tt.proposed
+			if proposed == "" {
+				proposed = "256"
+			}
+			suggestions := &fakeSuggestionRepo{}
+			suggestion := &domain.Suggestion{
+				BookID:  book.ID,
+				Changes: []domain.FieldChange{{Field: "pages", Current: "0", Proposed: proposed}},
+				Status:  domain.SuggestionPending,
+			}
+			suggestions.Create(suggestion)
+			books := newFakeBookRepo(book)
+			bookUseCase, _ := newTestBookUseCase(books, nil)
+			uc := NewEnrichmentUseCase(books, bookUseCase, suggestions, suggestions, fakeProvider{}, enrichmentTestRate)
+
+			_, err := uc.ResolveSuggestion(book.ID, suggestion.ID, tt.approve)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveSuggestion error = %v, want %v", err, tt.wantErr)
+			}
+			if book.Pages != tt.wantPages {
+				t.Errorf("pages = %d, want %d", book.Pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestGetSuggestionsOfMissingBook(t *testing.T) {
+	books := newFakeBookRepo()
+	bookUseCase, _ := newTestBookUseCase(books, nil)
+	suggestions := &fakeSuggestionRepo{}
+	uc := NewEnrichmentUseCase(books, bookUseCase, suggestions, suggestions, fakeProvider{}, enrichmentTestRate)
+
+	if _, err := uc.GetSuggestions("b9", ""); !errors.Is(err, repository.ErrBookNotFound) {
+		t.Errorf("GetSuggestions error = %v, want %v", err, repository.ErrBookNotFound)
+	}
+}
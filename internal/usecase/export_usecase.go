@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"io"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/export"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+)
+
+type ExportUseCase interface {
+	Export(format export.Format, w io.Writer) error
+}
+
+type exportUseCase struct {
+	bookRepo     repository.BookRepository
+	readBookRepo repository.ReadBookRepository
+}
+
+func NewExportUseCase(br repository.BookRepository, rbr repository.ReadBookRepository) ExportUseCase {
+	return &exportUseCase{
+		bookRepo:     br,
+		readBookRepo: rbr,
+	}
+}
+
+// exportBatchSize is how many streamed books share one query for their
+// reading records.
+const exportBatchSize = 500
+
+// Export streams every book, joined with its reading records, to w.
+func (uc *exportUseCase) Export(format export.Format, w io.Writer) error {
+	writer, err := export.NewWriter(format, w)
+	if err != nil {
+		return err
+	}
+
+	batch := make([]*domain.Book, 0, exportBatchSize)
+	err = uc.bookRepo.Stream(func(book *domain.Book) error {
+		batch = append(batch, book)
+		if len(batch) < exportBatchSize {
+			return nil
+		}
+		err := uc.writeBatch(writer, batch)
+		batch = batch[:0]
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := uc.writeBatch(writer, batch); err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// writeBatch fetches the reading records of books in one query and writes
+// an entry for each book, in order.
+func (uc *exportUseCase) writeBatch(writer export.Writer, books []*domain.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(books))
+	for i, book := range books {
+		ids[i] = book.ID
+	}
+	readBooks, err := uc.readBookRepo.GetByBookIDs(ids)
+	if err != nil {
+		return err
+	}
+	byBook := make(map[string][]*domain.ReadBook, len(books))
+	for _, readBook := range readBooks {
+		byBook[readBook.BookID] = append(byBook[readBook.BookID], readBook)
+	}
+
+	for _, book := range books {
+		entries := byBook[book.ID]
+		if entries == nil {
+			entries = []*domain.ReadBook{}
+		}
+		if err := writer.Write(&export.Entry{Book: book, ReadBooks: entries}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
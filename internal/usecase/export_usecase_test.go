@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/export"
+)
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name        string
+		books       int
+		wantQueries int
+	}{
+		{name: "no books", books: 0, wantQueries: 0},
+		{name: "one batch", books: 3, wantQueries: 1},
+		{name: "full batch", books: exportBatchSize, wantQueries: 1},
+		{name: "partial last batch", books: exportBatchSize*2 + 1, wantQueries: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			books := newFakeBookRepo()
+			readBooks := &fakeReadBookRepo{}
+			for i := 0; i < tt.books; i++ {
+				id := fmt.Sprintf("b%04d", i)
+				books.books[id] = &domain.Book{ID: id, Title: id}
+				// Só os livros pares têm leituras, e o b0000 tem duas.
+				if i%2 == 0 {
+					readBooks.readBooks = append(readBooks.readBooks, &domain.ReadBook{ID: "r" + id, BookID: id})
+				}
+			}
+			if tt.books > 0 {
+				readBooks.readBooks = append(readBooks.readBooks, &domain.ReadBook{ID: "rb0000-2", BookID: "b0000"})
+			}
+			uc := NewExportUseCase(books, readBooks)
+
+			var out bytes.Buffer
+			if err := uc.Export(export.FormatNDJSON, &out); err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+			if readBooks.queries != tt.wantQueries {
+				t.Errorf("%d queries for the reading records, want %d", readBooks.queries, tt.wantQueries)
+			}
+
+			scanner := bufio.NewScanner(&out)
+			count := 0
+			for ; scanner.Scan(); count++ {
+				var entry struct {
+					ID        string            `json:"id"`
+					ReadBooks []json.RawMessage `json:"read_books"`
+				}
+				if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+					t.Fatalf("line %d: %v", count, err)
+				}
+				want := 1 - count%2
+				if count == 0 {
+					want = 2
+				}
+				if entry.ID != fmt.Sprintf("b%04d", count) || entry.ReadBooks == nil || len(entry.ReadBooks) != want {
+					t.Fatalf("line %d = %s, want b%04d with %d reading records", count, scanner.Bytes(), count, want)
+				}
+			}
+			if count != tt.books {
+				t.Errorf("%d entries, want %d", count, tt.books)
+			}
+		})
+	}
+}
@@ -0,0 +1,1181 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/metadata"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+)
+
+// The fakes below keep their records in memory. Each embeds the repository
+// interface, so a test calling a method the fake does not implement panics
+// instead of silently passing.
+
+type fakeBookRepo struct {
+	repository.BookRepository
+	books map[string]*domain.Book
+	// err, when set, is returned by every method.
+	err error
+}
+
+func newFakeBookRepo(books ...*domain.Book) *fakeBookRepo {
+	r := &fakeBookRepo{books: make(map[string]*domain.Book)}
+	for _, b := range books {
+		r.books[b.ID] = b
+	}
+	return r
+}
+
+func (r *fakeBookRepo) Create(book *domain.Book) error {
+	if r.err != nil {
+		return r.err
+	}
+	if book.ISBN13 != "" {
+		if _, err := r.GetByISBN(book.ISBN13); err == nil {
+			return repository.ErrDuplicateISBN
+		}
+	}
+	book.ID = uuid.New().String()
+	r.books[book.ID] = book
+	return nil
+}
+
+func (r *fakeBookRepo) GetByID(id string) (*domain.Book, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	book, ok := r.books[id]
+	if !ok {
+		return nil, repository.ErrBookNotFound
+	}
+	return book, nil
+}
+
+func (r *fakeBookRepo) GetByIDs(ids []string) ([]*domain.Book, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var books []*domain.Book
+	for _, id := range ids {
+		if book, ok := r.books[id]; ok {
+			books = append(books, book)
+		}
+	}
+	return books, nil
+}
+
+func (r *fakeBookRepo) GetByIdentifier(scheme, value string) (*domain.Book, error) {
+	return r.find(func(b *domain.Book) bool { return b.Identifiers[scheme] == value })
+}
+
+func (r *fakeBookRepo) GetByISBN(isbn13 string) (*domain.Book, error) {
+	return r.find(func(b *domain.Book) bool { return b.ISBN13 == isbn13 })
+}
+
+func (r *fakeBookRepo) find(match func(*domain.Book) bool) (*domain.Book, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	for _, book := range r.books {
+		if match(book) {
+			return book, nil
+		}
+	}
+	return nil, repository.ErrBookNotFound
+}
+
+func (r *fakeBookRepo) Update(book *domain.Book) error {
+	if r.err != nil {
+		return r.err
+	}
+	if _, ok := r.books[book.ID]; !ok {
+		return repository.ErrBookNotFound
+	}
+	r.books[book.ID] = book
+	return nil
+}
+
+func (r *fakeBookRepo) Delete(id string) error {
+	if r.err != nil {
+		return r.err
+	}
+	if _, ok := r.books[id]; !ok {
+		return repository.ErrBookNotFound
+	}
+	delete(r.books, id)
+	return nil
+}
+
+func (r *fakeBookRepo) FindIncomplete(afterID string, limit int) ([]*domain.Book, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var books []*domain.Book
+	for _, book := range r.books {
+		incomplete := book.Subtitle == "" || book.Publisher == "" || book.Pages <= 0
+		if book.ID > afterID && book.ISBN13 != "" && incomplete {
+			books = append(books, book)
+		}
+	}
+	sort.Slice(books, func(i, j int) bool { return books[i].ID < books[j].ID })
+	if len(books) > limit {
+		books = books[:limit]
+	}
+	return books, nil
+}
+
+// Search supports the series, work and status filters only.
+func (r *fakeBookRepo) Search(filter domain.BookFilter) ([]*domain.Book, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var books []*domain.Book
+	for _, book := range r.books {
+		if (filter.SeriesID == "" || book.SeriesID == filter.SeriesID) && (filter.WorkID == "" || book.WorkID == filter.WorkID) && hasStatus(book, filter.Status) {
+			books = append(books, book)
+		}
+	}
+	sort.Slice(books, func(i, j int) bool { return books[i].ID < books[j].ID })
+	return books, nil
+}
+
+// hasStatus matches the status of a book as the database query does.
+func hasStatus(book *domain.Book, status string) bool {
+	switch status {
+	case domain.BookStatusAll:
+		return true
+	case "":
+		return !contains(domain.DisposalStatuses, book.Status)
+	case domain.BookOwned:
+		return book.Status == domain.BookOwned || book.Status == ""
+	}
+	return book.Status == status
+}
+
+func (r *fakeBookRepo) AddAttachment(id string, attachment *domain.Attachment) error {
+	book, err := r.GetByID(id)
+	if err != nil {
+		return err
+	}
+	book.Attachments = append(book.Attachments, *attachment)
+	return nil
+}
+
+func (r *fakeBookRepo) SetStatus(id, status string, disposal *domain.Disposal) error {
+	book, err := r.GetByID(id)
+	if err != nil {
+		return err
+	}
+	book.Status, book.Disposal = status, disposal
+	return nil
+}
+
+func (r *fakeBookRepo) Stream(fn func(book *domain.Book) error) error {
+	ids := make([]string, 0, len(r.books))
+	for id := range r.books {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := fn(r.books[id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fakeBookRepo) GetDisposed(year int) ([]*domain.Book, error) {
+	var books []*domain.Book
+	for _, book := range r.books {
+		if book.InLibrary() {
+			continue
+		}
+		if year != 0 && (book.Disposal == nil || book.Disposal.Date.Year() != year) {
+			continue
+		}
+		books = append(books, book)
+	}
+	// Como no banco, livros sem data de saída ficam por último.
+	sort.Slice(books, func(i, j int) bool {
+		a, b := books[i].Disposal, books[j].Disposal
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.Date.After(b.Date)
+	})
+	return books, nil
+}
+
+func (r *fakeBookRepo) LinkAuthor(authorID string, names []string) error {
+	for _, book := range r.books {
+		for i, c := range book.Contributors {
+			for _, name := range names {
+				if c.AuthorID == "" && strings.EqualFold(c.Name, name) {
+					book.Contributors[i].AuthorID = authorID
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *fakeBookRepo) RelinkAuthor(fromID, toID string) error {
+	for _, book := range r.books {
+		for i, c := range book.Contributors {
+			if c.AuthorID == fromID {
+				book.Contributors[i].AuthorID = toID
+			}
+		}
+	}
+	return nil
+}
+
+func (r *fakeBookRepo) AddTags(id string, tags []string) error {
+	book, err := r.GetByID(id)
+	if err != nil {
+		return err
+	}
+	book.Tags = domain.NormalizeTags(append(book.Tags, tags...))
+	return nil
+}
+
+func (r *fakeBookRepo) RetagBooks(from, to string) ([]string, error) {
+	var ids []string
+	for _, book := range r.books {
+		var tags []string
+		changed := false
+		for _, tag := range book.Tags {
+			if !domain.IsTagWithin(tag, from) {
+				tags = append(tags, tag)
+				continue
+			}
+			changed = true
+			if to != "" {
+				tags = append(tags, domain.RebaseTag(tag, from, to))
+			}
+		}
+		if changed {
+			book.Tags = domain.NormalizeTags(tags)
+			ids = append(ids, book.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (r *fakeBookRepo) TagCounts() (map[string]int, error) {
+	counts := make(map[string]int)
+	for _, book := range r.books {
+		seen := make(map[string]bool)
+		for _, tag := range book.Tags {
+			for _, path := range domain.TagAncestors(tag) {
+				if !seen[path] {
+					seen[path] = true
+					counts[path]++
+				}
+			}
+		}
+	}
+	return counts, nil
+}
+
+func (r *fakeBookRepo) SetCover(id string, cover *domain.Cover) error {
+	book, err := r.GetByID(id)
+	if err != nil {
+		return err
+	}
+	book.Cover = cover
+	return nil
+}
+
+type fakeAuthorRepo struct {
+	repository.AuthorRepository
+	authors []*domain.Author
+}
+
+func (r *fakeAuthorRepo) Create(author *domain.Author) error {
+	author.ID = uuid.New().String()
+	r.authors = append(r.authors, author)
+	return nil
+}
+
+func (r *fakeAuthorRepo) Update(author *domain.Author) error {
+	for i, a := range r.authors {
+		if a.ID == author.ID {
+			r.authors[i] = author
+			return nil
+		}
+	}
+	return repository.ErrAuthorNotFound
+}
+
+func (r *fakeAuthorRepo) Delete(id string) error {
+	for i, a := range r.authors {
+		if a.ID == id {
+			r.authors = append(r.authors[:i], r.authors[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrAuthorNotFound
+}
+
+func (r *fakeAuthorRepo) GetByID(id string) (*domain.Author, error) {
+	for _, a := range r.authors {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, repository.ErrAuthorNotFound
+}
+
+func (r *fakeAuthorRepo) FindByName(name string) (*domain.Author, error) {
+	for _, a := range r.authors {
+		for _, n := range a.Names() {
+			if strings.EqualFold(n, name) {
+				return a, nil
+			}
+		}
+	}
+	return nil, repository.ErrAuthorNotFound
+}
+
+type fakeSeriesRepo struct {
+	repository.SeriesRepository
+	series []*domain.Series
+}
+
+func (r *fakeSeriesRepo) GetByID(id string) (*domain.Series, error) {
+	for _, s := range r.series {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, repository.ErrSeriesNotFound
+}
+
+func (r *fakeSeriesRepo) FindByName(name string) (*domain.Series, error) {
+	for _, s := range r.series {
+		if strings.EqualFold(s.Name, name) {
+			return s, nil
+		}
+	}
+	return nil, repository.ErrSeriesNotFound
+}
+
+// fakeTagRepo hands out copies of its tags, as a database would.
+type fakeTagRepo struct {
+	repository.TagRepository
+	tags []*domain.Tag
+}
+
+func newFakeTagRepo(paths ...string) *fakeTagRepo {
+	r := &fakeTagRepo{}
+	r.EnsurePaths(paths)
+	return r
+}
+
+func (r *fakeTagRepo) Create(tag *domain.Tag) error {
+	if _, err := r.GetByPath(tag.Path); err == nil {
+		return repository.ErrDuplicateTag
+	}
+	tag.ID = uuid.New().String()
+	copied := *tag
+	r.tags = append(r.tags, &copied)
+	return nil
+}
+
+func (r *fakeTagRepo) GetByID(id string) (*domain.Tag, error) {
+	for _, t := range r.tags {
+		if t.ID == id {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrTagNotFound
+}
+
+func (r *fakeTagRepo) GetByPath(path string) (*domain.Tag, error) {
+	for _, t := range r.tags {
+		if t.Path == path {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrTagNotFound
+}
+
+func (r *fakeTagRepo) GetAll() ([]*domain.Tag, error) {
+	return r.GetSubtree("")
+}
+
+// GetSubtree returns every tag when path is empty.
+func (r *fakeTagRepo) GetSubtree(path string) ([]*domain.Tag, error) {
+	var tags []*domain.Tag
+	for _, t := range r.tags {
+		if path == "" || domain.IsTagWithin(t.Path, path) {
+			copied := *t
+			tags = append(tags, &copied)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Path < tags[j].Path })
+	return tags, nil
+}
+
+func (r *fakeTagRepo) Update(tag *domain.Tag) error {
+	for _, t := range r.tags {
+		if t.Path == tag.Path && t.ID != tag.ID {
+			return repository.ErrDuplicateTag
+		}
+	}
+	for i, t := range r.tags {
+		if t.ID == tag.ID {
+			copied := *tag
+			r.tags[i] = &copied
+			return nil
+		}
+	}
+	return repository.ErrTagNotFound
+}
+
+func (r *fakeTagRepo) Delete(id string) error {
+	for i, t := range r.tags {
+		if t.ID == id {
+			r.tags = append(r.tags[:i], r.tags[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrTagNotFound
+}
+
+func (r *fakeTagRepo) EnsurePaths(paths []string) error {
+	for _, path := range paths {
+		for _, p := range domain.TagAncestors(path) {
+			if _, err := r.GetByPath(p); err != nil {
+				tag := &domain.Tag{}
+				tag.SetPath(p)
+				r.Create(tag)
+			}
+		}
+	}
+	return nil
+}
+
+// paths returns the paths of the tree, sorted.
+func (r *fakeTagRepo) paths() []string {
+	tags, _ := r.GetAll()
+	paths := make([]string, len(tags))
+	for i, t := range tags {
+		paths[i] = t.Path
+	}
+	return paths
+}
+
+type fakeTagHistoryRepo struct {
+	repository.TagHistoryRepository
+	changes []*domain.TagChange
+}
+
+func (r *fakeTagHistoryRepo) AddChange(change *domain.TagChange) error {
+	change.ID = uuid.New().String()
+	r.changes = append(r.changes, change)
+	return nil
+}
+
+type fakeWorkRepo struct {
+	repository.WorkRepository
+	works []*domain.Work
+}
+
+func (r *fakeWorkRepo) Create(work *domain.Work) error {
+	work.ID = uuid.New().String()
+	r.works = append(r.works, work)
+	return nil
+}
+
+func (r *fakeWorkRepo) GetByID(id string) (*domain.Work, error) {
+	for _, w := range r.works {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+	return nil, repository.ErrWorkNotFound
+}
+
+func (r *fakeWorkRepo) Delete(id string) error {
+	for i, w := range r.works {
+		if w.ID == id {
+			r.works = append(r.works[:i], r.works[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrWorkNotFound
+}
+
+func (r *fakeWorkRepo) FindByTitleAuthor(title, author string) (*domain.Work, error) {
+	for _, w := range r.works {
+		if strings.EqualFold(w.Title, title) && strings.EqualFold(w.Author, author) {
+			return w, nil
+		}
+	}
+	return nil, repository.ErrWorkNotFound
+}
+
+type fakeCopyRepo struct {
+	repository.CopyRepository
+	copies []*domain.Copy
+	// err, when set, is returned by Create.
+	err error
+}
+
+func (r *fakeCopyRepo) Create(c *domain.Copy) error {
+	if r.err != nil {
+		return r.err
+	}
+	c.ID = uuid.New().String()
+	r.copies = append(r.copies, c)
+	return nil
+}
+
+func (r *fakeCopyRepo) GetByBookIDs(bookIDs []string) ([]*domain.Copy, error) {
+	var copies []*domain.Copy
+	for _, c := range r.copies {
+		for _, id := range bookIDs {
+			if c.BookID == id {
+				copies = append(copies, c)
+			}
+		}
+	}
+	return copies, nil
+}
+
+func (r *fakeCopyRepo) GetByID(id string) (*domain.Copy, error) {
+	for _, c := range r.copies {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, repository.ErrCopyNotFound
+}
+
+func (r *fakeCopyRepo) Update(c *domain.Copy) error {
+	stored, err := r.GetByID(c.ID)
+	if err != nil {
+		return err
+	}
+	stored.Condition, stored.Notes, stored.Acquisition = c.Condition, c.Notes, c.Acquisition
+	return nil
+}
+
+func (r *fakeCopyRepo) FindByLocations(locationIDs []string) ([]*domain.Copy, error) {
+	var copies []*domain.Copy
+	for _, c := range r.copies {
+		if c.Location != nil && contains(locationIDs, c.Location.LocationID) {
+			copies = append(copies, c)
+		}
+	}
+	return copies, nil
+}
+
+func (r *fakeCopyRepo) SetLocation(id string, location *domain.BookLocation) error {
+	c, err := r.GetByID(id)
+	if err != nil {
+		return err
+	}
+	c.Location = location
+	if location != nil {
+		c.LastLocation = nil
+	}
+	return nil
+}
+
+func (r *fakeCopyRepo) Relocate(fromID, toID string) (int, error) {
+	moved := 0
+	for _, c := range r.copies {
+		if c.Location != nil && c.Location.LocationID == fromID {
+			c.Location.LocationID = toID
+			moved++
+		}
+	}
+	return moved, nil
+}
+
+func (r *fakeCopyRepo) Unshelve(id string) error {
+	for _, c := range r.copies {
+		if c.ID == id {
+			c.LastLocation, c.Location = c.Location, nil
+			return nil
+		}
+	}
+	return repository.ErrCopyNotFound
+}
+
+func (r *fakeCopyRepo) DeleteByBookID(bookID string) error {
+	kept := r.copies[:0]
+	for _, c := range r.copies {
+		if c.BookID != bookID {
+			kept = append(kept, c)
+		}
+	}
+	r.copies = kept
+	return nil
+}
+
+func (r *fakeCopyRepo) SpendingTotals() ([]domain.SpendingTotal, error) {
+	var totals []domain.SpendingTotal
+	for _, c := range r.copies {
+		a := c.Acquisition
+		if a == nil || a.Method != domain.AcquiredPurchase || a.Price <= 0 {
+			continue
+		}
+		year := 0
+		if a.Date != nil {
+			year = a.Date.Year()
+		}
+		i := 0
+		for i < len(totals) && (totals[i].Year != year || totals[i].Store != a.Store || totals[i].Currency != a.Currency) {
+			i++
+		}
+		if i == len(totals) {
+			totals = append(totals, domain.SpendingTotal{Year: year, Store: a.Store, Currency: a.Currency})
+		}
+		totals[i].Amount += a.Price
+		totals[i].Count++
+	}
+	return totals, nil
+}
+
+type fakeExchangeRateRepo struct {
+	repository.ExchangeRateRepository
+	rates []*domain.ExchangeRate
+}
+
+func (r *fakeExchangeRateRepo) Set(rate *domain.ExchangeRate) error {
+	for i, existing := range r.rates {
+		if existing.Currency == rate.Currency {
+			r.rates[i] = rate
+			return nil
+		}
+	}
+	r.rates = append(r.rates, rate)
+	sort.Slice(r.rates, func(i, j int) bool { return r.rates[i].Currency < r.rates[j].Currency })
+	return nil
+}
+
+func (r *fakeExchangeRateRepo) GetAll() ([]*domain.ExchangeRate, error) {
+	return r.rates, nil
+}
+
+func (r *fakeExchangeRateRepo) Delete(currency string) error {
+	for i, rate := range r.rates {
+		if rate.Currency == currency {
+			r.rates = append(r.rates[:i], r.rates[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrExchangeRateNotFound
+}
+
+type fakeReadBookRepo struct {
+	repository.ReadBookRepository
+	readBooks []*domain.ReadBook
+	// queries counts the calls made to the repository.
+	queries int
+}
+
+func (r *fakeReadBookRepo) GetByWorkOrBookIDs(workIDs, bookIDs []string) ([]*domain.ReadBook, error) {
+	r.queries++
+	var readBooks []*domain.ReadBook
+	for _, rb := range r.readBooks {
+		if contains(workIDs, rb.WorkID) || contains(bookIDs, rb.BookID) {
+			readBooks = append(readBooks, rb)
+		}
+	}
+	return readBooks, nil
+}
+
+func (r *fakeReadBookRepo) GetByBookIDs(bookIDs []string) ([]*domain.ReadBook, error) {
+	return r.GetByWorkOrBookIDs(nil, bookIDs)
+}
+
+func (r *fakeReadBookRepo) GetByWorkID(workID string) ([]*domain.ReadBook, error) {
+	r.queries++
+	var readBooks []*domain.ReadBook
+	for _, rb := range r.readBooks {
+		if rb.WorkID == workID {
+			readBooks = append(readBooks, rb)
+		}
+	}
+	return readBooks, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeShelfRepo hands out copies of its shelves, so a use case holding one
+// sees a snapshot, as it would with a database.
+type fakeShelfRepo struct {
+	repository.ShelfRepository
+	shelves map[string]*domain.Shelf
+	// beforeWrite, when set, runs before each entry write, standing in for
+	// someone else editing the shelf at the same time.
+	beforeWrite func()
+	// writes counts the entry writes that succeeded.
+	writes int
+}
+
+func newFakeShelfRepo(shelves ...*domain.Shelf) *fakeShelfRepo {
+	r := &fakeShelfRepo{shelves: make(map[string]*domain.Shelf)}
+	for _, s := range shelves {
+		r.shelves[s.ID] = s
+	}
+	return r
+}
+
+func (r *fakeShelfRepo) GetByID(id string) (*domain.Shelf, error) {
+	shelf, ok := r.shelves[id]
+	if !ok {
+		return nil, repository.ErrShelfNotFound
+	}
+	copied := *shelf
+	copied.Entries = append([]domain.ShelfEntry(nil), shelf.Entries...)
+	copied.SortEntries()
+	return &copied, nil
+}
+
+// write checks the revision and applies change to the stored shelf.
+func (r *fakeShelfRepo) write(shelfID string, revision int64, change func(*domain.Shelf) error) error {
+	if r.beforeWrite != nil {
+		r.beforeWrite()
+	}
+	shelf, ok := r.shelves[shelfID]
+	if !ok {
+		return repository.ErrShelfNotFound
+	}
+	if revision >= 0 && shelf.Revision != revision {
+		return repository.ErrShelfChanged
+	}
+	if err := change(shelf); err != nil {
+		return err
+	}
+	shelf.Revision++
+	r.writes++
+	return nil
+}
+
+func (r *fakeShelfRepo) AddEntry(shelfID string, revision int64, entry domain.ShelfEntry) error {
+	return r.write(shelfID, revision, func(shelf *domain.Shelf) error {
+		if entryIndex(shelf.Entries, entry.BookID) >= 0 {
+			return repository.ErrBookAlreadyOnShelf
+		}
+		shelf.Entries = append(shelf.Entries, entry)
+		return nil
+	})
+}
+
+func (r *fakeShelfRepo) RemoveEntry(shelfID, bookID string) error {
+	return r.write(shelfID, -1, func(shelf *domain.Shelf) error {
+		i := entryIndex(shelf.Entries, bookID)
+		if i < 0 {
+			return repository.ErrBookNotOnShelf
+		}
+		shelf.Entries = append(shelf.Entries[:i], shelf.Entries[i+1:]...)
+		return nil
+	})
+}
+
+func (r *fakeShelfRepo) RemoveBook(bookID string) error {
+	for id, shelf := range r.shelves {
+		if entryIndex(shelf.Entries, bookID) >= 0 {
+			if err := r.RemoveEntry(id, bookID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *fakeShelfRepo) SetPositions(shelfID string, revision int64, positions map[string]float64) error {
+	return r.write(shelfID, revision, func(shelf *domain.Shelf) error {
+		for i, e := range shelf.Entries {
+			if position, ok := positions[e.BookID]; ok {
+				shelf.Entries[i].Position = position
+			}
+		}
+		return nil
+	})
+}
+
+// fakeLocationRepo hands out copies of its locations, as a database would.
+type fakeLocationRepo struct {
+	repository.LocationRepository
+	locations []*domain.Location
+}
+
+func (r *fakeLocationRepo) Create(location *domain.Location) error {
+	location.ID = uuid.New().String()
+	copied := *location
+	r.locations = append(r.locations, &copied)
+	return nil
+}
+
+func (r *fakeLocationRepo) GetByID(id string) (*domain.Location, error) {
+	for _, l := range r.locations {
+		if l.ID == id {
+			copied := *l
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrLocationNotFound
+}
+
+func (r *fakeLocationRepo) GetByIDs(ids []string) ([]*domain.Location, error) {
+	return r.filter(func(l *domain.Location) bool { return contains(ids, l.ID) }), nil
+}
+
+func (r *fakeLocationRepo) GetAll() ([]*domain.Location, error) {
+	locations := r.filter(func(*domain.Location) bool { return true })
+	sort.SliceStable(locations, func(i, j int) bool { return locations[i].Name < locations[j].Name })
+	return locations, nil
+}
+
+func (r *fakeLocationRepo) GetDescendants(id string) ([]*domain.Location, error) {
+	return r.filter(func(l *domain.Location) bool { return contains(l.Ancestors, id) }), nil
+}
+
+func (r *fakeLocationRepo) filter(match func(*domain.Location) bool) []*domain.Location {
+	var locations []*domain.Location
+	for _, l := range r.locations {
+		if match(l) {
+			copied := *l
+			locations = append(locations, &copied)
+		}
+	}
+	return locations
+}
+
+func (r *fakeLocationRepo) Update(location *domain.Location) error {
+	for i, l := range r.locations {
+		if l.ID == location.ID {
+			copied := *location
+			r.locations[i] = &copied
+			return nil
+		}
+	}
+	return repository.ErrLocationNotFound
+}
+
+func (r *fakeLocationRepo) Delete(id string) error {
+	for i, l := range r.locations {
+		if l.ID == id {
+			r.locations = append(r.locations[:i], r.locations[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrLocationNotFound
+}
+
+type fakeAuditRepo struct {
+	repository.AuditRepository
+	sessions []*domain.AuditSession
+}
+
+func (r *fakeAuditRepo) Create(session *domain.AuditSession) error {
+	if _, err := r.FindOpen(session.LocationID); err == nil {
+		return repository.ErrAuditInProgress
+	}
+	session.ID = uuid.New().String()
+	r.sessions = append(r.sessions, session)
+	return nil
+}
+
+func (r *fakeAuditRepo) FindOpen(locationID string) (*domain.AuditSession, error) {
+	for _, s := range r.sessions {
+		if s.LocationID == locationID && s.Status == domain.AuditOpen {
+			return s, nil
+		}
+	}
+	return nil, repository.ErrAuditNotFound
+}
+
+type fakeLoanRepo struct {
+	repository.LoanRepository
+	loans []*domain.Loan
+}
+
+func (r *fakeLoanRepo) Create(loan *domain.Loan) error {
+	for _, l := range r.loans {
+		if l.CopyID == loan.CopyID && l.ReturnedAt == nil {
+			return repository.ErrCopyOnLoan
+		}
+	}
+	loan.ID = uuid.New().String()
+	r.loans = append(r.loans, loan)
+	return nil
+}
+
+func (r *fakeLoanRepo) GetByID(id string) (*domain.Loan, error) {
+	for _, l := range r.loans {
+		if l.ID == id {
+			return l, nil
+		}
+	}
+	return nil, repository.ErrLoanNotFound
+}
+
+func (r *fakeLoanRepo) GetActive() ([]*domain.Loan, error) {
+	return r.active(func(*domain.Loan) bool { return true }), nil
+}
+
+func (r *fakeLoanRepo) GetOverdue(now time.Time) ([]*domain.Loan, error) {
+	return r.active(func(l *domain.Loan) bool { return l.DueAt.Before(now) }), nil
+}
+
+func (r *fakeLoanRepo) GetActiveByBookID(bookID string) ([]*domain.Loan, error) {
+	return r.active(func(l *domain.Loan) bool { return l.BookID == bookID }), nil
+}
+
+func (r *fakeLoanRepo) DeleteByBookID(bookID string) error {
+	kept := r.loans[:0]
+	for _, l := range r.loans {
+		if l.BookID != bookID {
+			kept = append(kept, l)
+		}
+	}
+	r.loans = kept
+	return nil
+}
+
+// active returns the active loans that match, the soonest due first.
+func (r *fakeLoanRepo) active(match func(*domain.Loan) bool) []*domain.Loan {
+	var loans []*domain.Loan
+	for _, l := range r.loans {
+		if l.ReturnedAt == nil && match(l) {
+			loans = append(loans, l)
+		}
+	}
+	sort.SliceStable(loans, func(i, j int) bool { return loans[i].DueAt.Before(loans[j].DueAt) })
+	return loans
+}
+
+func (r *fakeLoanRepo) GetByBorrower(borrower string) ([]*domain.Loan, error) {
+	var loans []*domain.Loan
+	for _, l := range r.loans {
+		if strings.EqualFold(l.Borrower, borrower) {
+			loans = append(loans, l)
+		}
+	}
+	sort.SliceStable(loans, func(i, j int) bool { return loans[i].LentAt.After(loans[j].LentAt) })
+	return loans, nil
+}
+
+func (r *fakeLoanRepo) Return(id string, returnedAt time.Time) error {
+	loan, err := r.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if loan.ReturnedAt != nil {
+		return repository.ErrLoanReturned
+	}
+	loan.Status, loan.ReturnedAt = domain.LoanReturned, &returnedAt
+	return nil
+}
+
+type fakeSuggestionRepo struct {
+	repository.SuggestionRepository
+	suggestions []*domain.Suggestion
+	progress    domain.EnrichmentProgress
+}
+
+func (r *fakeSuggestionRepo) Create(suggestion *domain.Suggestion) error {
+	suggestion.ID = uuid.New().String()
+	r.suggestions = append(r.suggestions, suggestion)
+	return nil
+}
+
+func (r *fakeSuggestionRepo) GetByID(id string) (*domain.Suggestion, error) {
+	for _, s := range r.suggestions {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, repository.ErrSuggestionNotFound
+}
+
+func (r *fakeSuggestionRepo) HasPending(bookID string) (bool, error) {
+	for _, s := range r.suggestions {
+		if s.BookID == bookID && s.Status == domain.SuggestionPending {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeSuggestionRepo) HasRejected(bookID, field, value string) (bool, error) {
+	for _, s := range r.suggestions {
+		if s.BookID != bookID || s.Status != domain.SuggestionRejected {
+			continue
+		}
+		for _, c := range s.Changes {
+			if c.Field == field && c.Proposed == value {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeSuggestionRepo) Resolve(id, status string, resolvedAt time.Time) error {
+	s, err := r.GetByID(id)
+	if err != nil || s.Status != domain.SuggestionPending {
+		return repository.ErrSuggestionNotFound
+	}
+	s.Status, s.ResolvedAt = status, &resolvedAt
+	return nil
+}
+
+func (r *fakeSuggestionRepo) GetProgress() (*domain.EnrichmentProgress, error) {
+	progress := r.progress
+	return &progress, nil
+}
+
+func (r *fakeSuggestionRepo) SaveProgress(progress *domain.EnrichmentProgress) error {
+	r.progress = *progress
+	return nil
+}
+
+// fakeProvider serves metadata from a map keyed by ISBN-13.
+type fakeProvider map[string]*domain.Book
+
+func (p fakeProvider) Name() string { return "fake" }
+
+func (p fakeProvider) LookupISBN(ctx context.Context, isbn13 string) (*domain.Book, error) {
+	book, ok := p[isbn13]
+	if !ok {
+		return nil, metadata.ErrNotFound
+	}
+	copied := *book
+	return &copied, nil
+}
+
+// fakeWishlistRepo hands out copies of its items, so a use case holding one
+// sees a snapshot, as it would with a database.
+type fakeWishlistRepo struct {
+	repository.WishlistRepository
+	items []*domain.WishlistItem
+}
+
+func (r *fakeWishlistRepo) Create(item *domain.WishlistItem) error {
+	item.ID = uuid.New().String()
+	stored := *item
+	r.items = append(r.items, &stored)
+	return nil
+}
+
+func (r *fakeWishlistRepo) find(id string) (*domain.WishlistItem, error) {
+	for _, item := range r.items {
+		if item.ID == id {
+			return item, nil
+		}
+	}
+	return nil, repository.ErrWishlistItemNotFound
+}
+
+func (r *fakeWishlistRepo) GetByID(id string) (*domain.WishlistItem, error) {
+	item, err := r.find(id)
+	if err != nil {
+		return nil, err
+	}
+	found := *item
+	return &found, nil
+}
+
+func (r *fakeWishlistRepo) GetAll() ([]*domain.WishlistItem, error) {
+	var items []*domain.WishlistItem
+	for _, item := range r.items {
+		found := *item
+		items = append(items, &found)
+	}
+	return items, nil
+}
+
+func (r *fakeWishlistRepo) Update(item *domain.WishlistItem) error {
+	stored, err := r.find(item.ID)
+	if err != nil {
+		return err
+	}
+	updated := *item
+	updated.Reservation, updated.CreatedAt = stored.Reservation, stored.CreatedAt
+	*stored = updated
+	return nil
+}
+
+func (r *fakeWishlistRepo) Delete(id string) error {
+	for i, item := range r.items {
+		if item.ID == id {
+			r.items = append(r.items[:i], r.items[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrWishlistItemNotFound
+}
+
+func (r *fakeWishlistRepo) Reserve(id string, reservation domain.Reservation) error {
+	item, err := r.find(id)
+	if err != nil {
+		return err
+	}
+	if item.Reservation != nil {
+		return repository.ErrAlreadyReserved
+	}
+	item.Reservation = &reservation
+	return nil
+}
+
+func (r *fakeWishlistRepo) CancelReservation(id, code string) error {
+	item, err := r.find(id)
+	if err != nil {
+		return err
+	}
+	if item.Reservation == nil || item.Reservation.Code != code {
+		return repository.ErrNotReserved
+	}
+	item.Reservation = nil
+	return nil
+}
+
+type fakeWishlistShareRepo struct {
+	repository.WishlistShareRepository
+	share *domain.WishlistShare
+}
+
+func (r *fakeWishlistShareRepo) Replace(share *domain.WishlistShare) error {
+	r.share = share
+	return nil
+}
+
+func (r *fakeWishlistShareRepo) Get() (*domain.WishlistShare, error) {
+	if r.share == nil {
+		return nil, repository.ErrShareNotFound
+	}
+	return r.share, nil
+}
+
+func (r *fakeWishlistShareRepo) Exists(token string) (bool, error) {
+	return r.share != nil && r.share.Token == token, nil
+}
+
+func (r *fakeWishlistShareRepo) DeleteAll() error {
+	r.share = nil
+	return nil
+}
@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rfulgencio3/go-personal-library/internal/calibre"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/marc"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+// ImportResult summarises the outcome of a bulk import.
+type ImportResult struct {
+	Created  int             `json:"created"`
+	Updated  int             `json:"updated"`
+	Failures []ImportFailure `json:"failures,omitempty"`
+}
+
+// ImportFailure describes a record that could not be imported.
+type ImportFailure struct {
+	Source string `json:"source"`
+	Error  string `json:"error"`
+}
+
+type ImportUseCase interface {
+	ImportCalibreLibrary(dir string) (*ImportResult, error)
+	ImportMARC(r io.Reader) (*ImportResult, error)
+}
+
+type importUseCase struct {
+	bookRepo   repository.BookRepository
+	authorRepo repository.AuthorRepository
+	seriesRepo repository.SeriesRepository
+	tagRepo    repository.TagRepository
+	workRepo   repository.WorkRepository
+	copyRepo   repository.CopyRepository
+}
+
+func NewImportUseCase(br repository.BookRepository, ar repository.AuthorRepository, sr repository.SeriesRepository, tr repository.TagRepository, wr repository.WorkRepository, cr repository.CopyRepository) ImportUseCase {
+	return &importUseCase{
+		bookRepo:   br,
+		authorRepo: ar,
+		seriesRepo: sr,
+		tagRepo:    tr,
+		workRepo:   wr,
+		copyRepo:   cr,
+	}
+}
+
+// ImportCalibreLibrary creates a book for every metadata.opf found in dir.
+// Books already imported are matched by their Calibre UUID and updated.
+func (uc *importUseCase) ImportCalibreLibrary(dir string) (*ImportResult, error) {
+	entries, err := calibre.ReadLibrary(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	for _, entry := range entries {
+		if entry.Err != nil {
+			result.fail(entry.Path, entry.Err)
+			continue
+		}
+		created, err := uc.upsert(entry.Book, uc.byIdentifier(entry.Book, domain.IdentifierCalibreUUID))
+		if isRecordError(err) {
+			result.fail(entry.Path, err)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.count(created)
+	}
+	return result, nil
+}
+
+// ImportMARC creates books from ISO 2709 or MARCXML records. Records are
+// matched to existing books by ISBN, or by control number when there is none.
+// Invalid records are reported in the result; an unreadable or truncated
+// stream, or a database failure, is returned as an error, even when earlier
+// records were already saved.
+func (uc *importUseCase) ImportMARC(r io.Reader) (*ImportResult, error) {
+	reader := marc.NewRecordReader(r)
+	result := &ImportResult{}
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		source := fmt.Sprintf("record %d", n)
+		if errors.Is(err, marc.ErrInvalidRecord) {
+			result.fail(source, err)
+			continue
+		}
+		if err != nil {
+			// Um stream interrompido não pode ser dado como importado. Os
+			// registros já salvos são atualizados ao repetir a importação.
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+
+		book := marc.BookFromRecord(record)
+		find := uc.byIdentifier(book, domain.IdentifierMARCControlNumber)
+		if book.ISBN13 != "" {
+			find = uc.byISBN(book)
+		}
+		created, err := uc.upsert(book, find)
+		if isRecordError(err) {
+			result.fail(source, err)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.count(created)
+	}
+	return result, nil
+}
+
+// isRecordError reports whether err is caused by the imported record
+// itself, in which case the import goes on with the next record. Other
+// errors, such as database failures, stop the import.
+func isRecordError(err error) bool {
+	return errors.Is(err, validator.ErrInvalidBookData) || errors.Is(err, repository.ErrDuplicateISBN)
+}
+
+// findFunc looks up the existing copy of an imported book.
+type findFunc func() (*domain.Book, error)
+
+// byIdentifier finds the book sharing the imported book's identifier under scheme.
+func (uc *importUseCase) byIdentifier(book *domain.Book, scheme string) findFunc {
+	return func() (*domain.Book, error) {
+		key := book.Identifiers[scheme]
+		if key == "" {
+			return nil, repository.ErrBookNotFound
+		}
+		return uc.bookRepo.GetByIdentifier(scheme, key)
+	}
+}
+
+// byISBN finds the book with the same ISBN-13 as the imported book.
+func (uc *importUseCase) byISBN(book *domain.Book) findFunc {
+	return func() (*domain.Book, error) {
+		return uc.bookRepo.GetByISBN(book.ISBN13)
+	}
+}
+
+// upsert creates the book, or updates the existing book returned by find.
+// It reports whether a new book was created. Either way the book saved is
+// validated like the ones sent to the API.
+func (uc *importUseCase) upsert(book *domain.Book, find findFunc) (bool, error) {
+	existing, err := find()
+	if errors.Is(err, repository.ErrBookNotFound) {
+		if err := uc.prepare(book); err != nil {
+			return false, err
+		}
+		return true, uc.create(book)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	mergeImported(existing, book)
+	if err := uc.prepare(existing); err != nil {
+		return false, err
+	}
+	return false, uc.bookRepo.Update(existing)
+}
+
+// prepare validates a book about to be saved and links it to its authors,
+// series and tags. Catalogues often lack the page count, so it may be zero.
+func (uc *importUseCase) prepare(book *domain.Book) error {
+	if err := validator.ValidateImportedBook(book); err != nil {
+		return err
+	}
+	if err := linkContributors(uc.authorRepo, book); err != nil {
+		return err
+	}
+	if err := linkSeries(uc.seriesRepo, book); err != nil {
+		return err
+	}
+	return uc.tagRepo.EnsurePaths(book.Tags)
+}
+
+// create saves an imported book as an edition of its work, with one copy.
+func (uc *importUseCase) create(book *domain.Book) error {
+	if err := linkWork(uc.workRepo, book); err != nil {
+		return err
+	}
+	book.Status = domain.BookOwned
+	return createWithCopy(uc.bookRepo, uc.copyRepo, book)
+}
+
+// mergeImported copies imported metadata onto an existing book. Comments are
+// personal notes and are never overwritten; other fields are only kept when
+// the import has no value for them.
+func mergeImported(existing, imported *domain.Book) {
+	existing.Title = imported.Title
+	if imported.Subtitle != "" {
+		existing.Subtitle = imported.Subtitle
+	}
+	if imported.Author != "" {
+		existing.Contributors = mergeContributors(existing.Contributors, imported)
+		existing.Author = imported.Author
+	}
+	if imported.Pages > 0 {
+		existing.Pages = imported.Pages
+	}
+	if imported.Publisher != "" {
+		existing.Publisher = imported.Publisher
+	}
+	if imported.Edition != "" {
+		existing.Edition = imported.Edition
+	}
+	if imported.Year > 0 {
+		existing.Year = imported.Year
+	}
+	if imported.ISBN13 != "" {
+		existing.ISBN10, existing.ISBN13 = imported.ISBN10, imported.ISBN13
+	}
+	if imported.Language != "" {
+		existing.Language = imported.Language
+	}
+	if imported.Description != "" {
+		existing.Description = imported.Description
+	}
+	if imported.Series != "" {
+		existing.Series, existing.SeriesID = imported.Series, imported.SeriesID
+		existing.SeriesPosition = imported.SeriesPosition
+	}
+	if len(imported.Tags) > 0 {
+		existing.Tags = imported.Tags
+	}
+	if existing.Identifiers == nil {
+		existing.Identifiers = make(map[string]string)
+	}
+	for scheme, value := range imported.Identifiers {
+		existing.Identifiers[scheme] = value
+	}
+}
+
+// mergeContributors replaces the author credits of contributors with those
+// of the imported book, as UpdateBook does, so translators and other roles
+// added by hand survive a re-import. Other roles brought by the import are
+// added when not yet credited.
+func mergeContributors(contributors []domain.Contributor, imported *domain.Book) []domain.Contributor {
+	merged := domain.ReplaceAuthors(contributors, imported.Author)
+	for _, c := range imported.Contributors {
+		if c.Role == domain.RoleAuthor || hasCredit(merged, c) {
+			continue
+		}
+		c.Order = len(merged) + 1
+		merged = append(merged, c)
+	}
+	return merged
+}
+
+// hasCredit reports whether c is already in contributors with the same role.
+func hasCredit(contributors []domain.Contributor, c domain.Contributor) bool {
+	for _, existing := range contributors {
+		if existing.Role == c.Role && strings.EqualFold(existing.Name, c.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ImportResult) count(created bool) {
+	if created {
+		r.Created++
+	} else {
+		r.Updated++
+	}
+}
+
+func (r *ImportResult) fail(source string, err error) {
+	r.Failures = append(r.Failures, ImportFailure{Source: source, Error: err.Error()})
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/marc"
+)
+
+// marcXML encodes books as a MARCXML collection.
+func marcXML(t *testing.T, books ...*domain.Book) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := marc.NewXMLWriter(&buf)
+	for _, book := range books {
+		if err := writer.Write(marc.RecordFromBook(book)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return &buf
+}
+
+func newTestImportUseCase(books *fakeBookRepo) (ImportUseCase, *fakeCopyRepo) {
+	copies := &fakeCopyRepo{}
+	return NewImportUseCase(books, &fakeAuthorRepo{}, &fakeSeriesRepo{}, &fakeTagRepo{}, &fakeWorkRepo{}, copies), copies
+}
+
+func TestImportMARC(t *testing.T) {
+	existing := &domain.Book{
+		ID:       "b1",
+		Title:    "Dom Casmurro",
+		Author:   "Machado de Assis",
+		ISBN13:   "9788535902778",
+		Comments: "Releitura em 2020",
+	}
+	books := newFakeBookRepo(existing)
+	uc, copies := newTestImportUseCase(books)
+
+	input := marcXML(t,
+		&domain.Book{Title: "Dom Casmurro", Author: "Machado de Assis", ISBN13: "9788535902778", Pages: 256},
+		&domain.Book{Title: "Grande Sertão: Veredas", Author: "João Guimarães Rosa", Year: 1956, Pages: 624},
+		// Sem título o registro não passa na validação.
+		&domain.Book{Author: "Anônimo"},
+	)
+	result, err := uc.ImportMARC(input)
+	if err != nil {
+		t.Fatalf("ImportMARC: %v", err)
+	}
+	if result.Created != 1 || result.Updated != 1 {
+		t.Errorf("created %d, updated %d, want 1 and 1", result.Created, result.Updated)
+	}
+	if len(result.Failures) != 1 || result.Failures[0].Source != "record 3" {
+		t.Errorf("failures = %+v, want record 3", result.Failures)
+	}
+	if existing.Pages != 256 || existing.Comments != "Releitura em 2020" {
+		t.Errorf("updated book = %+v, want pages merged and comments kept", existing)
+	}
+	if len(copies.copies) != 1 {
+		t.Errorf("%d copies created, want 1 for the new book", len(copies.copies))
+	}
+}
+
+func TestImportMARCTruncated(t *testing.T) {
+	books := newFakeBookRepo()
+	uc, _ := newTestImportUseCase(books)
+
+	input := marcXML(t,
+		&domain.Book{Title: "Dom Casmurro", Author: "Machado de Assis", Pages: 256},
+		&domain.Book{Title: "Grande Sertão: Veredas", Author: "João Guimarães Rosa", Pages: 624},
+	)
+	// O stream termina no meio do segundo registro.
+	truncated := bytes.NewReader(input.Bytes()[:input.Len()*3/4])
+	result, err := uc.ImportMARC(truncated)
+	if err == nil {
+		t.Fatalf("ImportMARC = %+v, want an error for the truncated stream", result)
+	}
+	if len(books.books) != 1 {
+		t.Errorf("%d books saved, want the record read before the break", len(books.books))
+	}
+}
+
+func TestImportMARCStopsOnDatabaseError(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	books := newFakeBookRepo()
+	books.err = dbErr
+	uc, _ := newTestImportUseCase(books)
+
+	input := marcXML(t, &domain.Book{Title: "Dom Casmurro", Author: "Machado de Assis", ISBN13: "9788535902778"})
+	if _, err := uc.ImportMARC(input); !errors.Is(err, dbErr) {
+		t.Errorf("ImportMARC error = %v, want %v", err, dbErr)
+	}
+}
+
+func TestImportCalibreLibrary(t *testing.T) {
+	books := newFakeBookRepo()
+	uc, copies := newTestImportUseCase(books)
+
+	result, err := uc.ImportCalibreLibrary(filepath.Join("..", "calibre", "testdata", "library"))
+	if err != nil {
+		t.Fatalf("ImportCalibreLibrary: %v", err)
+	}
+	if result.Created != 2 || result.Updated != 0 || len(result.Failures) != 1 {
+		t.Fatalf("result = %+v, want 2 created and the broken OPF failed", result)
+	}
+	if len(copies.copies) != 2 {
+		t.Errorf("%d copies created, want one per book", len(copies.copies))
+	}
+	// Sem a coluna #pages o livro entra com o número de páginas em branco.
+	leGuin, err := books.GetByIdentifier(domain.IdentifierCalibreUUID, "9c8b7a6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d")
+	if err != nil || leGuin.Title != "A Mão Esquerda da Escuridão" || leGuin.Pages != 0 {
+		t.Errorf("book = %+v, %v; want A Mão Esquerda da Escuridão without pages", leGuin, err)
+	}
+}
+
+func TestImportMARCKeepsOtherRoles(t *testing.T) {
+	existing := &domain.Book{
+		ID:     "b1",
+		Title:  "Cem Anos de Solidão",
+		Author: "Gabriel García Márquez",
+		Pages:  448,
+		ISBN13: "9788501012074",
+		Contributors: []domain.Contributor{
+			{Name: "Gabriel García Márquez", Role: domain.RoleAuthor, Order: 1, AuthorID: "a1"},
+			{Name: "Eric Nepomuceno", Role: domain.RoleTranslator, Order: 2},
+		},
+	}
+	books := newFakeBookRepo(existing)
+	uc, _ := newTestImportUseCase(books)
+
+	input := marcXML(t, &domain.Book{
+		Title:  "Cem Anos de Solidão",
+		Author: "Gabriel García Márquez",
+		ISBN13: "9788501012074",
+		Contributors: []domain.Contributor{
+			{Name: "Gabriel García Márquez", Role: domain.RoleAuthor, Order: 1},
+			{Name: "Eric Nepomuceno", Role: domain.RoleTranslator, Order: 2},
+			{Name: "Ferreira Gullar", Role: domain.RoleForeword, Order: 3},
+		},
+	})
+	if _, err := uc.ImportMARC(input); err != nil {
+		t.Fatalf("ImportMARC: %v", err)
+	}
+	want := []domain.Contributor{
+		{Name: "Gabriel García Márquez", Role: domain.RoleAuthor, Order: 1, AuthorID: "a1"},
+		{Name: "Eric Nepomuceno", Role: domain.RoleTranslator, Order: 2},
+		{Name: "Ferreira Gullar", Role: domain.RoleForeword, Order: 3},
+	}
+	if !reflect.DeepEqual(existing.Contributors, want) {
+		t.Errorf("contributors = %+v, want %+v", existing.Contributors, want)
+	}
+}
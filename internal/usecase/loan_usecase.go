@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+var (
+	ErrBookUnavailable = errors.New("every copy of the book is lent")
+)
+
+// LoanDetails is a loan with the book lent and how late it is.
+type LoanDetails struct {
+	*domain.Loan
+	Book        *domain.Book `json:"book,omitempty"`
+	Overdue     bool         `json:"overdue"`
+	DaysOverdue int          `json:"days_overdue,omitempty"`
+}
+
+type LoanUseCase interface {
+	// Lend lends a copy of a book. Without a copy ID, any copy on hand is lent.
+	Lend(loan *domain.Loan) error
+	// Return marks a loan as returned today.
+	Return(id string) (*domain.Loan, error)
+	GetLoan(id string) (*LoanDetails, error)
+	// GetActiveLoans returns the books currently lent, the soonest due first.
+	GetActiveLoans() ([]*LoanDetails, error)
+	// GetOverdueLoans returns the books lent past their due date, the most
+	// overdue first.
+	GetOverdueLoans() ([]*LoanDetails, error)
+	// GetBorrowerLoans returns the history of a borrower, the most recent first.
+	GetBorrowerLoans(borrower string) ([]*LoanDetails, error)
+}
+
+type loanUseCase struct {
+	loanRepo repository.LoanRepository
+	bookRepo repository.BookRepository
+	copyRepo repository.CopyRepository
+}
+
+func NewLoanUseCase(lr repository.LoanRepository, br repository.BookRepository, cr repository.CopyRepository) LoanUseCase {
+	return &loanUseCase{
+		loanRepo: lr,
+		bookRepo: br,
+		copyRepo: cr,
+	}
+}
+
+func (uc *loanUseCase) Lend(loan *domain.Loan) error {
+	if loan.LentAt.IsZero() {
+		loan.LentAt = time.Now()
+	}
+	if err := validator.ValidateLoan(loan); err != nil {
+		return err
+	}
+
+	if loan.CopyID != "" {
+		c, err := uc.copyRepo.GetByID(loan.CopyID)
+		if errors.Is(err, repository.ErrCopyNotFound) {
+			return fmt.Errorf("%w: copy %s not found", validator.ErrInvalidLoanData, loan.CopyID)
+		}
+		if err != nil {
+			return err
+		}
+		if loan.BookID != "" && loan.BookID != c.BookID {
+			return fmt.Errorf("%w: copy %s is not a copy of book %s", validator.ErrInvalidLoanData, c.ID, loan.BookID)
+		}
+		loan.BookID = c.BookID
+	} else if loan.BookID == "" {
+		return fmt.Errorf("%w: book_id or copy_id is required", validator.ErrInvalidLoanData)
+	}
+
+	book, err := uc.bookRepo.GetByID(loan.BookID)
+	if err != nil {
+		return err
+	}
+	if !book.InLibrary() {
+		return ErrBookDisposed
+	}
+	if loan.CopyID == "" {
+		if loan.CopyID, err = uc.copyOnHand(loan.BookID); err != nil {
+			return err
+		}
+	}
+
+	loan.Status, loan.ReturnedAt = domain.LoanActive, nil
+	if err := uc.loanRepo.Create(loan); err != nil {
+		return err
+	}
+	return uc.updateStatus(book)
+}
+
+// copyOnHand returns a copy of the book that is not lent.
+func (uc *loanUseCase) copyOnHand(bookID string) (string, error) {
+	copies, err := uc.copyRepo.GetByBookIDs([]string{bookID})
+	if err != nil {
+		return "", err
+	}
+	loans, err := uc.loanRepo.GetActiveByBookID(bookID)
+	if err != nil {
+		return "", err
+	}
+	lent := make(map[string]bool, len(loans))
+	for _, l := range loans {
+		lent[l.CopyID] = true
+	}
+	for _, c := range copies {
+		if !lent[c.ID] {
+			return c.ID, nil
+		}
+	}
+	return "", ErrBookUnavailable
+}
+
+func (uc *loanUseCase) Return(id string) (*domain.Loan, error) {
+	if err := uc.loanRepo.Return(id, time.Now()); err != nil {
+		return nil, err
+	}
+	loan, err := uc.loanRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := uc.bookRepo.GetByID(loan.BookID)
+	if errors.Is(err, repository.ErrBookNotFound) {
+		return loan, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return loan, uc.updateStatus(book)
+}
+
+// updateStatus marks a book in the library as lent while every copy of it
+// is lent, and as owned otherwise.
+func (uc *loanUseCase) updateStatus(book *domain.Book) error {
+	if !book.InLibrary() {
+		return nil
+	}
+	a, err := availability(uc.loanRepo, uc.copyRepo, book.ID)
+	if err != nil {
+		return err
+	}
+	status := domain.BookOwned
+	if !a.Available && a.Lent > 0 {
+		status = domain.BookLent
+	}
+	if status == book.Status {
+		return nil
+	}
+	return uc.bookRepo.SetStatus(book.ID, status, nil)
+}
+
+func (uc *loanUseCase) GetLoan(id string) (*LoanDetails, error) {
+	loan, err := uc.loanRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	details, err := uc.details([]*domain.Loan{loan})
+	if err != nil {
+		return nil, err
+	}
+	return details[0], nil
+}
+
+func (uc *loanUseCase) GetActiveLoans() ([]*LoanDetails, error) {
+	loans, err := uc.loanRepo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+	return uc.details(loans)
+}
+
+func (uc *loanUseCase) GetOverdueLoans() ([]*LoanDetails, error) {
+	loans, err := uc.loanRepo.GetOverdue(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return uc.details(loans)
+}
+
+func (uc *loanUseCase) GetBorrowerLoans(borrower string) ([]*LoanDetails, error) {
+	loans, err := uc.loanRepo.GetByBorrower(borrower)
+	if err != nil {
+		return nil, err
+	}
+	return uc.details(loans)
+}
+
+// details joins the loans with their books. Books deleted since they were
+// lent are left out of the details, but the loans are kept.
+func (uc *loanUseCase) details(loans []*domain.Loan) ([]*LoanDetails, error) {
+	ids := make([]string, len(loans))
+	for i, l := range loans {
+		ids[i] = l.BookID
+	}
+	books, err := uc.bookRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domain.Book, len(books))
+	for _, book := range books {
+		byID[book.ID] = book
+	}
+
+	now := time.Now()
+	details := make([]*LoanDetails, 0, len(loans))
+	for _, l := range loans {
+		d := &LoanDetails{Loan: l, Book: byID[l.BookID], Overdue: l.IsOverdue(now)}
+		if d.Overdue {
+			d.DaysOverdue = int(now.Sub(l.DueAt).Hours() / 24)
+		}
+		details = append(details, d)
+	}
+	return details, nil
+}
+
+// availability tells whether a book has a copy that is not lent.
+func availability(loanRepo repository.LoanRepository, copyRepo repository.CopyRepository, bookID string) (*domain.Availability, error) {
+	copies, err := copyRepo.GetByBookIDs([]string{bookID})
+	if err != nil {
+		return nil, err
+	}
+	loans, err := loanRepo.GetActiveByBookID(bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &domain.Availability{Copies: len(copies), Lent: len(loans)}
+	a.Available = a.Lent < a.Copies
+	if len(loans) > 0 {
+		// Os empréstimos vêm ordenados pela data de devolução.
+		a.DueAt = &loans[0].DueAt
+	}
+	return a, nil
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+// newShelf returns b1 with two copies, one of them lent, b2 with a single
+// copy and b3, which was sold.
+func newShelf() (*fakeLoanRepo, *fakeBookRepo, *fakeCopyRepo) {
+	loans := &fakeLoanRepo{loans: []*domain.Loan{
+		{ID: "l1", BookID: "b1", CopyID: "c1", Borrower: "Ana", Status: domain.LoanActive, DueAt: time.Now().AddDate(0, 0, 7)},
+	}}
+	books := newFakeBookRepo(
+		&domain.Book{ID: "b1", Title: "O Cortiço", Status: domain.BookOwned},
+		&domain.Book{ID: "b2", Title: "Iracema", Status: domain.BookOwned},
+		&domain.Book{ID: "b3", Title: "Senhora", Status: domain.BookSold},
+	)
+	copies := &fakeCopyRepo{copies: []*domain.Copy{
+		{ID: "c1", BookID: "b1"},
+		{ID: "c2", BookID: "b1"},
+		{ID: "c3", BookID: "b2"},
+		{ID: "c4", BookID: "b3"},
+	}}
+	return loans, books, copies
+}
+
+func TestLend(t *testing.T) {
+	due := time.Now().AddDate(0, 0, 14)
+	tests := []struct {
+		name       string
+		loan       domain.Loan
+		wantErr    error
+		wantCopy   string
+		wantStatus string
+	}{
+		{name: "any copy on hand", loan: domain.Loan{BookID: "b1", Borrower: " Bruno  Lima ", DueAt: due}, wantCopy: "c2", wantStatus: domain.BookLent},
+		{name: "by copy", loan: domain.Loan{CopyID: "c3", Borrower: "Bruno", DueAt: due}, wantCopy: "c3", wantStatus: domain.BookLent},
+		{name: "copy already lent", loan: domain.Loan{CopyID: "c1", Borrower: "Bruno", DueAt: due}, wantErr: repository.ErrCopyOnLoan, wantStatus: domain.BookOwned},
+		{name: "copy of another book", loan: domain.Loan{BookID: "b2", CopyID: "c2", Borrower: "Bruno", DueAt: due}, wantErr: validator.ErrInvalidLoanData, wantStatus: domain.BookOwned},
+		{name: "unknown copy", loan: domain.Loan{CopyID: "c9", Borrower: "Bruno", DueAt: due}, wantErr: validator.ErrInvalidLoanData, wantStatus: domain.BookOwned},
+		{name: "no book", loan: domain.Loan{Borrower: "Bruno", DueAt: due}, wantErr: validator.ErrInvalidLoanData, wantStatus: domain.BookOwned},
+		{name: "no borrower", loan: domain.Loan{BookID: "b1", DueAt: due}, wantErr: validator.ErrInvalidLoanData, wantStatus: domain.BookOwned},
+		{name: "due before lent", loan: domain.Loan{BookID: "b1", Borrower: "Bruno", LentAt: due, DueAt: due.AddDate(0, 0, -1)}, wantErr: validator.ErrInvalidLoanData, wantStatus: domain.BookOwned},
+		{name: "sold book", loan: domain.Loan{BookID: "b3", Borrower: "Bruno", DueAt: due}, wantErr: ErrBookDisposed, wantStatus: domain.BookSold},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loans, books, copies := newShelf()
+			uc := NewLoanUseCase(loans, books, copies)
+
+			loan := tt.loan
+			if err := uc.Lend(&loan); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Lend error = %v, want %v", err, tt.wantErr)
+			}
+			c, _ := copies.GetByID(loan.CopyID)
+			bookID := loan.BookID
+			if c != nil {
+				bookID = c.BookID
+			}
+			if book, err := books.GetByID(bookID); err == nil && book.Status != tt.wantStatus {
+				t.Errorf("book status = %s, want %s", book.Status, tt.wantStatus)
+			}
+			if tt.wantErr != nil {
+				if len(loans.loans) != 1 {
+					t.Errorf("%d loans, want the refused one left out", len(loans.loans))
+				}
+				return
+			}
+			if loan.CopyID != tt.wantCopy || loan.Status != domain.LoanActive || loan.LentAt.IsZero() {
+				t.Errorf("loan = %+v, want copy %s active from today", loan, tt.wantCopy)
+			}
+			if loan.Borrower != strings.Join(strings.Fields(tt.loan.Borrower), " ") {
+				t.Errorf("borrower = %q, want the spaces tidied", loan.Borrower)
+			}
+		})
+	}
+}
+
+func TestLendEveryCopy(t *testing.T) {
+	loans, books, copies := newShelf()
+	uc := NewLoanUseCase(loans, books, copies)
+
+	due := time.Now().AddDate(0, 0, 14)
+	if err := uc.Lend(&domain.Loan{BookID: "b1", Borrower: "Bruno", DueAt: due}); err != nil {
+		t.Fatalf("Lend: %v", err)
+	}
+	if err := uc.Lend(&domain.Loan{BookID: "b1", Borrower: "Carla", DueAt: due}); !errors.Is(err, ErrBookUnavailable) {
+		t.Errorf("Lend with every copy lent error = %v, want %v", err, ErrBookUnavailable)
+	}
+}
+
+func TestReturn(t *testing.T) {
+	loans, books, copies := newShelf()
+	books.books["b1"].Status = domain.BookLent
+	loans.loans = append(loans.loans, &domain.Loan{ID: "l2", BookID: "b1", CopyID: "c2", Borrower: "Bruno", Status: domain.LoanActive, DueAt: time.Now()})
+	uc := NewLoanUseCase(loans, books, copies)
+
+	loan, err := uc.Return("l2")
+	if err != nil {
+		t.Fatalf("Return: %v", err)
+	}
+	if loan.Status != domain.LoanReturned || loan.ReturnedAt == nil {
+		t.Errorf("loan = %+v, want it returned", loan)
+	}
+	if got := books.books["b1"].Status; got != domain.BookOwned {
+		t.Errorf("book status = %s, want %s once a copy is back", got, domain.BookOwned)
+	}
+
+	if _, err := uc.Return("l2"); !errors.Is(err, repository.ErrLoanReturned) {
+		t.Errorf("Return twice error = %v, want %v", err, repository.ErrLoanReturned)
+	}
+	if _, err := uc.Return("l9"); !errors.Is(err, repository.ErrLoanNotFound) {
+		t.Errorf("Return of an unknown loan error = %v, want %v", err, repository.ErrLoanNotFound)
+	}
+
+	// O livro pode ter sido excluído enquanto estava emprestado.
+	delete(books.books, "b1")
+	if _, err := uc.Return("l1"); err != nil {
+		t.Errorf("Return of a deleted book: %v", err)
+	}
+}
+
+func TestGetOverdueLoans(t *testing.T) {
+	loans, books, copies := newShelf()
+	now := time.Now()
+	loans.loans = append(loans.loans,
+		&domain.Loan{ID: "l2", BookID: "b1", CopyID: "c2", Borrower: "Bruno", Status: domain.LoanActive, DueAt: now.AddDate(0, 0, -3)},
+		&domain.Loan{ID: "l3", BookID: "b9", CopyID: "c9", Borrower: "Carla", Status: domain.LoanActive, DueAt: now.AddDate(0, 0, -10)},
+	)
+	uc := NewLoanUseCase(loans, books, copies)
+
+	overdue, err := uc.GetOverdueLoans()
+	if err != nil {
+		t.Fatalf("GetOverdueLoans: %v", err)
+	}
+	if len(overdue) != 2 {
+		t.Fatalf("%d overdue loans, want 2", len(overdue))
+	}
+	tests := []struct {
+		id       string
+		wantDays int
+		wantBook bool
+	}{
+		{id: "l3", wantDays: 10},
+		{id: "l2", wantDays: 3, wantBook: true},
+	}
+	for i, tt := range tests {
+		d := overdue[i]
+		if d.ID != tt.id || !d.Overdue || d.DaysOverdue != tt.wantDays || (d.Book != nil) != tt.wantBook {
+			t.Errorf("overdue[%d] = %s, %d days, book %v; want %s, %d days, book %v",
+				i, d.ID, d.DaysOverdue, d.Book != nil, tt.id, tt.wantDays, tt.wantBook)
+		}
+	}
+
+	active, err := uc.GetActiveLoans()
+	if err != nil || len(active) != 3 || active[2].ID != "l1" || active[2].Overdue {
+		t.Errorf("GetActiveLoans = %d loans, %v; want l1 last and not overdue", len(active), err)
+	}
+}
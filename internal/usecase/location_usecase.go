@@ -0,0 +1,462 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+var (
+	ErrLocationCycle  = errors.New("a location cannot be moved into itself or a location below it")
+	ErrLocationInUse  = errors.New("the location still holds copies or other locations")
+	ErrBookNotPlaced  = errors.New("no copy of the book has a location")
+	ErrRelocateToSelf = errors.New("copies cannot be relocated to the location they are in")
+)
+
+// LocationNode is a location of the tree with the locations inside it.
+type LocationNode struct {
+	*domain.Location
+	Children []*LocationNode `json:"children,omitempty"`
+}
+
+// LocationDetails is a location with the path leading to it.
+type LocationDetails struct {
+	*domain.Location
+	Path  []*domain.Location `json:"path"`
+	Label string             `json:"label"`
+}
+
+// CopyWhereabouts tells where a copy of a book is kept: the path of
+// locations from the outermost down and a label such as "Home > Study >
+// Bookcase 2 > Shelf 3 > position 14". Both are empty for copies without a
+// location, and Copy is nil for books without copies.
+type CopyWhereabouts struct {
+	Copy  *domain.Copy       `json:"copy,omitempty"`
+	Book  *domain.Book       `json:"book"`
+	Path  []*domain.Location `json:"path,omitempty"`
+	Label string             `json:"label,omitempty"`
+}
+
+type LocationUseCase interface {
+	CreateLocation(location *domain.Location) error
+	// GetLocationTree returns the outermost locations with the locations inside them.
+	GetLocationTree() ([]*LocationNode, error)
+	GetLocation(id string) (*LocationDetails, error)
+	// UpdateLocation replaces a location. Changing its parent moves it along
+	// with everything inside it, copies included.
+	UpdateLocation(location *domain.Location) error
+	// DeleteLocation removes an empty location.
+	DeleteLocation(id string) error
+	// GetLocationCopies returns the copies kept in a location or in any
+	// location inside it.
+	GetLocationCopies(id string) ([]*CopyWhereabouts, error)
+	// RelocateCopies moves every copy kept in fromID to toID, keeping their
+	// positions, and returns the number of copies moved.
+	RelocateCopies(fromID, toID string) (int, error)
+	// WhereIs tells where each copy of a book is kept.
+	WhereIs(bookID string) ([]*CopyWhereabouts, error)
+	// FindBooks searches books and tells where each of their copies is kept.
+	FindBooks(filter domain.BookFilter) ([]*CopyWhereabouts, error)
+	PlaceCopy(copyID string, location domain.BookLocation) (*CopyWhereabouts, error)
+	UnplaceCopy(copyID string) error
+}
+
+type locationUseCase struct {
+	locationRepo repository.LocationRepository
+	copyRepo     repository.CopyRepository
+	bookRepo     repository.BookRepository
+}
+
+func NewLocationUseCase(lr repository.LocationRepository, cr repository.CopyRepository, br repository.BookRepository) LocationUseCase {
+	return &locationUseCase{
+		locationRepo: lr,
+		copyRepo:     cr,
+		bookRepo:     br,
+	}
+}
+
+func (uc *locationUseCase) CreateLocation(location *domain.Location) error {
+	if err := validator.ValidateLocation(location); err != nil {
+		return err
+	}
+	location.Description = strings.TrimSpace(location.Description)
+	ancestors, err := uc.ancestors(location)
+	if err != nil {
+		return err
+	}
+	location.Ancestors = ancestors
+	return uc.locationRepo.Create(location)
+}
+
+// ancestors checks the parent of a location and returns the ancestors the
+// location gets below it.
+func (uc *locationUseCase) ancestors(location *domain.Location) ([]string, error) {
+	if location.ParentID == "" {
+		return nil, nil
+	}
+	parent, err := uc.locationRepo.GetByID(location.ParentID)
+	if errors.Is(err, repository.ErrLocationNotFound) {
+		return nil, fmt.Errorf("%w: parent %s not found", validator.ErrInvalidLocationData, location.ParentID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if domain.LocationDepth(location.Kind) <= domain.LocationDepth(parent.Kind) {
+		return nil, fmt.Errorf("%w: a %s cannot be inside a %s", validator.ErrInvalidLocationData, location.Kind, parent.Kind)
+	}
+	return append(append([]string{}, parent.Ancestors...), parent.ID), nil
+}
+
+func (uc *locationUseCase) GetLocationTree() ([]*LocationNode, error) {
+	locations, err := uc.locationRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	// Os pais vêm antes dos filhos; a ordem por nome se mantém entre irmãos.
+	sort.SliceStable(locations, func(i, j int) bool {
+		return len(locations[i].Ancestors) < len(locations[j].Ancestors)
+	})
+
+	roots := []*LocationNode{}
+	nodes := make(map[string]*LocationNode)
+	for _, location := range locations {
+		node := &LocationNode{Location: location}
+		nodes[location.ID] = node
+		if parent, ok := nodes[location.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots, nil
+}
+
+func (uc *locationUseCase) GetLocation(id string) (*LocationDetails, error) {
+	location, err := uc.locationRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := withAncestors(uc.locationRepo, []*domain.Location{location})
+	if err != nil {
+		return nil, err
+	}
+	path := locationPath(locations, id)
+	return &LocationDetails{Location: location, Path: path, Label: domain.LocationLabel(path, 0)}, nil
+}
+
+func (uc *locationUseCase) UpdateLocation(location *domain.Location) error {
+	if err := validator.ValidateLocation(location); err != nil {
+		return err
+	}
+	location.Description = strings.TrimSpace(location.Description)
+	existing, err := uc.locationRepo.GetByID(location.ID)
+	if err != nil {
+		return err
+	}
+	if location.ParentID == location.ID {
+		return ErrLocationCycle
+	}
+	ancestors, err := uc.ancestors(location)
+	if err != nil {
+		return err
+	}
+	for _, id := range ancestors {
+		if id == location.ID {
+			return ErrLocationCycle
+		}
+	}
+	location.Ancestors = ancestors
+
+	descendants, err := uc.locationRepo.GetDescendants(location.ID)
+	if err != nil {
+		return err
+	}
+	for _, d := range descendants {
+		if d.ParentID == location.ID && domain.LocationDepth(d.Kind) <= domain.LocationDepth(location.Kind) {
+			return fmt.Errorf("%w: a %s cannot hold a %s", validator.ErrInvalidLocationData, location.Kind, d.Kind)
+		}
+	}
+
+	if err := uc.locationRepo.Update(location); err != nil {
+		return err
+	}
+	if location.ParentID == existing.ParentID {
+		return nil
+	}
+	// Os exemplares referenciam só a localização em que estão, então basta
+	// atualizar os ancestrais das localizações de dentro.
+	for _, d := range descendants {
+		for i, id := range d.Ancestors {
+			if id == location.ID {
+				d.Ancestors = append(append(append([]string{}, ancestors...), location.ID), d.Ancestors[i+1:]...)
+				break
+			}
+		}
+		if err := uc.locationRepo.Update(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *locationUseCase) DeleteLocation(id string) error {
+	if _, err := uc.locationRepo.GetByID(id); err != nil {
+		return err
+	}
+	descendants, err := uc.locationRepo.GetDescendants(id)
+	if err != nil {
+		return err
+	}
+	copies, err := uc.copyRepo.FindByLocations([]string{id})
+	if err != nil {
+		return err
+	}
+	if len(descendants) > 0 || len(copies) > 0 {
+		return ErrLocationInUse
+	}
+	return uc.locationRepo.Delete(id)
+}
+
+// GetLocationCopies returns the copies ordered by location and then by
+// position and title.
+func (uc *locationUseCase) GetLocationCopies(id string) ([]*CopyWhereabouts, error) {
+	if _, err := uc.locationRepo.GetByID(id); err != nil {
+		return nil, err
+	}
+	descendants, err := uc.locationRepo.GetDescendants(id)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{id}
+	for _, d := range descendants {
+		ids = append(ids, d.ID)
+	}
+	copies, err := uc.copyRepo.FindByLocations(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := copyWhereabouts(uc.locationRepo, uc.bookRepo, copies)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		a, b := found[i], found[j]
+		if la, lb := domain.LocationLabel(a.Path, 0), domain.LocationLabel(b.Path, 0); la != lb {
+			return la < lb
+		}
+		if a.Copy.Location.Position != b.Copy.Location.Position {
+			return a.Copy.Location.Position < b.Copy.Location.Position
+		}
+		return a.Book.Title < b.Book.Title
+	})
+	return found, nil
+}
+
+func (uc *locationUseCase) RelocateCopies(fromID, toID string) (int, error) {
+	if fromID == toID {
+		return 0, ErrRelocateToSelf
+	}
+	if _, err := uc.locationRepo.GetByID(fromID); err != nil {
+		return 0, err
+	}
+	if _, err := uc.locationRepo.GetByID(toID); err != nil {
+		return 0, err
+	}
+	return uc.copyRepo.Relocate(fromID, toID)
+}
+
+// WhereIs lists the copies of a book, reporting ErrBookNotPlaced when none
+// of them has a location.
+func (uc *locationUseCase) WhereIs(bookID string) ([]*CopyWhereabouts, error) {
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, err
+	}
+	found, err := whereabouts(uc.locationRepo, uc.copyRepo, []*domain.Book{book})
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range found {
+		if w.Copy != nil && w.Copy.Location != nil {
+			return found, nil
+		}
+	}
+	return nil, ErrBookNotPlaced
+}
+
+func (uc *locationUseCase) FindBooks(filter domain.BookFilter) ([]*CopyWhereabouts, error) {
+	books, err := uc.bookRepo.Search(filter)
+	if err != nil {
+		return nil, err
+	}
+	return whereabouts(uc.locationRepo, uc.copyRepo, books)
+}
+
+func (uc *locationUseCase) PlaceCopy(copyID string, location domain.BookLocation) (*CopyWhereabouts, error) {
+	if location.Position < 0 {
+		return nil, fmt.Errorf("%w: position must not be negative", validator.ErrInvalidLocationData)
+	}
+	if _, err := uc.locationRepo.GetByID(location.LocationID); err != nil {
+		return nil, err
+	}
+	if err := uc.copyRepo.SetLocation(copyID, &location); err != nil {
+		return nil, err
+	}
+	c, err := uc.copyRepo.GetByID(copyID)
+	if err != nil {
+		return nil, err
+	}
+	found, err := copyWhereabouts(uc.locationRepo, uc.bookRepo, []*domain.Copy{c})
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, repository.ErrBookNotFound
+	}
+	return found[0], nil
+}
+
+func (uc *locationUseCase) UnplaceCopy(copyID string) error {
+	return uc.copyRepo.SetLocation(copyID, nil)
+}
+
+// whereabouts lists the copies of the books, in the order of the books, with
+// an entry without copy for each book that has none.
+func whereabouts(locationRepo repository.LocationRepository, copyRepo repository.CopyRepository, books []*domain.Book) ([]*CopyWhereabouts, error) {
+	ids := make([]string, len(books))
+	for i, book := range books {
+		ids[i] = book.ID
+	}
+	copies, err := copyRepo.GetByBookIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byBook := make(map[string][]*domain.Copy)
+	for _, c := range copies {
+		byBook[c.BookID] = append(byBook[c.BookID], c)
+	}
+	paths, err := copyPaths(locationRepo, copies)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*CopyWhereabouts, 0, len(copies))
+	for _, book := range books {
+		if len(byBook[book.ID]) == 0 {
+			result = append(result, &CopyWhereabouts{Book: book})
+		}
+		for _, c := range byBook[book.ID] {
+			result = append(result, paths.whereabouts(c, book))
+		}
+	}
+	return result, nil
+}
+
+// copyWhereabouts resolves the book and location path of each copy, dropping
+// copies whose book no longer exists.
+func copyWhereabouts(locationRepo repository.LocationRepository, bookRepo repository.BookRepository, copies []*domain.Copy) ([]*CopyWhereabouts, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, c := range copies {
+		if !seen[c.BookID] {
+			seen[c.BookID] = true
+			ids = append(ids, c.BookID)
+		}
+	}
+	books, err := bookRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domain.Book, len(books))
+	for _, book := range books {
+		byID[book.ID] = book
+	}
+	paths, err := copyPaths(locationRepo, copies)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*CopyWhereabouts, 0, len(copies))
+	for _, c := range copies {
+		if book, ok := byID[c.BookID]; ok {
+			result = append(result, paths.whereabouts(c, book))
+		}
+	}
+	return result, nil
+}
+
+// locationIndex holds the locations of a set of copies with their ancestors.
+type locationIndex map[string]*domain.Location
+
+// copyPaths loads every location holding one of the copies at once.
+func copyPaths(locationRepo repository.LocationRepository, copies []*domain.Copy) (locationIndex, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, c := range copies {
+		if c.Location != nil && !seen[c.Location.LocationID] {
+			seen[c.Location.LocationID] = true
+			ids = append(ids, c.Location.LocationID)
+		}
+	}
+	locations, err := locationRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	return withAncestors(locationRepo, locations)
+}
+
+func (idx locationIndex) whereabouts(c *domain.Copy, book *domain.Book) *CopyWhereabouts {
+	w := &CopyWhereabouts{Copy: c, Book: book}
+	if c.Location != nil {
+		w.Path = locationPath(idx, c.Location.LocationID)
+		w.Label = domain.LocationLabel(w.Path, c.Location.Position)
+	}
+	return w
+}
+
+// withAncestors indexes the locations by ID along with their ancestors.
+func withAncestors(locationRepo repository.LocationRepository, locations []*domain.Location) (map[string]*domain.Location, error) {
+	byID := make(map[string]*domain.Location)
+	var missing []string
+	for _, l := range locations {
+		byID[l.ID] = l
+	}
+	for _, l := range locations {
+		for _, id := range l.Ancestors {
+			if _, ok := byID[id]; !ok {
+				byID[id] = nil
+				missing = append(missing, id)
+			}
+		}
+	}
+
+	ancestors, err := locationRepo.GetByIDs(missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range ancestors {
+		byID[l.ID] = l
+	}
+	return byID, nil
+}
+
+// locationPath returns the location with its ancestors, from the outermost down.
+func locationPath(byID map[string]*domain.Location, id string) []*domain.Location {
+	location := byID[id]
+	if location == nil {
+		return nil
+	}
+	var path []*domain.Location
+	for _, ancestorID := range location.Ancestors {
+		if ancestor := byID[ancestorID]; ancestor != nil {
+			path = append(path, ancestor)
+		}
+	}
+	return append(path, location)
+}
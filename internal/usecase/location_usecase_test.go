@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+// newHome returns a house with a study holding a bookcase with a shelf, and
+// an empty living room. Copy c1 of b1 sits on the shelf, c2 of b2 in the
+// bookcase, and c3 of b3 and c4 of b1 have no location.
+func newHome() (*fakeLocationRepo, *fakeCopyRepo, *fakeBookRepo) {
+	locations := &fakeLocationRepo{locations: []*domain.Location{
+		{ID: "home", Name: "Home", Kind: domain.LocationHouse},
+		{ID: "study", Name: "Study", Kind: domain.LocationRoom, ParentID: "home", Ancestors: []string{"home"}},
+		{ID: "living", Name: "Living room", Kind: domain.LocationRoom, ParentID: "home", Ancestors: []string{"home"}},
+		{ID: "case", Name: "Bookcase 2", Kind: domain.LocationBookcase, ParentID: "study", Ancestors: []string{"home", "study"}},
+		{ID: "shelf", Name: "Shelf 3", Kind: domain.LocationShelf, ParentID: "case", Ancestors: []string{"home", "study", "case"}},
+	}}
+	copies := &fakeCopyRepo{copies: []*domain.Copy{
+		{ID: "c1", BookID: "b1", Location: &domain.BookLocation{LocationID: "shelf", Position: 14}},
+		{ID: "c2", BookID: "b2", Location: &domain.BookLocation{LocationID: "case"}},
+		{ID: "c3", BookID: "b3"},
+		{ID: "c4", BookID: "b1"},
+	}}
+	books := newFakeBookRepo(
+		&domain.Book{ID: "b1", Title: "Grande Sertão: Veredas"},
+		&domain.Book{ID: "b2", Title: "Vidas Secas"},
+		&domain.Book{ID: "b3", Title: "Macunaíma"},
+	)
+	return locations, copies, books
+}
+
+func TestCreateLocation(t *testing.T) {
+	tests := []struct {
+		name          string
+		location      domain.Location
+		wantErr       error
+		wantAncestors string
+	}{
+		{name: "outermost", location: domain.Location{Name: "Beach house", Kind: domain.LocationHouse}},
+		{name: "inside", location: domain.Location{Name: "Shelf 4", Kind: domain.LocationShelf, ParentID: "case"}, wantAncestors: "home study case"},
+		{name: "skipping levels", location: domain.Location{Name: "Hall bookcase", Kind: domain.LocationBookcase, ParentID: "home"}, wantAncestors: "home"},
+		{name: "inside a smaller kind", location: domain.Location{Name: "Attic", Kind: domain.LocationRoom, ParentID: "case"}, wantErr: validator.ErrInvalidLocationData},
+		{name: "inside the same kind", location: domain.Location{Name: "Nook", Kind: domain.LocationShelf, ParentID: "shelf"}, wantErr: validator.ErrInvalidLocationData},
+		{name: "unknown parent", location: domain.Location{Name: "Shelf 4", Kind: domain.LocationShelf, ParentID: "nowhere"}, wantErr: validator.ErrInvalidLocationData},
+		{name: "unknown kind", location: domain.Location{Name: "Box", Kind: "box"}, wantErr: validator.ErrInvalidLocationData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locations, copies, books := newHome()
+			uc := NewLocationUseCase(locations, copies, books)
+
+			location := tt.location
+			err := uc.CreateLocation(&location)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateLocation error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && strings.Join(location.Ancestors, " ") != tt.wantAncestors {
+				t.Errorf("ancestors = %v, want %s", location.Ancestors, tt.wantAncestors)
+			}
+		})
+	}
+}
+
+func TestUpdateLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		location domain.Location
+		wantErr  error
+		// wantAncestors maps locations to their ancestors after the update.
+		wantAncestors map[string]string
+	}{
+		{
+			name:          "move with everything inside",
+			location:      domain.Location{ID: "case", Name: "Bookcase 2", Kind: domain.LocationBookcase, ParentID: "living"},
+			wantAncestors: map[string]string{"case": "home living", "shelf": "home living case", "study": "home"},
+		},
+		{
+			name:          "rename in place",
+			location:      domain.Location{ID: "study", Name: "Office", Kind: domain.LocationRoom, ParentID: "home"},
+			wantAncestors: map[string]string{"study": "home", "shelf": "home study case"},
+		},
+		{
+			name:     "into a location inside it",
+			location: domain.Location{ID: "study", Name: "Study", Kind: domain.LocationRoom, ParentID: "shelf"},
+			wantErr:  validator.ErrInvalidLocationData,
+		},
+		{
+			name:     "into itself",
+			location: domain.Location{ID: "case", Name: "Bookcase 2", Kind: domain.LocationBookcase, ParentID: "case"},
+			wantErr:  ErrLocationCycle,
+		},
+		{
+			name:     "to a kind that cannot hold its children",
+			location: domain.Location{ID: "case", Name: "Bookcase 2", Kind: domain.LocationShelf, ParentID: "study"},
+			wantErr:  validator.ErrInvalidLocationData,
+		},
+		{
+			name:     "unknown",
+			location: domain.Location{ID: "attic", Name: "Attic", Kind: domain.LocationRoom},
+			wantErr:  repository.ErrLocationNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locations, copies, books := newHome()
+			uc := NewLocationUseCase(locations, copies, books)
+
+			location := tt.location
+			if err := uc.UpdateLocation(&location); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateLocation error = %v, want %v", err, tt.wantErr)
+			}
+			for id, want := range tt.wantAncestors {
+				l, _ := locations.GetByID(id)
+				if got := strings.Join(l.Ancestors, " "); got != want {
+					t.Errorf("%s ancestors = %s, want %s", id, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteLocation(t *testing.T) {
+	tests := []struct {
+		id      string
+		wantErr error
+	}{
+		{id: "shelf", wantErr: ErrLocationInUse},
+		{id: "study", wantErr: ErrLocationInUse},
+		{id: "living"},
+		{id: "attic", wantErr: repository.ErrLocationNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			locations, copies, books := newHome()
+			uc := NewLocationUseCase(locations, copies, books)
+
+			if err := uc.DeleteLocation(tt.id); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteLocation error = %v, want %v", err, tt.wantErr)
+			}
+			_, err := locations.GetByID(tt.id)
+			if gone := errors.Is(err, repository.ErrLocationNotFound); gone == (tt.wantErr == ErrLocationInUse) {
+				t.Errorf("location gone = %v after DeleteLocation", gone)
+			}
+		})
+	}
+}
+
+func TestWhereIs(t *testing.T) {
+	tests := []struct {
+		bookID     string
+		wantErr    error
+		wantLabels []string
+	}{
+		{bookID: "b1", wantLabels: []string{"c1: Home > Study > Bookcase 2 > Shelf 3 > position 14", "c4: "}},
+		{bookID: "b2", wantLabels: []string{"c2: Home > Study > Bookcase 2"}},
+		{bookID: "b3", wantErr: ErrBookNotPlaced},
+		{bookID: "b9", wantErr: repository.ErrBookNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.bookID, func(t *testing.T) {
+			locations, copies, books := newHome()
+			uc := NewLocationUseCase(locations, copies, books)
+
+			found, err := uc.WhereIs(tt.bookID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WhereIs error = %v, want %v", err, tt.wantErr)
+			}
+			if got := labels(found); strings.Join(got, "\n") != strings.Join(tt.wantLabels, "\n") {
+				t.Errorf("whereabouts = %q, want %q", got, tt.wantLabels)
+			}
+		})
+	}
+}
+
+func TestGetLocationCopies(t *testing.T) {
+	locations, copies, books := newHome()
+	uc := NewLocationUseCase(locations, copies, books)
+
+	found, err := uc.GetLocationCopies("study")
+	if err != nil {
+		t.Fatalf("GetLocationCopies: %v", err)
+	}
+	want := []string{"c2: Home > Study > Bookcase 2", "c1: Home > Study > Bookcase 2 > Shelf 3 > position 14"}
+	if got := labels(found); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("copies = %q, want %q", got, want)
+	}
+
+	found, err = uc.GetLocationCopies("living")
+	if err != nil || len(found) != 0 {
+		t.Errorf("GetLocationCopies(living) = %q, %v; want no copies", labels(found), err)
+	}
+}
+
+func TestRelocateCopies(t *testing.T) {
+	locations, copies, books := newHome()
+	uc := NewLocationUseCase(locations, copies, books)
+
+	if _, err := uc.RelocateCopies("shelf", "shelf"); !errors.Is(err, ErrRelocateToSelf) {
+		t.Errorf("RelocateCopies to itself error = %v, want %v", err, ErrRelocateToSelf)
+	}
+	if _, err := uc.RelocateCopies("shelf", "attic"); !errors.Is(err, repository.ErrLocationNotFound) {
+		t.Errorf("RelocateCopies to an unknown location error = %v, want %v", err, repository.ErrLocationNotFound)
+	}
+
+	moved, err := uc.RelocateCopies("shelf", "living")
+	if err != nil || moved != 1 {
+		t.Fatalf("RelocateCopies = %d, %v; want 1 copy moved", moved, err)
+	}
+	found, _ := uc.WhereIs("b1")
+	if got := labels(found)[0]; got != "c1: Home > Living room > position 14" {
+		t.Errorf("moved copy = %s, want it in the living room at the same position", got)
+	}
+}
+
+func TestPlaceCopy(t *testing.T) {
+	tests := []struct {
+		name      string
+		location  domain.BookLocation
+		wantErr   error
+		wantLabel string
+	}{
+		{name: "on a shelf", location: domain.BookLocation{LocationID: "shelf", Position: 2}, wantLabel: "c3: Home > Study > Bookcase 2 > Shelf 3 > position 2"},
+		{name: "without position", location: domain.BookLocation{LocationID: "living"}, wantLabel: "c3: Home > Living room"},
+		{name: "negative position", location: domain.BookLocation{LocationID: "shelf", Position: -1}, wantErr: validator.ErrInvalidLocationData},
+		{name: "unknown location", location: domain.BookLocation{LocationID: "attic"}, wantErr: repository.ErrLocationNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locations, copies, books := newHome()
+			uc := NewLocationUseCase(locations, copies, books)
+
+			found, err := uc.PlaceCopy("c3", tt.location)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PlaceCopy error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if c, _ := copies.GetByID("c3"); c.Location != nil {
+					t.Errorf("a refused placement moved the copy to %+v", c.Location)
+				}
+				return
+			}
+			if got := labels([]*CopyWhereabouts{found})[0]; got != tt.wantLabel {
+				t.Errorf("whereabouts = %s, want %s", got, tt.wantLabel)
+			}
+		})
+	}
+}
+
+func labels(found []*CopyWhereabouts) []string {
+	var labels []string
+	for _, w := range found {
+		labels = append(labels, w.Copy.ID+": "+w.Label)
+	}
+	return labels
+}
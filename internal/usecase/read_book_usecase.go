@@ -1,8 +1,12 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/rfulgencio3/go-personal-library/internal/domain"
 	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
 )
 
 type ReadBookUseCase interface {
@@ -15,17 +19,46 @@ type ReadBookUseCase interface {
 }
 
 type readBookUseCase struct {
-	repo repository.ReadBookRepository
+	repo     repository.ReadBookRepository
+	bookRepo repository.BookRepository
+	workRepo repository.WorkRepository
 }
 
-func NewReadBookUseCase(repo repository.ReadBookRepository) ReadBookUseCase {
-	return &readBookUseCase{repo: repo}
+func NewReadBookUseCase(repo repository.ReadBookRepository, br repository.BookRepository, wr repository.WorkRepository) ReadBookUseCase {
+	return &readBookUseCase{repo: repo, bookRepo: br, workRepo: wr}
 }
 
 func (u *readBookUseCase) CreateReadBook(readBook *domain.ReadBook) error {
+	if err := u.linkWork(readBook); err != nil {
+		return err
+	}
 	return u.repo.Create(readBook)
 }
 
+// linkWork attaches a reading to the work of the edition read, or checks the
+// work given when no edition is.
+func (u *readBookUseCase) linkWork(readBook *domain.ReadBook) error {
+	if readBook.BookID != "" {
+		book, err := u.bookRepo.GetByID(readBook.BookID)
+		if errors.Is(err, repository.ErrBookNotFound) {
+			return fmt.Errorf("%w: book %s not found", validator.ErrInvalidReadBookData, readBook.BookID)
+		}
+		if err != nil {
+			return err
+		}
+		readBook.WorkID = book.WorkID
+		return nil
+	}
+	if readBook.WorkID == "" {
+		return fmt.Errorf("%w: book_id or work_id is required", validator.ErrInvalidReadBookData)
+	}
+	_, err := u.workRepo.GetByID(readBook.WorkID)
+	if errors.Is(err, repository.ErrWorkNotFound) {
+		return fmt.Errorf("%w: work %s not found", validator.ErrInvalidReadBookData, readBook.WorkID)
+	}
+	return err
+}
+
 func (u *readBookUseCase) GetReadBookByID(id string) (*domain.ReadBook, error) {
 	return u.repo.GetByID(id)
 }
@@ -35,6 +68,9 @@ func (u *readBookUseCase) GetAllReadBooks() ([]*domain.ReadBook, error) {
 }
 
 func (u *readBookUseCase) UpdateReadBook(readBook *domain.ReadBook) error {
+	if err := u.linkWork(readBook); err != nil {
+		return err
+	}
 	return u.repo.Update(readBook)
 }
 
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+// ValueGroup totals the purchases sharing a year, store or currency. Total
+// is in the base currency; Amounts keeps the sums in the currencies paid.
+type ValueGroup struct {
+	Key     string             `json:"key"`
+	Total   float64            `json:"total"`
+	Count   int                `json:"count"`
+	Amounts map[string]float64 `json:"amounts"`
+}
+
+// CollectionValue is the spending on the collection. Purchases in a
+// currency without an exchange rate are counted in Amounts but left out of
+// the totals, and the currency is listed in MissingRates.
+type CollectionValue struct {
+	BaseCurrency string       `json:"base_currency"`
+	Total        float64      `json:"total"`
+	Count        int          `json:"count"`
+	ByYear       []ValueGroup `json:"by_year"`
+	ByStore      []ValueGroup `json:"by_store"`
+	ByCurrency   []ValueGroup `json:"by_currency"`
+	MissingRates []string     `json:"missing_rates"`
+}
+
+// DepartureYear gathers the books that left the library in a year, the most
+// recent departure first, with the proceeds of the sales in each currency.
+// Books without a departure date, saved before dates were required, are
+// gathered last under year 0.
+type DepartureYear struct {
+	Year     int                `json:"year"`
+	Count    int                `json:"count"`
+	ByStatus map[string]int     `json:"by_status"`
+	Proceeds map[string]float64 `json:"proceeds"`
+	Books    []*domain.Book     `json:"books"`
+}
+
+type ReportUseCase interface {
+	// CollectionValue totals the spending per year, per store and per currency.
+	CollectionValue() (*CollectionValue, error)
+	// Departures returns the books sold, donated, lost or discarded by year,
+	// the most recent year first. A year other than zero narrows it to that year.
+	Departures(year int) ([]*DepartureYear, error)
+	GetExchangeRates() ([]*domain.ExchangeRate, error)
+	SetExchangeRate(rate *domain.ExchangeRate) error
+	DeleteExchangeRate(currency string) error
+}
+
+type reportUseCase struct {
+	copyRepo     repository.CopyRepository
+	rateRepo     repository.ExchangeRateRepository
+	bookRepo     repository.BookRepository
+	baseCurrency string
+}
+
+func NewReportUseCase(cr repository.CopyRepository, rr repository.ExchangeRateRepository, br repository.BookRepository, baseCurrency string) ReportUseCase {
+	return &reportUseCase{
+		copyRepo:     cr,
+		rateRepo:     rr,
+		bookRepo:     br,
+		baseCurrency: strings.ToUpper(baseCurrency),
+	}
+}
+
+func (uc *reportUseCase) CollectionValue() (*CollectionValue, error) {
+	totals, err := uc.copyRepo.SpendingTotals()
+	if err != nil {
+		return nil, err
+	}
+	rates, err := uc.rateRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	toBase := map[string]float64{uc.baseCurrency: 1}
+	for _, r := range rates {
+		toBase[r.Currency] = r.Rate
+	}
+
+	value := &CollectionValue{BaseCurrency: uc.baseCurrency, MissingRates: []string{}}
+	byYear := make(map[string]*ValueGroup)
+	byStore := make(map[string]*ValueGroup)
+	byCurrency := make(map[string]*ValueGroup)
+	missing := make(map[string]bool)
+	for _, t := range totals {
+		rate, ok := toBase[t.Currency]
+		if !ok && !missing[t.Currency] {
+			missing[t.Currency] = true
+			value.MissingRates = append(value.MissingRates, t.Currency)
+		}
+		converted := t.Amount * rate
+
+		year := ""
+		if t.Year > 0 {
+			year = strconv.Itoa(t.Year)
+		}
+		for _, g := range []*ValueGroup{group(byYear, year), group(byStore, t.Store), group(byCurrency, t.Currency)} {
+			g.Total += converted
+			g.Count += t.Count
+			g.Amounts[t.Currency] += t.Amount
+		}
+		value.Total += converted
+		value.Count += t.Count
+	}
+
+	value.Total = roundCents(value.Total)
+	value.ByYear = sortedGroups(byYear)
+	value.ByStore = sortedGroups(byStore)
+	value.ByCurrency = sortedGroups(byCurrency)
+	sort.Strings(value.MissingRates)
+	return value, nil
+}
+
+func group(groups map[string]*ValueGroup, key string) *ValueGroup {
+	g, ok := groups[key]
+	if !ok {
+		g = &ValueGroup{Key: key, Amounts: make(map[string]float64)}
+		groups[key] = g
+	}
+	return g
+}
+
+// sortedGroups returns the groups ordered by key, with the empty key, for
+// purchases without a date or store, last.
+func sortedGroups(groups map[string]*ValueGroup) []ValueGroup {
+	sorted := make([]ValueGroup, 0, len(groups))
+	for _, g := range groups {
+		g.Total = roundCents(g.Total)
+		for currency, amount := range g.Amounts {
+			g.Amounts[currency] = roundCents(amount)
+		}
+		sorted = append(sorted, *g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i].Key, sorted[j].Key
+		if a == "" || b == "" {
+			return b == ""
+		}
+		return a < b
+	})
+	return sorted
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func (uc *reportUseCase) Departures(year int) ([]*DepartureYear, error) {
+	books, err := uc.bookRepo.GetDisposed(year)
+	if err != nil {
+		return nil, err
+	}
+
+	// Os livros vêm ordenados pela data de saída, então os anos também.
+	years := []*DepartureYear{}
+	for _, book := range books {
+		y := 0
+		if book.Disposal != nil && !book.Disposal.Date.IsZero() {
+			y = book.Disposal.Date.Year()
+		}
+		if len(years) == 0 || years[len(years)-1].Year != y {
+			years = append(years, &DepartureYear{Year: y, ByStatus: make(map[string]int), Proceeds: make(map[string]float64)})
+		}
+		d := years[len(years)-1]
+		d.Count++
+		d.ByStatus[book.Status]++
+		if book.Disposal != nil && book.Disposal.Price > 0 {
+			d.Proceeds[book.Disposal.Currency] = roundCents(d.Proceeds[book.Disposal.Currency] + book.Disposal.Price)
+		}
+		d.Books = append(d.Books, book)
+	}
+	return years, nil
+}
+
+func (uc *reportUseCase) GetExchangeRates() ([]*domain.ExchangeRate, error) {
+	rates, err := uc.rateRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	if rates == nil {
+		rates = []*domain.ExchangeRate{}
+	}
+	return rates, nil
+}
+
+func (uc *reportUseCase) SetExchangeRate(rate *domain.ExchangeRate) error {
+	if err := validator.ValidateExchangeRate(rate); err != nil {
+		return err
+	}
+	if rate.Currency == uc.baseCurrency {
+		return fmt.Errorf("%w: %s is the base currency", validator.ErrInvalidExchangeRate, rate.Currency)
+	}
+	rate.UpdatedAt = time.Now()
+	return uc.rateRepo.Set(rate)
+}
+
+func (uc *reportUseCase) DeleteExchangeRate(currency string) error {
+	return uc.rateRepo.Delete(strings.ToUpper(currency))
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+func purchased(id string, date *time.Time, store string, price float64, currency string) *domain.Copy {
+	return &domain.Copy{ID: id, BookID: "b1", Acquisition: &domain.Acquisition{
+		Method: domain.AcquiredPurchase, Date: date, Store: store, Price: price, Currency: currency,
+	}}
+}
+
+func TestCollectionValue(t *testing.T) {
+	march := time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC)
+	july := time.Date(2023, 7, 2, 0, 0, 0, 0, time.UTC)
+	june := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	copies := &fakeCopyRepo{copies: []*domain.Copy{
+		purchased("c1", &march, "Livraria Cultura", 50, "BRL"),
+		purchased("c2", &july, "Livraria Cultura", 30.1, "BRL"),
+		purchased("c3", &june, "Powell's", 20, "USD"),
+		// Sem cotação para EUR: entra nas quantias, mas não nos totais.
+		purchased("c4", nil, "", 15, "EUR"),
+		{ID: "c5", BookID: "b1", Acquisition: &domain.Acquisition{Method: domain.AcquiredGift}},
+		{ID: "c6", BookID: "b1"},
+	}}
+	rates := &fakeExchangeRateRepo{rates: []*domain.ExchangeRate{{Currency: "USD", Rate: 5}}}
+	uc := NewReportUseCase(copies, rates, newFakeBookRepo(), "brl")
+
+	value, err := uc.CollectionValue()
+	if err != nil {
+		t.Fatalf("CollectionValue: %v", err)
+	}
+	if value.BaseCurrency != "BRL" || value.Total != 180.1 || value.Count != 4 {
+		t.Errorf("value = %s %.2f in %d purchases, want BRL 180.10 in 4", value.BaseCurrency, value.Total, value.Count)
+	}
+	if got := strings.Join(value.MissingRates, " "); got != "EUR" {
+		t.Errorf("missing rates = %s, want EUR", got)
+	}
+
+	tests := []struct {
+		name   string
+		groups []ValueGroup
+		want   []string
+	}{
+		{name: "by year", groups: value.ByYear, want: []string{"2023 80.10 2 map[BRL:80.1]", "2024 100.00 1 map[USD:20]", " 0.00 1 map[EUR:15]"}},
+		{name: "by store", groups: value.ByStore, want: []string{"Livraria Cultura 80.10 2 map[BRL:80.1]", "Powell's 100.00 1 map[USD:20]", " 0.00 1 map[EUR:15]"}},
+		{name: "by currency", groups: value.ByCurrency, want: []string{"BRL 80.10 2 map[BRL:80.1]", "EUR 0.00 1 map[EUR:15]", "USD 100.00 1 map[USD:20]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, g := range tt.groups {
+				got = append(got, fmt.Sprintf("%s %.2f %d %v", g.Key, g.Total, g.Count, g.Amounts))
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("groups =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestCollectionValueEmpty(t *testing.T) {
+	uc := NewReportUseCase(&fakeCopyRepo{}, &fakeExchangeRateRepo{}, newFakeBookRepo(), "BRL")
+
+	// Listas vazias, e não nulas, para que o JSON traga [].
+	value, err := uc.CollectionValue()
+	if err != nil || value.ByYear == nil || value.ByStore == nil || value.ByCurrency == nil || value.MissingRates == nil {
+		t.Errorf("CollectionValue = %+v, %v; want empty lists", value, err)
+	}
+}
+
+func TestSetExchangeRate(t *testing.T) {
+	tests := []struct {
+		name         string
+		rate         domain.ExchangeRate
+		wantErr      error
+		wantCurrency string
+	}{
+		{name: "new currency", rate: domain.ExchangeRate{Currency: " eur ", Rate: 6.2}, wantCurrency: "EUR"},
+		{name: "replaces the rate", rate: domain.ExchangeRate{Currency: "USD", Rate: 5.4}, wantCurrency: "USD"},
+		{name: "base currency", rate: domain.ExchangeRate{Currency: "brl", Rate: 1}, wantErr: validator.ErrInvalidExchangeRate},
+		{name: "not a code", rate: domain.ExchangeRate{Currency: "US$", Rate: 5}, wantErr: validator.ErrInvalidExchangeRate},
+		{name: "zero rate", rate: domain.ExchangeRate{Currency: "EUR"}, wantErr: validator.ErrInvalidExchangeRate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates := &fakeExchangeRateRepo{rates: []*domain.ExchangeRate{{Currency: "USD", Rate: 5}}}
+			uc := NewReportUseCase(&fakeCopyRepo{}, rates, newFakeBookRepo(), "BRL")
+
+			rate := tt.rate
+			if err := uc.SetExchangeRate(&rate); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetExchangeRate error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(rates.rates) != 1 || rates.rates[0].Rate != 5 {
+					t.Errorf("rates = %+v, want them untouched", rates.rates)
+				}
+				return
+			}
+			if rate.Currency != tt.wantCurrency || rate.UpdatedAt.IsZero() {
+				t.Errorf("rate = %+v, want %s updated now", rate, tt.wantCurrency)
+			}
+			all, _ := uc.GetExchangeRates()
+			for _, r := range all {
+				if r.Currency == tt.wantCurrency && r.Rate != tt.rate.Rate {
+					t.Errorf("%s rate = %v, want %v", r.Currency, r.Rate, tt.rate.Rate)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteExchangeRate(t *testing.T) {
+	rates := &fakeExchangeRateRepo{rates: []*domain.ExchangeRate{{Currency: "USD", Rate: 5}}}
+	uc := NewReportUseCase(&fakeCopyRepo{}, rates, newFakeBookRepo(), "BRL")
+
+	if err := uc.DeleteExchangeRate("usd"); err != nil {
+		t.Fatalf("DeleteExchangeRate: %v", err)
+	}
+	all, err := uc.GetExchangeRates()
+	if err != nil || all == nil || len(all) != 0 {
+		t.Errorf("GetExchangeRates = %v, %v; want an empty list", all, err)
+	}
+	if err := uc.DeleteExchangeRate("USD"); !errors.Is(err, repository.ErrExchangeRateNotFound) {
+		t.Errorf("DeleteExchangeRate twice error = %v, want %v", err, repository.ErrExchangeRateNotFound)
+	}
+}
+
+func TestDepartures(t *testing.T) {
+	disposed := func(id, status string, date time.Time, price float64, currency string) *domain.Book {
+		return &domain.Book{ID: id, Title: id, Status: status, Disposal: &domain.Disposal{Date: date, Price: price, Currency: currency}}
+	}
+	books := newFakeBookRepo(
+		disposed("b1", domain.BookSold, time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), 25.5, "BRL"),
+		disposed("b2", domain.BookDonated, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), 0, ""),
+		disposed("b3", domain.BookSold, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), 10, "USD"),
+		disposed("b4", domain.BookLost, time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC), 0, ""),
+		&domain.Book{ID: "b5", Title: "b5", Status: domain.BookOwned},
+		// Saídas antigas, sem data, ficam no ano 0, por último.
+		&domain.Book{ID: "b7", Title: "b7", Status: domain.BookDiscarded},
+		// Sem status conta como próprio e não sai no relatório.
+		&domain.Book{ID: "b6", Title: "b6"},
+	)
+	uc := NewReportUseCase(&fakeCopyRepo{}, &fakeExchangeRateRepo{}, books, "BRL")
+
+	tests := []struct {
+		year int
+		want []string
+	}{
+		{year: 0, want: []string{
+			"2024 3 map[donated:1 sold:2] map[BRL:25.5 USD:10] b1 b2 b3",
+			"2022 1 map[lost:1] map[] b4",
+			"0 1 map[discarded:1] map[] b7",
+		}},
+		{year: 2022, want: []string{"2022 1 map[lost:1] map[] b4"}},
+		{year: 2023},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.year), func(t *testing.T) {
+			years, err := uc.Departures(tt.year)
+			if err != nil {
+				t.Fatalf("Departures: %v", err)
+			}
+			if years == nil {
+				t.Fatal("Departures returned nil, want an empty list")
+			}
+			var got []string
+			for _, y := range years {
+				line := fmt.Sprintf("%d %d %v %v", y.Year, y.Count, y.ByStatus, y.Proceeds)
+				for _, book := range y.Books {
+					line += " " + book.ID
+				}
+				got = append(got, line)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("departures =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
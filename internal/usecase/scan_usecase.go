@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"log"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/barcode"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/isbn"
+	"github.com/rfulgencio3/go-personal-library/internal/metadata"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+)
+
+var (
+	ErrNotISBN = errors.New("the barcode is not an ISBN")
+)
+
+// ScanResult is the outcome of reading a barcode photo. Book is set when
+// the library already has the book; otherwise Draft holds the data found
+// for it, to be confirmed before creating the book.
+type ScanResult struct {
+	Barcode string       `json:"barcode"`
+	ISBN13  string       `json:"isbn13"`
+	ISBN10  string       `json:"isbn10,omitempty"`
+	Book    *domain.Book `json:"book,omitempty"`
+	Draft   *domain.Book `json:"draft,omitempty"`
+}
+
+type ScanUseCase interface {
+	// ScanImage decodes the EAN-13 barcode in a JPEG or PNG photo.
+	ScanImage(data []byte) (*ScanResult, error)
+}
+
+type scanUseCase struct {
+	bookRepo repository.BookRepository
+	metadata metadata.Provider
+}
+
+// NewScanUseCase creates the scan use case. The metadata provider may be
+// nil, in which case drafts only carry the ISBN.
+func NewScanUseCase(br repository.BookRepository, mp metadata.Provider) ScanUseCase {
+	return &scanUseCase{
+		bookRepo: br,
+		metadata: mp,
+	}
+}
+
+func (uc *scanUseCase) ScanImage(data []byte) (*ScanResult, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width*config.Height > maxImagePixels {
+		return nil, ErrUnsupportedImage
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	code, err := barcode.Decode(img)
+	if err != nil {
+		return nil, err
+	}
+	// Só os prefixos 978 e 979 (Bookland) identificam livros.
+	isbn10, isbn13, err := isbn.Parse(code)
+	if err != nil || (code[:3] != "978" && code[:3] != "979") {
+		return &ScanResult{Barcode: code}, ErrNotISBN
+	}
+	result := &ScanResult{Barcode: code, ISBN13: isbn13, ISBN10: isbn10}
+
+	book, err := uc.bookRepo.GetByISBN(isbn13)
+	if err == nil {
+		result.Book = book
+		return result, nil
+	}
+	if !errors.Is(err, repository.ErrBookNotFound) {
+		return nil, err
+	}
+
+	result.Draft = &domain.Book{ISBN10: isbn10, ISBN13: isbn13}
+	if uc.metadata != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		found, err := uc.metadata.LookupISBN(ctx, isbn13)
+		switch {
+		case err == nil:
+			metadata.Merge(result.Draft, found)
+		case !errors.Is(err, metadata.ErrNotFound):
+			// O rascunho com o ISBN ainda é útil mesmo sem os metadados.
+			log.Printf("Erro ao buscar metadados do ISBN %s: %v", isbn13, err)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+var (
+	ErrSeriesFinished = errors.New("every book in the series has been read")
+	ErrNotInSeries    = errors.New("the book is not in this series")
+)
+
+// SeriesEntry is a book of a series with its read status.
+type SeriesEntry struct {
+	Book       *domain.Book `json:"book"`
+	ReadStatus string       `json:"read_status"`
+}
+
+// SeriesDetails is a series with its books in reading order.
+type SeriesDetails struct {
+	*domain.Series
+	Books []SeriesEntry `json:"books"`
+}
+
+type SeriesUseCase interface {
+	CreateSeries(series *domain.Series) error
+	GetSeriesByID(id string) (*SeriesDetails, error)
+	GetAllSeries() ([]*domain.Series, error)
+	UpdateSeries(series *domain.Series) error
+	DeleteSeries(id string) error
+	// NextInSeries returns the first book of the series, in reading order,
+	// that has not been read yet.
+	NextInSeries(id string) (*SeriesEntry, error)
+	AddBookToSeries(seriesID, bookID string, position float64) error
+	RemoveBookFromSeries(seriesID, bookID string) error
+}
+
+type seriesUseCase struct {
+	seriesRepo   repository.SeriesRepository
+	bookRepo     repository.BookRepository
+	readBookRepo repository.ReadBookRepository
+}
+
+func NewSeriesUseCase(sr repository.SeriesRepository, br repository.BookRepository, rbr repository.ReadBookRepository) SeriesUseCase {
+	return &seriesUseCase{
+		seriesRepo:   sr,
+		bookRepo:     br,
+		readBookRepo: rbr,
+	}
+}
+
+// CreateSeries saves the series and links the books already naming it.
+func (uc *seriesUseCase) CreateSeries(series *domain.Series) error {
+	if err := validator.ValidateSeries(series); err != nil {
+		return err
+	}
+	if err := uc.seriesRepo.Create(series); err != nil {
+		return err
+	}
+	return uc.bookRepo.LinkSeries(series.ID, series.Name)
+}
+
+// GetSeriesByID returns the series with its books ordered by position and
+// then by title. Books without a position come last.
+func (uc *seriesUseCase) GetSeriesByID(id string) (*SeriesDetails, error) {
+	series, err := uc.seriesRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	books, err := uc.bookRepo.Search(domain.BookFilter{SeriesID: id})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(books, func(i, j int) bool {
+		a, b := books[i], books[j]
+		if a.SeriesPosition != b.SeriesPosition {
+			if a.SeriesPosition == 0 || b.SeriesPosition == 0 {
+				return b.SeriesPosition == 0
+			}
+			return a.SeriesPosition < b.SeriesPosition
+		}
+		return a.Title < b.Title
+	})
+
+	// As leituras pertencem à obra, qualquer que seja a edição lida; só os
+	// livros sem obra são procurados pelo próprio ID.
+	var workIDs, bookIDs []string
+	for _, book := range books {
+		if book.WorkID != "" {
+			workIDs = append(workIDs, book.WorkID)
+		} else {
+			bookIDs = append(bookIDs, book.ID)
+		}
+	}
+	readBooks, err := uc.readBookRepo.GetByWorkOrBookIDs(workIDs, bookIDs)
+	if err != nil {
+		return nil, err
+	}
+	byWork := make(map[string][]*domain.ReadBook)
+	byBook := make(map[string][]*domain.ReadBook)
+	for _, rb := range readBooks {
+		if rb.WorkID != "" {
+			byWork[rb.WorkID] = append(byWork[rb.WorkID], rb)
+		}
+		byBook[rb.BookID] = append(byBook[rb.BookID], rb)
+	}
+
+	details := &SeriesDetails{Series: series, Books: []SeriesEntry{}}
+	for _, book := range books {
+		reads := byBook[book.ID]
+		if book.WorkID != "" {
+			reads = byWork[book.WorkID]
+		}
+		details.Books = append(details.Books, SeriesEntry{Book: book, ReadStatus: domain.ReadStatus(reads)})
+	}
+	return details, nil
+}
+
+func (uc *seriesUseCase) GetAllSeries() ([]*domain.Series, error) {
+	return uc.seriesRepo.GetAll()
+}
+
+// UpdateSeries replaces the series and renames it in its books. Books naming
+// the new name that were not linked yet are linked to it.
+func (uc *seriesUseCase) UpdateSeries(series *domain.Series) error {
+	if err := validator.ValidateSeries(series); err != nil {
+		return err
+	}
+	if err := uc.seriesRepo.Update(series); err != nil {
+		return err
+	}
+	if err := uc.bookRepo.RenameSeries(series.ID, series.Name); err != nil {
+		return err
+	}
+	return uc.bookRepo.LinkSeries(series.ID, series.Name)
+}
+
+// DeleteSeries removes the series. Its books keep their series name and
+// position but are no longer linked to it.
+func (uc *seriesUseCase) DeleteSeries(id string) error {
+	if err := uc.seriesRepo.Delete(id); err != nil {
+		return err
+	}
+	return uc.bookRepo.UnlinkSeries(id)
+}
+
+func (uc *seriesUseCase) NextInSeries(id string) (*SeriesEntry, error) {
+	details, err := uc.GetSeriesByID(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range details.Books {
+		if entry.ReadStatus != domain.ReadStatusRead {
+			return &entry, nil
+		}
+	}
+	return nil, ErrSeriesFinished
+}
+
+func (uc *seriesUseCase) AddBookToSeries(seriesID, bookID string, position float64) error {
+	if position < 0 {
+		return fmt.Errorf("%w: position must not be negative", validator.ErrInvalidSeriesData)
+	}
+	series, err := uc.seriesRepo.GetByID(seriesID)
+	if err != nil {
+		return err
+	}
+	return uc.bookRepo.SetSeries(bookID, series.ID, series.Name, position)
+}
+
+func (uc *seriesUseCase) RemoveBookFromSeries(seriesID, bookID string) error {
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return err
+	}
+	if book.SeriesID != seriesID {
+		return ErrNotInSeries
+	}
+	return uc.bookRepo.SetSeries(bookID, "", "", 0)
+}
+
+// linkSeries links a book to its series record. A series ID must point to an
+// existing series, whose name is copied to the book; otherwise the series
+// name is looked up.
+func linkSeries(series repository.SeriesRepository, book *domain.Book) error {
+	if book.SeriesID != "" {
+		s, err := series.GetByID(book.SeriesID)
+		if errors.Is(err, repository.ErrSeriesNotFound) {
+			return fmt.Errorf("%w: series %s not found", validator.ErrInvalidBookData, book.SeriesID)
+		}
+		if err != nil {
+			return err
+		}
+		book.Series = s.Name
+		return nil
+	}
+	if book.Series == "" {
+		return nil
+	}
+
+	s, err := series.FindByName(book.Series)
+	if errors.Is(err, repository.ErrSeriesNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	book.SeriesID, book.Series = s.ID, s.Name
+	return nil
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+func TestGetSeriesByID(t *testing.T) {
+	finished := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	books := newFakeBookRepo(
+		&domain.Book{ID: "b1", Title: "O Retorno do Rei", SeriesID: "s1", SeriesPosition: 3, WorkID: "w3"},
+		&domain.Book{ID: "b2", Title: "A Sociedade do Anel", SeriesID: "s1", SeriesPosition: 1, WorkID: "w1"},
+		&domain.Book{ID: "b3", Title: "As Duas Torres", SeriesID: "s1", SeriesPosition: 2},
+		&domain.Book{ID: "b4", Title: "Contos Inacabados", SeriesID: "s1"},
+		&domain.Book{ID: "b5", Title: "O Hobbit", SeriesID: "s2", WorkID: "w1"},
+	)
+	reads := &fakeReadBookRepo{readBooks: []*domain.ReadBook{
+		// Lida em outra edição da mesma obra.
+		{ID: "r1", WorkID: "w1", BookID: "b-other", ActualEndDate: &finished},
+		{ID: "r2", BookID: "b3"},
+		{ID: "r3", WorkID: "w9", BookID: "b9", ActualEndDate: &finished},
+	}}
+	series := &fakeSeriesRepo{series: []*domain.Series{{ID: "s1", Name: "O Senhor dos Anéis"}}}
+	uc := NewSeriesUseCase(series, books, reads)
+
+	details, err := uc.GetSeriesByID("s1")
+	if err != nil {
+		t.Fatalf("GetSeriesByID: %v", err)
+	}
+	want := []struct{ id, status string }{
+		{"b2", domain.ReadStatusRead},
+		{"b3", domain.ReadStatusReading},
+		{"b1", domain.ReadStatusUnread},
+		{"b4", domain.ReadStatusUnread},
+	}
+	if len(details.Books) != len(want) {
+		t.Fatalf("got %d books, want %d", len(details.Books), len(want))
+	}
+	for i, w := range want {
+		got := details.Books[i]
+		if got.Book.ID != w.id || got.ReadStatus != w.status {
+			t.Errorf("books[%d] = %s (%s), want %s (%s)", i, got.Book.ID, got.ReadStatus, w.id, w.status)
+		}
+	}
+	if reads.queries != 1 {
+		t.Errorf("read status took %d queries, want 1", reads.queries)
+	}
+
+	next, err := uc.NextInSeries("s1")
+	if err != nil {
+		t.Fatalf("NextInSeries: %v", err)
+	}
+	if next.Book.ID != "b3" {
+		t.Errorf("NextInSeries = %s, want b3", next.Book.ID)
+	}
+}
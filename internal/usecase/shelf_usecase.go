@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+// shelfGap is the distance between consecutive positions when a book is
+// appended or a shelf is renumbered.
+const shelfGap = 1.0
+
+// shelfAttempts bounds how many times a book is placed again when the shelf
+// keeps changing under it.
+const shelfAttempts = 5
+
+// ShelfPlacement tells where a book goes on a shelf, relative to the books
+// around the place it was dropped. After wins when both are given; with
+// neither, the book goes to the end of the shelf.
+type ShelfPlacement struct {
+	After  string `json:"after,omitempty"`
+	Before string `json:"before,omitempty"`
+}
+
+// ShelfDetails is a shelf with its books in order.
+type ShelfDetails struct {
+	*domain.Shelf
+	Books []*domain.Book `json:"books"`
+}
+
+type ShelfUseCase interface {
+	CreateShelf(shelf *domain.Shelf) error
+	GetShelf(id string) (*ShelfDetails, error)
+	GetAllShelves() ([]*domain.Shelf, error)
+	// GetBookShelves returns the shelves holding the book.
+	GetBookShelves(bookID string) ([]*domain.Shelf, error)
+	// UpdateShelf renames a shelf or changes its description.
+	UpdateShelf(shelf *domain.Shelf) error
+	DeleteShelf(id string) error
+	AddBook(shelfID, bookID string, placement ShelfPlacement) (*domain.Shelf, error)
+	MoveBook(shelfID, bookID string, placement ShelfPlacement) (*domain.Shelf, error)
+	RemoveBook(shelfID, bookID string) error
+}
+
+type shelfUseCase struct {
+	shelfRepo repository.ShelfRepository
+	bookRepo  repository.BookRepository
+}
+
+func NewShelfUseCase(sr repository.ShelfRepository, br repository.BookRepository) ShelfUseCase {
+	return &shelfUseCase{
+		shelfRepo: sr,
+		bookRepo:  br,
+	}
+}
+
+// CreateShelf saves an empty shelf; books are added one at a time.
+func (uc *shelfUseCase) CreateShelf(shelf *domain.Shelf) error {
+	if err := validator.ValidateShelf(shelf); err != nil {
+		return err
+	}
+	shelf.Description = strings.TrimSpace(shelf.Description)
+	shelf.Entries = nil
+	shelf.CreatedAt = time.Now()
+	return uc.shelfRepo.Create(shelf)
+}
+
+// GetShelf returns the shelf with its books in order. Entries of books that
+// no longer exist are left out of the response.
+func (uc *shelfUseCase) GetShelf(id string) (*ShelfDetails, error) {
+	shelf, err := uc.shelfRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(shelf.Entries))
+	for i, e := range shelf.Entries {
+		ids[i] = e.BookID
+	}
+	books, err := uc.bookRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domain.Book, len(books))
+	for _, book := range books {
+		byID[book.ID] = book
+	}
+
+	details := &ShelfDetails{Shelf: shelf, Books: []*domain.Book{}}
+	entries := shelf.Entries[:0]
+	for _, e := range shelf.Entries {
+		book, ok := byID[e.BookID]
+		if !ok {
+			continue
+		}
+		entries = append(entries, e)
+		details.Books = append(details.Books, book)
+	}
+	shelf.Entries = entries
+	return details, nil
+}
+
+func (uc *shelfUseCase) GetAllShelves() ([]*domain.Shelf, error) {
+	return uc.shelfRepo.GetAll()
+}
+
+func (uc *shelfUseCase) GetBookShelves(bookID string) ([]*domain.Shelf, error) {
+	if _, err := uc.bookRepo.GetByID(bookID); err != nil {
+		return nil, err
+	}
+	return uc.shelfRepo.GetByBookID(bookID)
+}
+
+func (uc *shelfUseCase) UpdateShelf(shelf *domain.Shelf) error {
+	if err := validator.ValidateShelf(shelf); err != nil {
+		return err
+	}
+	shelf.Description = strings.TrimSpace(shelf.Description)
+	if err := uc.shelfRepo.Update(shelf); err != nil {
+		return err
+	}
+
+	updated, err := uc.shelfRepo.GetByID(shelf.ID)
+	if err != nil {
+		return err
+	}
+	*shelf = *updated
+	return nil
+}
+
+func (uc *shelfUseCase) DeleteShelf(id string) error {
+	return uc.shelfRepo.Delete(id)
+}
+
+func (uc *shelfUseCase) AddBook(shelfID, bookID string, placement ShelfPlacement) (*domain.Shelf, error) {
+	if _, err := uc.shelfRepo.GetByID(shelfID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.bookRepo.GetByID(bookID); err != nil {
+		return nil, err
+	}
+	return uc.place(shelfID, bookID, placement, func(shelf *domain.Shelf, position float64) error {
+		if entryIndex(shelf.Entries, bookID) >= 0 {
+			return repository.ErrBookAlreadyOnShelf
+		}
+		entry := domain.ShelfEntry{BookID: bookID, Position: position, AddedAt: time.Now()}
+		return uc.shelfRepo.AddEntry(shelfID, shelf.Revision, entry)
+	})
+}
+
+// MoveBook changes the position of a single entry, so books added or moved
+// at the same time by someone else keep their place.
+func (uc *shelfUseCase) MoveBook(shelfID, bookID string, placement ShelfPlacement) (*domain.Shelf, error) {
+	return uc.place(shelfID, bookID, placement, func(shelf *domain.Shelf, position float64) error {
+		if entryIndex(shelf.Entries, bookID) < 0 {
+			return repository.ErrBookNotOnShelf
+		}
+		return uc.shelfRepo.SetPositions(shelfID, shelf.Revision, map[string]float64{bookID: position})
+	})
+}
+
+// place reads the shelf, works out the position of bookID and hands it to
+// write. Positions are only valid for the revision they were computed from,
+// so when someone else changed the shelf in between it starts over.
+func (uc *shelfUseCase) place(shelfID, bookID string, placement ShelfPlacement, write func(shelf *domain.Shelf, position float64) error) (*domain.Shelf, error) {
+	for attempt := 1; ; attempt++ {
+		shelf, err := uc.shelfRepo.GetByID(shelfID)
+		if err != nil {
+			return nil, err
+		}
+		position, err := uc.position(shelf, bookID, placement)
+		if err == nil {
+			err = write(shelf, position)
+		}
+		if errors.Is(err, repository.ErrShelfChanged) && attempt < shelfAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return uc.shelfRepo.GetByID(shelfID)
+	}
+}
+
+func (uc *shelfUseCase) RemoveBook(shelfID, bookID string) error {
+	return uc.shelfRepo.RemoveEntry(shelfID, bookID)
+}
+
+// position returns the position halfway between the neighbours of the
+// place where bookID is dropped. When the neighbours are too close to fit
+// another position in between, the shelf is renumbered first, which moves
+// it to the next revision.
+func (uc *shelfUseCase) position(shelf *domain.Shelf, bookID string, placement ShelfPlacement) (float64, error) {
+	var others []domain.ShelfEntry
+	for _, e := range shelf.Entries {
+		if e.BookID != bookID {
+			others = append(others, e)
+		}
+	}
+
+	// Índice da entrada que ficará logo depois do livro.
+	next := len(others)
+	switch {
+	case placement.After != "":
+		i := entryIndex(others, placement.After)
+		if i < 0 {
+			return 0, fmt.Errorf("%w: book %s is not on the shelf", validator.ErrInvalidShelfData, placement.After)
+		}
+		next = i + 1
+	case placement.Before != "":
+		i := entryIndex(others, placement.Before)
+		if i < 0 {
+			return 0, fmt.Errorf("%w: book %s is not on the shelf", validator.ErrInvalidShelfData, placement.Before)
+		}
+		next = i
+	}
+
+	position, ok := between(others, next)
+	if ok {
+		return position, nil
+	}
+
+	renumbered := make(map[string]float64, len(others))
+	for i := range others {
+		others[i].Position = float64(i+1) * shelfGap
+		renumbered[others[i].BookID] = others[i].Position
+	}
+	if err := uc.shelfRepo.SetPositions(shelf.ID, shelf.Revision, renumbered); err != nil {
+		return 0, err
+	}
+	shelf.Revision++
+	position, _ = between(others, next)
+	return position, nil
+}
+
+// between returns the position halfway between the entries at next-1 and
+// next, reporting false when no distinct position fits between them.
+func between(entries []domain.ShelfEntry, next int) (float64, bool) {
+	switch {
+	case len(entries) == 0:
+		return shelfGap, true
+	case next == 0:
+		return entries[0].Position - shelfGap, true
+	case next == len(entries):
+		return entries[next-1].Position + shelfGap, true
+	}
+	lo, hi := entries[next-1].Position, entries[next].Position
+	mid := lo + (hi-lo)/2
+	return mid, lo < mid && mid < hi
+}
+
+func entryIndex(entries []domain.ShelfEntry, bookID string) int {
+	for i, e := range entries {
+		if e.BookID == bookID {
+			return i
+		}
+	}
+	return -1
+}
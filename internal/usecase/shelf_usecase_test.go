@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+// shelfOf builds a shelf holding the given books at the given positions.
+func shelfOf(positions map[string]float64) *domain.Shelf {
+	shelf := &domain.Shelf{ID: "s1", Name: "Favoritos"}
+	for id, p := range positions {
+		shelf.Entries = append(shelf.Entries, domain.ShelfEntry{BookID: id, Position: p})
+	}
+	return shelf
+}
+
+func shelfBooks(ids ...string) *fakeBookRepo {
+	books := newFakeBookRepo()
+	for _, id := range ids {
+		books.books[id] = &domain.Book{ID: id, Title: id}
+	}
+	return books
+}
+
+func entryOrder(shelf *domain.Shelf) []string {
+	shelf.SortEntries()
+	var ids []string
+	for _, e := range shelf.Entries {
+		ids = append(ids, e.BookID)
+	}
+	return ids
+}
+
+func TestAddBookToShelf(t *testing.T) {
+	tight := math.Nextafter(1, 2)
+	tests := []struct {
+		name      string
+		entries   map[string]float64
+		placement ShelfPlacement
+		wantErr   error
+		want      []string
+	}{
+		{name: "empty shelf", want: []string{"new"}},
+		{name: "end of the shelf", entries: map[string]float64{"a": 1, "b": 2}, want: []string{"a", "b", "new"}},
+		{name: "after", entries: map[string]float64{"a": 1, "b": 2}, placement: ShelfPlacement{After: "a"}, want: []string{"a", "new", "b"}},
+		{name: "before the first", entries: map[string]float64{"a": 1, "b": 2}, placement: ShelfPlacement{Before: "a"}, want: []string{"new", "a", "b"}},
+		{name: "renumbers when no position fits", entries: map[string]float64{"a": 1, "b": tight}, placement: ShelfPlacement{After: "a"}, want: []string{"a", "new", "b"}},
+		{name: "unknown neighbour", entries: map[string]float64{"a": 1}, placement: ShelfPlacement{After: "x"}, wantErr: validator.ErrInvalidShelfData},
+		{name: "already on the shelf", entries: map[string]float64{"new": 1}, wantErr: repository.ErrBookAlreadyOnShelf},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shelves := newFakeShelfRepo(shelfOf(tt.entries))
+			uc := NewShelfUseCase(shelves, shelfBooks("a", "b", "new"))
+
+			shelf, err := uc.AddBook("s1", "new", tt.placement)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddBook error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got := entryOrder(shelf); !equalStrings(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShelfConcurrentEdits(t *testing.T) {
+	// Com a mesma posição, a entrada mais antiga viria primeiro.
+	later := time.Now().Add(time.Hour)
+	tests := []struct {
+		name    string
+		entries map[string]float64
+		// concurrent is added by someone else after the shelf was read.
+		concurrent domain.ShelfEntry
+		placement  ShelfPlacement
+		want       []string
+	}{
+		{
+			name:       "both appended",
+			entries:    map[string]float64{"a": 1},
+			concurrent: domain.ShelfEntry{BookID: "other", Position: 2, AddedAt: later},
+			want:       []string{"a", "other", "new"},
+		},
+		{
+			name:       "added while renumbering",
+			entries:    map[string]float64{"a": 1, "b": math.Nextafter(1, 2)},
+			concurrent: domain.ShelfEntry{BookID: "other", Position: 1.5, AddedAt: later},
+			placement:  ShelfPlacement{After: "a"},
+			want:       []string{"a", "new", "b", "other"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shelves := newFakeShelfRepo(shelfOf(tt.entries))
+			uc := NewShelfUseCase(shelves, shelfBooks("a", "b", "new", "other"))
+			shelves.beforeWrite = func() {
+				shelves.beforeWrite = nil
+				if err := shelves.AddEntry("s1", shelves.shelves["s1"].Revision, tt.concurrent); err != nil {
+					t.Fatalf("concurrent AddEntry: %v", err)
+				}
+			}
+
+			shelf, err := uc.AddBook("s1", "new", tt.placement)
+			if err != nil {
+				t.Fatalf("AddBook: %v", err)
+			}
+			if got := entryOrder(shelf); !equalStrings(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetShelfSkipsDeletedBooks(t *testing.T) {
+	shelves := newFakeShelfRepo(shelfOf(map[string]float64{"a": 1, "gone": 2, "b": 3}))
+	uc := NewShelfUseCase(shelves, shelfBooks("a", "b"))
+
+	details, err := uc.GetShelf("s1")
+	if err != nil {
+		t.Fatalf("GetShelf: %v", err)
+	}
+	if len(details.Books) != 2 || details.Books[0].ID != "a" || details.Books[1].ID != "b" {
+		t.Errorf("books = %+v, want a and b", details.Books)
+	}
+	if shelves.writes != 0 || len(shelves.shelves["s1"].Entries) != 3 {
+		t.Errorf("GetShelf wrote to the shelf")
+	}
+}
+
+func TestDeleteBookTakesItOffShelves(t *testing.T) {
+	books := shelfBooks("a", "b")
+	shelves := newFakeShelfRepo(shelfOf(map[string]float64{"a": 1, "b": 2}))
+	uc := NewBookUseCase(books, &fakeAuthorRepo{}, &fakeSeriesRepo{}, &fakeTagRepo{}, &fakeWorkRepo{}, &fakeCopyRepo{}, &fakeLoanRepo{}, shelves, nil, nil)
+
+	if err := uc.DeleteBook("a"); err != nil {
+		t.Fatalf("DeleteBook: %v", err)
+	}
+	if got := entryOrder(shelves.shelves["s1"]); !equalStrings(got, []string{"b"}) {
+		t.Errorf("shelf entries = %v, want [b]", got)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+var (
+	ErrTagCycle = errors.New("a tag cannot be moved or merged into itself or its descendants")
+)
+
+// TagNode is a tag of the tree with the number of books tagged with it or
+// with one of its descendants.
+type TagNode struct {
+	*domain.Tag
+	Count    int        `json:"count"`
+	Children []*TagNode `json:"children,omitempty"`
+}
+
+type TagUseCase interface {
+	CreateTag(tag *domain.Tag) error
+	// GetTagTree returns the root tags with their descendants and counts.
+	GetTagTree() ([]*TagNode, error)
+	GetTag(id string) (*TagNode, error)
+	// UpdateTag changes the description of a tag; its path only changes
+	// through RenameTag, MoveTag and MergeTag.
+	UpdateTag(tag *domain.Tag) error
+	RenameTag(id, name string) (*domain.Tag, error)
+	// MoveTag moves a tag and its descendants below parent, or to the root
+	// when parent is empty.
+	MoveTag(id, parent string) (*domain.Tag, error)
+	// MergeTag folds the source tag and its descendants into the target tag
+	// and deletes the source.
+	MergeTag(sourceID, targetID string) (*domain.Tag, error)
+	// DeleteTag removes a tag and its descendants from the tree and from
+	// every book.
+	DeleteTag(id string) error
+	TagBook(bookID string, tags []string) (*domain.Book, error)
+	UntagBook(bookID string, tags []string) (*domain.Book, error)
+	GetHistory() ([]*domain.TagChange, error)
+}
+
+type tagUseCase struct {
+	tagRepo     repository.TagRepository
+	historyRepo repository.TagHistoryRepository
+	bookRepo    repository.BookRepository
+}
+
+func NewTagUseCase(tr repository.TagRepository, hr repository.TagHistoryRepository, br repository.BookRepository) TagUseCase {
+	return &tagUseCase{
+		tagRepo:     tr,
+		historyRepo: hr,
+		bookRepo:    br,
+	}
+}
+
+// CreateTag creates a tag from its path, along with any missing ancestor.
+func (uc *tagUseCase) CreateTag(tag *domain.Tag) error {
+	path := domain.NormalizeTagPath(tag.Path)
+	if path == "" {
+		return fmt.Errorf("%w: path is required", validator.ErrInvalidTagData)
+	}
+	tag.SetPath(path)
+	if tag.Parent != "" {
+		if err := uc.tagRepo.EnsurePaths([]string{tag.Parent}); err != nil {
+			return err
+		}
+	}
+	return uc.tagRepo.Create(tag)
+}
+
+func (uc *tagUseCase) GetTagTree() ([]*TagNode, error) {
+	tags, err := uc.tagRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return uc.buildTree(tags)
+}
+
+func (uc *tagUseCase) GetTag(id string) (*TagNode, error) {
+	tag, err := uc.tagRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	subtree, err := uc.tagRepo.GetSubtree(tag.Path)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := uc.buildTree(subtree)
+	if err != nil {
+		return nil, err
+	}
+	return nodes[0], nil
+}
+
+// buildTree arranges tags sorted by path into trees, returning their roots.
+func (uc *tagUseCase) buildTree(tags []*domain.Tag) ([]*TagNode, error) {
+	counts, err := uc.bookRepo.TagCounts()
+	if err != nil {
+		return nil, err
+	}
+
+	roots := []*TagNode{}
+	nodes := make(map[string]*TagNode)
+	for _, tag := range tags {
+		node := &TagNode{Tag: tag, Count: counts[tag.Path]}
+		nodes[tag.Path] = node
+		if parent, ok := nodes[tag.Parent]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots, nil
+}
+
+func (uc *tagUseCase) UpdateTag(tag *domain.Tag) error {
+	existing, err := uc.tagRepo.GetByID(tag.ID)
+	if err != nil {
+		return err
+	}
+	existing.Description = strings.TrimSpace(tag.Description)
+	if err := uc.tagRepo.Update(existing); err != nil {
+		return err
+	}
+	*tag = *existing
+	return nil
+}
+
+func (uc *tagUseCase) RenameTag(id, name string) (*domain.Tag, error) {
+	name = domain.NormalizeTagPath(name)
+	if name == "" || strings.Contains(name, domain.TagSeparator) {
+		return nil, fmt.Errorf("%w: name is required and cannot contain %q", validator.ErrInvalidTagData, domain.TagSeparator)
+	}
+	tag, err := uc.tagRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	path := name
+	if tag.Parent != "" {
+		path = tag.Parent + domain.TagSeparator + name
+	}
+	return uc.relocate(tag, path, domain.TagRenamed)
+}
+
+func (uc *tagUseCase) MoveTag(id, parent string) (*domain.Tag, error) {
+	tag, err := uc.tagRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	path := tag.Name
+	if parent = domain.NormalizeTagPath(parent); parent != "" {
+		path = parent + domain.TagSeparator + tag.Name
+	}
+	return uc.relocate(tag, path, domain.TagMoved)
+}
+
+// relocate gives a tag a new path, carrying its descendants and the books
+// tagged with any of them. The new path must be free.
+func (uc *tagUseCase) relocate(tag *domain.Tag, path, action string) (*domain.Tag, error) {
+	if path == tag.Path {
+		return tag, nil
+	}
+	if domain.IsTagWithin(path, tag.Path) {
+		return nil, ErrTagCycle
+	}
+	if _, err := uc.tagRepo.GetByPath(path); err == nil {
+		return nil, repository.ErrDuplicateTag
+	} else if !errors.Is(err, repository.ErrTagNotFound) {
+		return nil, err
+	}
+
+	subtree, err := uc.tagRepo.GetSubtree(tag.Path)
+	if err != nil {
+		return nil, err
+	}
+	if parent := domain.TagParent(path); parent != "" {
+		if err := uc.tagRepo.EnsurePaths([]string{parent}); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range subtree {
+		t.SetPath(domain.RebaseTag(t.Path, tag.Path, path))
+		if err := uc.tagRepo.Update(t); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.retag(action, tag.Path, path); err != nil {
+		return nil, err
+	}
+	return uc.tagRepo.GetByID(tag.ID)
+}
+
+func (uc *tagUseCase) MergeTag(sourceID, targetID string) (*domain.Tag, error) {
+	source, err := uc.tagRepo.GetByID(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := uc.tagRepo.GetByID(targetID)
+	if err != nil {
+		return nil, err
+	}
+	if domain.IsTagWithin(target.Path, source.Path) {
+		return nil, ErrTagCycle
+	}
+
+	subtree, err := uc.tagRepo.GetSubtree(source.Path)
+	if err != nil {
+		return nil, err
+	}
+	// Descendentes que já existem no destino são fundidos; os demais são movidos.
+	var paths []string
+	for _, t := range subtree {
+		path := domain.RebaseTag(t.Path, source.Path, target.Path)
+		paths = append(paths, path)
+		_, err := uc.tagRepo.GetByPath(path)
+		switch {
+		case err == nil:
+			err = uc.tagRepo.Delete(t.ID)
+		case errors.Is(err, repository.ErrTagNotFound):
+			t.SetPath(path)
+			err = uc.tagRepo.Update(t)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.retag(domain.TagMerged, source.Path, target.Path); err != nil {
+		return nil, err
+	}
+	if err := uc.tagRepo.EnsurePaths(paths); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+func (uc *tagUseCase) DeleteTag(id string) error {
+	tag, err := uc.tagRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	subtree, err := uc.tagRepo.GetSubtree(tag.Path)
+	if err != nil {
+		return err
+	}
+	// Os livros são alterados primeiro, para que uma falha não deixe tags fora da árvore.
+	if err := uc.retag(domain.TagDeleted, tag.Path, ""); err != nil {
+		return err
+	}
+	for _, t := range subtree {
+		if err := uc.tagRepo.Delete(t.ID); err != nil && !errors.Is(err, repository.ErrTagNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// retag rewrites the tags of the books and records the change in the history.
+func (uc *tagUseCase) retag(action, from, to string) error {
+	bookIDs, err := uc.bookRepo.RetagBooks(from, to)
+	if err != nil {
+		return err
+	}
+	return uc.historyRepo.AddChange(&domain.TagChange{
+		Action:    action,
+		From:      from,
+		To:        to,
+		BookIDs:   bookIDs,
+		CreatedAt: time.Now(),
+	})
+}
+
+// TagBook adds tags to a book, creating the tags missing from the tree.
+func (uc *tagUseCase) TagBook(bookID string, tags []string) (*domain.Book, error) {
+	tags = domain.NormalizeTags(tags)
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("%w: at least one tag is required", validator.ErrInvalidTagData)
+	}
+	if _, err := uc.bookRepo.GetByID(bookID); err != nil {
+		return nil, err
+	}
+	if err := uc.tagRepo.EnsurePaths(tags); err != nil {
+		return nil, err
+	}
+	if err := uc.bookRepo.AddTags(bookID, tags); err != nil {
+		return nil, err
+	}
+	return uc.bookRepo.GetByID(bookID)
+}
+
+// UntagBook removes tags from a book. Tags below them are kept.
+func (uc *tagUseCase) UntagBook(bookID string, tags []string) (*domain.Book, error) {
+	tags = domain.NormalizeTags(tags)
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("%w: at least one tag is required", validator.ErrInvalidTagData)
+	}
+	if err := uc.bookRepo.RemoveTags(bookID, tags); err != nil {
+		return nil, err
+	}
+	return uc.bookRepo.GetByID(bookID)
+}
+
+func (uc *tagUseCase) GetHistory() ([]*domain.TagChange, error) {
+	return uc.historyRepo.GetChanges()
+}
@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+func tagged(id string, tags ...string) *domain.Book {
+	return &domain.Book{ID: id, Title: id, Tags: tags}
+}
+
+func TestGetTagTree(t *testing.T) {
+	tags := newFakeTagRepo("fiction/sci-fi/cyberpunk", "fiction/fantasy", "poetry")
+	books := newFakeBookRepo(
+		tagged("b1", "fiction/sci-fi/cyberpunk"),
+		// Duas tags sob "fiction" contam uma vez só para ela.
+		tagged("b2", "fiction/sci-fi", "fiction/fantasy"),
+		tagged("b3", "poetry"),
+	)
+	uc := NewTagUseCase(tags, &fakeTagHistoryRepo{}, books)
+
+	roots, err := uc.GetTagTree()
+	if err != nil {
+		t.Fatalf("GetTagTree: %v", err)
+	}
+	var got []string
+	var walk func(nodes []*TagNode, depth int)
+	walk = func(nodes []*TagNode, depth int) {
+		for _, n := range nodes {
+			got = append(got, fmt.Sprintf("%s%s %d", strings.Repeat("  ", depth), n.Name, n.Count))
+			walk(n.Children, depth+1)
+		}
+	}
+	walk(roots, 0)
+	want := []string{"fiction 2", "  fantasy 1", "  sci-fi 2", "    cyberpunk 1", "poetry 1"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("tree =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	node, err := uc.GetTag(mustTag(t, tags, "fiction/sci-fi").ID)
+	if err != nil || node.Count != 2 || len(node.Children) != 1 {
+		t.Errorf("GetTag = %+v, %v; want sci-fi with its child and 2 books", node, err)
+	}
+}
+
+func TestRelocateTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		op      func(uc TagUseCase, id string) (*domain.Tag, error)
+		wantErr error
+		// wantPaths and wantTags are the tree and the tags of book b1 afterwards.
+		wantPaths string
+		wantTags  string
+		wantBooks string
+	}{
+		{
+			name:      "rename carries descendants and books",
+			tag:       "fiction/sci-fi",
+			op:        func(uc TagUseCase, id string) (*domain.Tag, error) { return uc.RenameTag(id, " science  fiction ") },
+			wantPaths: "fiction|fiction/fantasy|fiction/science fiction|fiction/science fiction/cyberpunk|poetry",
+			wantTags:  "fiction/science fiction/cyberpunk|poetry",
+			wantBooks: "b1 b2",
+		},
+		{
+			name:      "move to another parent",
+			tag:       "fiction/sci-fi",
+			op:        func(uc TagUseCase, id string) (*domain.Tag, error) { return uc.MoveTag(id, "genres/speculative") },
+			wantPaths: "fiction|fiction/fantasy|genres|genres/speculative|genres/speculative/sci-fi|genres/speculative/sci-fi/cyberpunk|poetry",
+			wantTags:  "genres/speculative/sci-fi/cyberpunk|poetry",
+			wantBooks: "b1 b2",
+		},
+		{
+			name:      "move to the root",
+			tag:       "fiction/sci-fi/cyberpunk",
+			op:        func(uc TagUseCase, id string) (*domain.Tag, error) { return uc.MoveTag(id, "") },
+			wantPaths: "cyberpunk|fiction|fiction/fantasy|fiction/sci-fi|poetry",
+			wantTags:  "cyberpunk|poetry",
+			wantBooks: "b1",
+		},
+		{
+			name:    "move below itself",
+			tag:     "fiction",
+			op:      func(uc TagUseCase, id string) (*domain.Tag, error) { return uc.MoveTag(id, "fiction/sci-fi") },
+			wantErr: ErrTagCycle,
+		},
+		{
+			name:    "rename onto an existing tag",
+			tag:     "fiction/sci-fi",
+			op:      func(uc TagUseCase, id string) (*domain.Tag, error) { return uc.RenameTag(id, "fantasy") },
+			wantErr: repository.ErrDuplicateTag,
+		},
+		{
+			name:    "rename to a path",
+			tag:     "fiction/sci-fi",
+			op:      func(uc TagUseCase, id string) (*domain.Tag, error) { return uc.RenameTag(id, "a/b") },
+			wantErr: validator.ErrInvalidTagData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := newFakeTagRepo("fiction/sci-fi/cyberpunk", "fiction/fantasy", "poetry")
+			history := &fakeTagHistoryRepo{}
+			books := newFakeBookRepo(
+				tagged("b1", "fiction/sci-fi/cyberpunk", "poetry"),
+				tagged("b2", "fiction/sci-fi"),
+				tagged("b3", "fiction/fantasy"),
+			)
+			uc := NewTagUseCase(tags, history, books)
+			before := strings.Join(tags.paths(), "|")
+
+			_, err := tt.op(uc, mustTag(t, tags, tt.tag).ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if got := strings.Join(tags.paths(), "|"); got != before || len(history.changes) != 0 {
+					t.Errorf("a failed change touched the tree: %s, %d changes", got, len(history.changes))
+				}
+				return
+			}
+
+			if got := strings.Join(tags.paths(), "|"); got != tt.wantPaths {
+				t.Errorf("paths = %s\nwant %s", got, tt.wantPaths)
+			}
+			if got := strings.Join(books.books["b1"].Tags, "|"); got != tt.wantTags {
+				t.Errorf("b1 tags = %s, want %s", got, tt.wantTags)
+			}
+			if got := strings.Join(books.books["b3"].Tags, "|"); got != "fiction/fantasy" {
+				t.Errorf("b3 tags = %s, want them untouched", got)
+			}
+			if len(history.changes) != 1 || strings.Join(history.changes[0].BookIDs, " ") != tt.wantBooks {
+				t.Errorf("history = %+v, want one change listing %s", history.changes, tt.wantBooks)
+			}
+		})
+	}
+}
+
+func TestMergeTag(t *testing.T) {
+	tags := newFakeTagRepo("sf/cyberpunk", "sf/space opera", "fiction/sci-fi/cyberpunk")
+	history := &fakeTagHistoryRepo{}
+	books := newFakeBookRepo(
+		tagged("b1", "sf/cyberpunk", "fiction/sci-fi/cyberpunk"),
+		tagged("b2", "sf/space opera"),
+		tagged("b3", "sf"),
+	)
+	uc := NewTagUseCase(tags, history, books)
+
+	if _, err := uc.MergeTag(mustTag(t, tags, "fiction").ID, mustTag(t, tags, "fiction/sci-fi").ID); !errors.Is(err, ErrTagCycle) {
+		t.Fatalf("merge into a descendant error = %v, want %v", err, ErrTagCycle)
+	}
+
+	target, err := uc.MergeTag(mustTag(t, tags, "sf").ID, mustTag(t, tags, "fiction/sci-fi").ID)
+	if err != nil {
+		t.Fatalf("MergeTag: %v", err)
+	}
+	if target.Path != "fiction/sci-fi" {
+		t.Errorf("target = %s, want fiction/sci-fi", target.Path)
+	}
+	want := "fiction|fiction/sci-fi|fiction/sci-fi/cyberpunk|fiction/sci-fi/space opera"
+	if got := strings.Join(tags.paths(), "|"); got != want {
+		t.Errorf("paths = %s\nwant %s", got, want)
+	}
+	wantTags := map[string]string{
+		"b1": "fiction/sci-fi/cyberpunk",
+		"b2": "fiction/sci-fi/space opera",
+		"b3": "fiction/sci-fi",
+	}
+	for id, tagsWant := range wantTags {
+		if got := strings.Join(books.books[id].Tags, "|"); got != tagsWant {
+			t.Errorf("%s tags = %s, want %s", id, got, tagsWant)
+		}
+	}
+	if len(history.changes) != 1 || history.changes[0].Action != domain.TagMerged {
+		t.Errorf("history = %+v, want one merge", history.changes)
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	tags := newFakeTagRepo("fiction/sci-fi/cyberpunk", "fiction/fantasy")
+	books := newFakeBookRepo(tagged("b1", "fiction/sci-fi/cyberpunk", "fiction/fantasy"))
+	uc := NewTagUseCase(tags, &fakeTagHistoryRepo{}, books)
+
+	if err := uc.DeleteTag(mustTag(t, tags, "fiction/sci-fi").ID); err != nil {
+		t.Fatalf("DeleteTag: %v", err)
+	}
+	if got := strings.Join(tags.paths(), "|"); got != "fiction|fiction/fantasy" {
+		t.Errorf("paths = %s, want the subtree gone", got)
+	}
+	if got := strings.Join(books.books["b1"].Tags, "|"); got != "fiction/fantasy" {
+		t.Errorf("b1 tags = %s, want fiction/fantasy", got)
+	}
+}
+
+func TestTagBook(t *testing.T) {
+	tags := newFakeTagRepo("fiction")
+	books := newFakeBookRepo(tagged("b1", "fiction"))
+	uc := NewTagUseCase(tags, &fakeTagHistoryRepo{}, books)
+
+	book, err := uc.TagBook("b1", []string{" fiction / sci-fi ", "fiction/sci-fi", "", "fiction"})
+	if err != nil {
+		t.Fatalf("TagBook: %v", err)
+	}
+	if got := strings.Join(book.Tags, "|"); got != "fiction|fiction/sci-fi" {
+		t.Errorf("tags = %s, want fiction|fiction/sci-fi", got)
+	}
+	if got := strings.Join(tags.paths(), "|"); got != "fiction|fiction/sci-fi" {
+		t.Errorf("paths = %s, want the new tag in the tree", got)
+	}
+
+	if _, err := uc.TagBook("b1", []string{" / "}); !errors.Is(err, validator.ErrInvalidTagData) {
+		t.Errorf("TagBook without tags error = %v, want %v", err, validator.ErrInvalidTagData)
+	}
+	if _, err := uc.TagBook("b9", []string{"poetry"}); !errors.Is(err, repository.ErrBookNotFound) {
+		t.Errorf("TagBook of a missing book error = %v, want %v", err, repository.ErrBookNotFound)
+	}
+	if _, err := tags.GetByPath("poetry"); err == nil {
+		t.Error("tagging a missing book created its tags")
+	}
+}
+
+func mustTag(t *testing.T, tags *fakeTagRepo, path string) *domain.Tag {
+	t.Helper()
+	tag, err := tags.GetByPath(path)
+	if err != nil {
+		t.Fatalf("tag %s: %v", path, err)
+	}
+	return tag
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/metadata"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+// ShareLink is the public address of the wishlist.
+type ShareLink struct {
+	*domain.WishlistShare
+	URL string `json:"url"`
+}
+
+// SharedWishlistItem is a wishlist item as friends see it through the share
+// link: whether it is reserved, but not by whom.
+type SharedWishlistItem struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Author   string `json:"author,omitempty"`
+	ISBN     string `json:"isbn,omitempty"`
+	Edition  string `json:"edition,omitempty"`
+	Format   string `json:"format,omitempty"`
+	Priority string `json:"priority"`
+	Notes    string `json:"notes,omitempty"`
+	Reserved bool   `json:"reserved"`
+}
+
+// ReservationReceipt carries the code needed to cancel a reservation.
+type ReservationReceipt struct {
+	ItemID string `json:"item_id"`
+	Code   string `json:"code"`
+}
+
+type WishlistUseCase interface {
+	AddItem(item *domain.WishlistItem) error
+	// GetItems returns the wishlist, the most wanted first.
+	GetItems() ([]*domain.WishlistItem, error)
+	GetItem(id string) (*domain.WishlistItem, error)
+	UpdateItem(item *domain.WishlistItem) error
+	DeleteItem(id string) error
+	// Acquire moves an item into the library as a new book. Fields of draft
+	// take precedence over those of the item.
+	Acquire(id string, draft *domain.Book) error
+
+	// Share creates a new share link, revoking the previous one.
+	Share() (*ShareLink, error)
+	GetShare() (*ShareLink, error)
+	RevokeShare() error
+
+	// GetSharedWishlist returns the wishlist seen through a share link.
+	GetSharedWishlist(token string) ([]*SharedWishlistItem, error)
+	Reserve(token, id string) (*ReservationReceipt, error)
+	CancelReservation(token, id, code string) error
+}
+
+type wishlistUseCase struct {
+	wishlistRepo repository.WishlistRepository
+	shareRepo    repository.WishlistShareRepository
+	bookUseCase  BookUseCase
+	publicURL    string
+}
+
+func NewWishlistUseCase(wr repository.WishlistRepository, sr repository.WishlistShareRepository, bu BookUseCase, publicURL string) WishlistUseCase {
+	return &wishlistUseCase{
+		wishlistRepo: wr,
+		shareRepo:    sr,
+		bookUseCase:  bu,
+		publicURL:    strings.TrimSuffix(publicURL, "/"),
+	}
+}
+
+func (uc *wishlistUseCase) AddItem(item *domain.WishlistItem) error {
+	if err := validator.ValidateWishlistItem(item); err != nil {
+		return err
+	}
+	item.Reservation = nil
+	item.CreatedAt = time.Now()
+	return uc.wishlistRepo.Create(item)
+}
+
+func (uc *wishlistUseCase) GetItems() ([]*domain.WishlistItem, error) {
+	items, err := uc.wishlistRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []*domain.WishlistItem{}
+	}
+	// Os itens vêm do mais antigo ao mais novo; a ordem se mantém em cada prioridade.
+	sort.SliceStable(items, func(i, j int) bool {
+		return domain.PriorityRank(items[i].Priority) < domain.PriorityRank(items[j].Priority)
+	})
+	return items, nil
+}
+
+func (uc *wishlistUseCase) GetItem(id string) (*domain.WishlistItem, error) {
+	return uc.wishlistRepo.GetByID(id)
+}
+
+func (uc *wishlistUseCase) UpdateItem(item *domain.WishlistItem) error {
+	if err := validator.ValidateWishlistItem(item); err != nil {
+		return err
+	}
+	if err := uc.wishlistRepo.Update(item); err != nil {
+		return err
+	}
+
+	updated, err := uc.wishlistRepo.GetByID(item.ID)
+	if err != nil {
+		return err
+	}
+	*item = *updated
+	return nil
+}
+
+func (uc *wishlistUseCase) DeleteItem(id string) error {
+	return uc.wishlistRepo.Delete(id)
+}
+
+// Acquire creates the book and then drops the item, so a book that fails
+// validation leaves the wishlist untouched.
+func (uc *wishlistUseCase) Acquire(id string, draft *domain.Book) error {
+	item, err := uc.wishlistRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	draft.ID = ""
+	metadata.Merge(draft, &domain.Book{
+		Title:   item.Title,
+		Author:  item.Author,
+		ISBN13:  item.ISBN,
+		Edition: item.Edition,
+	})
+	if err := uc.bookUseCase.CreateBook(draft); err != nil {
+		return err
+	}
+	err = uc.wishlistRepo.Delete(id)
+	if errors.Is(err, repository.ErrWishlistItemNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (uc *wishlistUseCase) Share() (*ShareLink, error) {
+	share := &domain.WishlistShare{Token: uuid.New().String(), CreatedAt: time.Now()}
+	if err := uc.shareRepo.Replace(share); err != nil {
+		return nil, err
+	}
+	return uc.link(share), nil
+}
+
+func (uc *wishlistUseCase) GetShare() (*ShareLink, error) {
+	share, err := uc.shareRepo.Get()
+	if err != nil {
+		return nil, err
+	}
+	return uc.link(share), nil
+}
+
+func (uc *wishlistUseCase) link(share *domain.WishlistShare) *ShareLink {
+	return &ShareLink{WishlistShare: share, URL: uc.publicURL + "/shared/wishlist/" + share.Token}
+}
+
+func (uc *wishlistUseCase) RevokeShare() error {
+	return uc.shareRepo.DeleteAll()
+}
+
+func (uc *wishlistUseCase) GetSharedWishlist(token string) ([]*SharedWishlistItem, error) {
+	if err := uc.checkShare(token); err != nil {
+		return nil, err
+	}
+	items, err := uc.GetItems()
+	if err != nil {
+		return nil, err
+	}
+
+	shared := make([]*SharedWishlistItem, 0, len(items))
+	for _, item := range items {
+		shared = append(shared, &SharedWishlistItem{
+			ID:       item.ID,
+			Title:    item.Title,
+			Author:   item.Author,
+			ISBN:     item.ISBN,
+			Edition:  item.Edition,
+			Format:   item.Format,
+			Priority: item.Priority,
+			Notes:    item.Notes,
+			Reserved: item.Reservation != nil,
+		})
+	}
+	return shared, nil
+}
+
+func (uc *wishlistUseCase) Reserve(token, id string) (*ReservationReceipt, error) {
+	if err := uc.checkShare(token); err != nil {
+		return nil, err
+	}
+	reservation := domain.Reservation{Code: uuid.New().String(), ReservedAt: time.Now()}
+	if err := uc.wishlistRepo.Reserve(id, reservation); err != nil {
+		return nil, err
+	}
+	return &ReservationReceipt{ItemID: id, Code: reservation.Code}, nil
+}
+
+func (uc *wishlistUseCase) CancelReservation(token, id, code string) error {
+	if err := uc.checkShare(token); err != nil {
+		return err
+	}
+	if code == "" {
+		return repository.ErrNotReserved
+	}
+	return uc.wishlistRepo.CancelReservation(id, code)
+}
+
+// checkShare reports ErrShareNotFound for tokens of revoked or unknown links.
+func (uc *wishlistUseCase) checkShare(token string) error {
+	ok, err := uc.shareRepo.Exists(token)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return repository.ErrShareNotFound
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+func newTestWishlist(books *fakeBookRepo, items ...*domain.WishlistItem) (WishlistUseCase, *fakeWishlistRepo) {
+	wishlist := &fakeWishlistRepo{items: items}
+	bookUseCase, _ := newTestBookUseCase(books, nil)
+	return NewWishlistUseCase(wishlist, &fakeWishlistShareRepo{}, bookUseCase, "https://books.example.com/"), wishlist
+}
+
+func TestAddItem(t *testing.T) {
+	tests := []struct {
+		name         string
+		item         domain.WishlistItem
+		wantErr      error
+		wantPriority string
+		wantISBN     string
+	}{
+		{name: "defaults", item: domain.WishlistItem{Title: " A Hora da Estrela "}, wantPriority: domain.PriorityMedium},
+		{name: "ISBN-10", item: domain.WishlistItem{Title: "A Hora da Estrela", ISBN: "0-306-40615-2", Priority: domain.PriorityHigh}, wantPriority: domain.PriorityHigh, wantISBN: "9780306406157"},
+		{name: "no title", item: domain.WishlistItem{Title: " "}, wantErr: validator.ErrInvalidWishlistData},
+		{name: "unknown priority", item: domain.WishlistItem{Title: "A Hora da Estrela", Priority: "urgent"}, wantErr: validator.ErrInvalidWishlistData},
+		{name: "unknown format", item: domain.WishlistItem{Title: "A Hora da Estrela", Format: "scroll"}, wantErr: validator.ErrInvalidWishlistData},
+		{name: "invalid ISBN", item: domain.WishlistItem{Title: "A Hora da Estrela", ISBN: "9780306406158"}, wantErr: validator.ErrInvalidWishlistData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, wishlist := newTestWishlist(newFakeBookRepo())
+
+			item := tt.item
+			// Uma reserva no corpo da requisição é ignorada.
+			item.Reservation = &domain.Reservation{Code: "forged"}
+			if err := uc.AddItem(&item); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddItem error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(wishlist.items) != 0 {
+					t.Errorf("%d items, want the invalid one left out", len(wishlist.items))
+				}
+				return
+			}
+			stored, _ := wishlist.GetByID(item.ID)
+			if stored.Title != "A Hora da Estrela" || stored.Priority != tt.wantPriority || stored.ISBN != tt.wantISBN {
+				t.Errorf("item = %q %s %q, want %s %q", stored.Title, stored.Priority, stored.ISBN, tt.wantPriority, tt.wantISBN)
+			}
+			if stored.Reservation != nil || stored.CreatedAt.IsZero() {
+				t.Errorf("item = %+v, want it unreserved and dated", stored)
+			}
+		})
+	}
+}
+
+func TestGetItems(t *testing.T) {
+	uc, _ := newTestWishlist(newFakeBookRepo(),
+		&domain.WishlistItem{ID: "w1", Title: "Lavoura Arcaica", Priority: domain.PriorityLow},
+		&domain.WishlistItem{ID: "w2", Title: "Vidas Secas", Priority: domain.PriorityMedium},
+		&domain.WishlistItem{ID: "w3", Title: "São Bernardo", Priority: domain.PriorityHigh},
+		&domain.WishlistItem{ID: "w4", Title: "Angústia", Priority: domain.PriorityMedium},
+	)
+
+	items, err := uc.GetItems()
+	if err != nil {
+		t.Fatalf("GetItems: %v", err)
+	}
+	var got []string
+	for _, item := range items {
+		got = append(got, item.ID)
+	}
+	if strings.Join(got, " ") != "w3 w2 w4 w1" {
+		t.Errorf("items = %v, want w3 w2 w4 w1", got)
+	}
+}
+
+func TestUpdateItemKeepsReservation(t *testing.T) {
+	uc, wishlist := newTestWishlist(newFakeBookRepo(), &domain.WishlistItem{
+		ID: "w1", Title: "Vidas Secas", Priority: domain.PriorityLow, Reservation: &domain.Reservation{Code: "c1"},
+	})
+
+	item := &domain.WishlistItem{ID: "w1", Title: "Vidas Secas", Priority: domain.PriorityHigh}
+	if err := uc.UpdateItem(item); err != nil {
+		t.Fatalf("UpdateItem: %v", err)
+	}
+	if item.Priority != domain.PriorityHigh || wishlist.items[0].Reservation == nil {
+		t.Errorf("item = %+v, want the new priority and the reservation kept", wishlist.items[0])
+	}
+	if err := uc.UpdateItem(&domain.WishlistItem{ID: "w9", Title: "Angústia"}); !errors.Is(err, repository.ErrWishlistItemNotFound) {
+		t.Errorf("UpdateItem of a missing item error = %v, want %v", err, repository.ErrWishlistItemNotFound)
+	}
+}
+
+func TestAcquire(t *testing.T) {
+	tests := []struct {
+		name      string
+		draft     domain.Book
+		existing  *domain.Book
+		wantErr   error
+		wantTitle string
+	}{
+		{name: "from the item", draft: domain.Book{Pages: 88}, wantTitle: "A Hora da Estrela"},
+		{name: "draft takes precedence", draft: domain.Book{Title: "A Hora da Estrela (edição comemorativa)", Pages: 88}, wantTitle: "A Hora da Estrela (edição comemorativa)"},
+		{name: "invalid book", wantErr: validator.ErrInvalidBookData},
+		{name: "book already in the library", draft: domain.Book{Pages: 88}, existing: &domain.Book{ID: "b1", Title: "A Hora da Estrela", ISBN13: "9780306406157"}, wantErr: repository.ErrDuplicateISBN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			books := newFakeBookRepo()
+			if tt.existing != nil {
+				books = newFakeBookRepo(tt.existing)
+			}
+			uc, wishlist := newTestWishlist(books, &domain.WishlistItem{
+				ID: "w1", Title: "A Hora da Estrela", Author: "Clarice Lispector", ISBN: "9780306406157", Priority: domain.PriorityHigh,
+			})
+
+			draft := tt.draft
+			if err := uc.Acquire("w1", &draft); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Acquire error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(wishlist.items) != 1 {
+					t.Error("a failed acquisition dropped the item")
+				}
+				return
+			}
+			if len(wishlist.items) != 0 {
+				t.Error("the acquired item is still wished")
+			}
+			book, err := books.GetByID(draft.ID)
+			if err != nil || book.Title != tt.wantTitle || book.Author != "Clarice Lispector" || book.ISBN13 != "9780306406157" {
+				t.Errorf("book = %+v, %v; want %s by Clarice Lispector", book, err, tt.wantTitle)
+			}
+		})
+	}
+
+	uc, _ := newTestWishlist(newFakeBookRepo())
+	if err := uc.Acquire("w9", &domain.Book{}); !errors.Is(err, repository.ErrWishlistItemNotFound) {
+		t.Errorf("Acquire of a missing item error = %v, want %v", err, repository.ErrWishlistItemNotFound)
+	}
+}
+
+func TestShare(t *testing.T) {
+	uc, _ := newTestWishlist(newFakeBookRepo(), &domain.WishlistItem{ID: "w1", Title: "Vidas Secas", Priority: domain.PriorityHigh})
+
+	if _, err := uc.GetShare(); !errors.Is(err, repository.ErrShareNotFound) {
+		t.Errorf("GetShare before sharing error = %v, want %v", err, repository.ErrShareNotFound)
+	}
+	first, err := uc.Share()
+	if err != nil {
+		t.Fatalf("Share: %v", err)
+	}
+	if first.URL != "https://books.example.com/shared/wishlist/"+first.Token {
+		t.Errorf("URL = %s, want it under the public URL", first.URL)
+	}
+
+	second, _ := uc.Share()
+	if _, err := uc.GetSharedWishlist(first.Token); !errors.Is(err, repository.ErrShareNotFound) {
+		t.Errorf("GetSharedWishlist with a replaced link error = %v, want %v", err, repository.ErrShareNotFound)
+	}
+	shared, err := uc.GetSharedWishlist(second.Token)
+	if err != nil || len(shared) != 1 || shared[0].Title != "Vidas Secas" {
+		t.Errorf("GetSharedWishlist = %+v, %v; want Vidas Secas", shared, err)
+	}
+
+	if err := uc.RevokeShare(); err != nil {
+		t.Fatalf("RevokeShare: %v", err)
+	}
+	if _, err := uc.GetSharedWishlist(second.Token); !errors.Is(err, repository.ErrShareNotFound) {
+		t.Errorf("GetSharedWishlist with a revoked link error = %v, want %v", err, repository.ErrShareNotFound)
+	}
+}
+
+func TestReserve(t *testing.T) {
+	uc, wishlist := newTestWishlist(newFakeBookRepo(), &domain.WishlistItem{ID: "w1", Title: "Vidas Secas", Priority: domain.PriorityHigh})
+	link, _ := uc.Share()
+
+	if _, err := uc.Reserve("guessed", "w1"); !errors.Is(err, repository.ErrShareNotFound) {
+		t.Errorf("Reserve with an unknown link error = %v, want %v", err, repository.ErrShareNotFound)
+	}
+	receipt, err := uc.Reserve(link.Token, "w1")
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if _, err := uc.Reserve(link.Token, "w1"); !errors.Is(err, repository.ErrAlreadyReserved) {
+		t.Errorf("Reserve twice error = %v, want %v", err, repository.ErrAlreadyReserved)
+	}
+	if _, err := uc.Reserve(link.Token, "w9"); !errors.Is(err, repository.ErrWishlistItemNotFound) {
+		t.Errorf("Reserve of a missing item error = %v, want %v", err, repository.ErrWishlistItemNotFound)
+	}
+	if shared, _ := uc.GetSharedWishlist(link.Token); !shared[0].Reserved {
+		t.Error("the shared wishlist does not show the reservation")
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		wantErr error
+	}{
+		{name: "without code", wantErr: repository.ErrNotReserved},
+		{name: "wrong code", code: "guessed", wantErr: repository.ErrNotReserved},
+		{name: "right code", code: receipt.Code},
+		{name: "already cancelled", code: receipt.Code, wantErr: repository.ErrNotReserved},
+	}
+	for _, tt := range tests {
+		if err := uc.CancelReservation(link.Token, "w1", tt.code); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: CancelReservation error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+	if wishlist.items[0].Reservation != nil {
+		t.Error("the reservation was not cancelled")
+	}
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+var (
+	ErrWorkInUse = errors.New("the work still has editions")
+)
+
+// WorkDetails is a work with its editions, the copies of those editions and
+// its reading records.
+type WorkDetails struct {
+	*domain.Work
+	Editions   []*domain.Book     `json:"editions"`
+	Copies     []*domain.Copy     `json:"copies"`
+	ReadBooks  []*domain.ReadBook `json:"read_books"`
+	ReadStatus string             `json:"read_status"`
+}
+
+type WorkUseCase interface {
+	CreateWork(work *domain.Work) error
+	GetWork(id string) (*WorkDetails, error)
+	// GetWorks returns the works whose title contains title, or every work.
+	GetWorks(title string) ([]*domain.Work, error)
+	UpdateWork(work *domain.Work) error
+	// DeleteWork removes a work without editions.
+	DeleteWork(id string) error
+}
+
+type workUseCase struct {
+	workRepo     repository.WorkRepository
+	bookRepo     repository.BookRepository
+	copyRepo     repository.CopyRepository
+	readBookRepo repository.ReadBookRepository
+}
+
+func NewWorkUseCase(wr repository.WorkRepository, br repository.BookRepository, cr repository.CopyRepository, rbr repository.ReadBookRepository) WorkUseCase {
+	return &workUseCase{
+		workRepo:     wr,
+		bookRepo:     br,
+		copyRepo:     cr,
+		readBookRepo: rbr,
+	}
+}
+
+func (uc *workUseCase) CreateWork(work *domain.Work) error {
+	if err := validator.ValidateWork(work); err != nil {
+		return err
+	}
+	return uc.workRepo.Create(work)
+}
+
+func (uc *workUseCase) GetWork(id string) (*WorkDetails, error) {
+	work, err := uc.workRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	editions, err := uc.bookRepo.Search(domain.BookFilter{WorkID: id, Status: domain.BookStatusAll})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(editions))
+	for i, book := range editions {
+		ids[i] = book.ID
+	}
+	copies, err := uc.copyRepo.GetByBookIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	readBooks, err := uc.readBookRepo.GetByWorkID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &WorkDetails{
+		Work:       work,
+		Editions:   editions,
+		Copies:     copies,
+		ReadBooks:  readBooks,
+		ReadStatus: domain.ReadStatus(readBooks),
+	}
+	if details.Editions == nil {
+		details.Editions = []*domain.Book{}
+	}
+	if details.Copies == nil {
+		details.Copies = []*domain.Copy{}
+	}
+	if details.ReadBooks == nil {
+		details.ReadBooks = []*domain.ReadBook{}
+	}
+	return details, nil
+}
+
+func (uc *workUseCase) GetWorks(title string) ([]*domain.Work, error) {
+	return uc.workRepo.Search(title)
+}
+
+func (uc *workUseCase) UpdateWork(work *domain.Work) error {
+	if err := validator.ValidateWork(work); err != nil {
+		return err
+	}
+	return uc.workRepo.Update(work)
+}
+
+func (uc *workUseCase) DeleteWork(id string) error {
+	if _, err := uc.workRepo.GetByID(id); err != nil {
+		return err
+	}
+	editions, err := uc.bookRepo.Search(domain.BookFilter{WorkID: id, Status: domain.BookStatusAll})
+	if err != nil {
+		return err
+	}
+	if len(editions) > 0 {
+		return ErrWorkInUse
+	}
+	return uc.workRepo.Delete(id)
+}
+
+// linkWork links an edition to its work. A work ID must point to an existing
+// work; otherwise the work with the same title and author is used, and
+// created when there is none yet.
+func linkWork(works repository.WorkRepository, book *domain.Book) error {
+	if book.WorkID != "" {
+		_, err := works.GetByID(book.WorkID)
+		if errors.Is(err, repository.ErrWorkNotFound) {
+			return fmt.Errorf("%w: work %s not found", validator.ErrInvalidBookData, book.WorkID)
+		}
+		return err
+	}
+
+	work, err := works.FindByTitleAuthor(book.Title, book.Author)
+	if errors.Is(err, repository.ErrWorkNotFound) {
+		work = domain.WorkFromBook(book)
+		err = works.Create(work)
+	}
+	if err != nil {
+		return err
+	}
+	book.WorkID = work.ID
+	return nil
+}
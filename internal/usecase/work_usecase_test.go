@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/repository"
+	"github.com/rfulgencio3/go-personal-library/internal/validator"
+)
+
+func TestLinkWork(t *testing.T) {
+	tests := []struct {
+		name      string
+		book      domain.Book
+		wantErr   error
+		wantWork  string
+		wantWorks int
+	}{
+		{name: "existing work", book: domain.Book{Title: "Dom Casmurro", Author: "Machado de Assis", WorkID: "w1"}, wantWork: "w1", wantWorks: 1},
+		{name: "unknown work", book: domain.Book{Title: "Dom Casmurro", WorkID: "w9"}, wantErr: validator.ErrInvalidBookData, wantWorks: 1},
+		{name: "same title and author", book: domain.Book{Title: "DOM CASMURRO", Author: "machado de assis"}, wantWork: "w1", wantWorks: 1},
+		{name: "new work", book: domain.Book{Title: "Quincas Borba", Author: "Machado de Assis"}, wantWorks: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			works := &fakeWorkRepo{works: []*domain.Work{{ID: "w1", Title: "Dom Casmurro", Author: "Machado de Assis"}}}
+
+			book := tt.book
+			if err := linkWork(works, &book); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("linkWork error = %v, want %v", err, tt.wantErr)
+			}
+			if len(works.works) != tt.wantWorks {
+				t.Errorf("%d works, want %d", len(works.works), tt.wantWorks)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if tt.wantWork != "" && book.WorkID != tt.wantWork {
+				t.Errorf("work = %q, want %q", book.WorkID, tt.wantWork)
+			}
+			work, err := works.GetByID(book.WorkID)
+			if err != nil || !strings.EqualFold(work.Title, book.Title) {
+				t.Errorf("book linked to %+v, %v", work, err)
+			}
+		})
+	}
+}
+
+func TestGetWork(t *testing.T) {
+	finished := time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC)
+	works := &fakeWorkRepo{works: []*domain.Work{
+		{ID: "w1", Title: "Dom Casmurro", Author: "Machado de Assis"},
+		{ID: "w2", Title: "Quincas Borba", Author: "Machado de Assis"},
+	}}
+	books := newFakeBookRepo(
+		&domain.Book{ID: "b1", WorkID: "w1"},
+		&domain.Book{ID: "b2", WorkID: "w1", Status: domain.BookDonated},
+		&domain.Book{ID: "b3", WorkID: "w2"},
+	)
+	copies := &fakeCopyRepo{copies: []*domain.Copy{{ID: "c1", BookID: "b1"}, {ID: "c2", BookID: "b1"}, {ID: "c3", BookID: "b3"}}}
+	reads := &fakeReadBookRepo{readBooks: []*domain.ReadBook{
+		{ID: "r1", WorkID: "w1", BookID: "b2", ActualEndDate: &finished},
+		{ID: "r2", WorkID: "w2", BookID: "b3"},
+	}}
+	uc := NewWorkUseCase(works, books, copies, reads)
+
+	details, err := uc.GetWork("w1")
+	if err != nil {
+		t.Fatalf("GetWork: %v", err)
+	}
+	if len(details.Editions) != 2 || len(details.Copies) != 2 || len(details.ReadBooks) != 1 {
+		t.Errorf("got %d editions, %d copies and %d readings; want 2, 2 and 1",
+			len(details.Editions), len(details.Copies), len(details.ReadBooks))
+	}
+	if details.ReadStatus != domain.ReadStatusRead {
+		t.Errorf("read status = %s, want %s", details.ReadStatus, domain.ReadStatusRead)
+	}
+
+	// Listas vazias, e não nulas, para que o JSON traga [].
+	works.works = append(works.works, &domain.Work{ID: "w3", Title: "Esaú e Jacó", Author: "Machado de Assis"})
+	details, err = uc.GetWork("w3")
+	if err != nil || details.Editions == nil || details.Copies == nil || details.ReadBooks == nil {
+		t.Errorf("GetWork(w3) = %+v, %v; want empty lists", details, err)
+	}
+	if details.ReadStatus != domain.ReadStatusUnread {
+		t.Errorf("read status = %s, want %s", details.ReadStatus, domain.ReadStatusUnread)
+	}
+
+	if _, err := uc.GetWork("w9"); !errors.Is(err, repository.ErrWorkNotFound) {
+		t.Errorf("GetWork(w9) error = %v, want %v", err, repository.ErrWorkNotFound)
+	}
+}
+
+func TestDeleteWork(t *testing.T) {
+	tests := []struct {
+		id      string
+		wantErr error
+	}{
+		{id: "w1", wantErr: ErrWorkInUse},
+		{id: "w2"},
+		{id: "w9", wantErr: repository.ErrWorkNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			works := &fakeWorkRepo{works: []*domain.Work{{ID: "w1"}, {ID: "w2"}}}
+			// Edições que saíram da biblioteca continuam ligadas à obra.
+			books := newFakeBookRepo(&domain.Book{ID: "b1", WorkID: "w1", Status: domain.BookSold})
+			uc := NewWorkUseCase(works, books, &fakeCopyRepo{}, &fakeReadBookRepo{})
+
+			if err := uc.DeleteWork(tt.id); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteWork error = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := works.GetByID("w1"); err != nil {
+				t.Error("the work with editions was deleted")
+			}
+		})
+	}
+}
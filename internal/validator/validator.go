@@ -2,25 +2,313 @@ package validator
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/rfulgencio3/go-personal-library/internal/domain"
+	"github.com/rfulgencio3/go-personal-library/internal/isbn"
 )
 
 var (
-	ErrInvalidBookData = errors.New("invalid book data")
+	ErrInvalidBookData     = errors.New("invalid book data")
+	ErrInvalidAuthorData   = errors.New("invalid author data")
+	ErrInvalidSeriesData   = errors.New("invalid series data")
+	ErrInvalidTagData      = errors.New("invalid tag data")
+	ErrInvalidShelfData    = errors.New("invalid shelf data")
+	ErrInvalidLocationData = errors.New("invalid location data")
+	ErrInvalidWorkData     = errors.New("invalid work data")
+	ErrInvalidCopyData     = errors.New("invalid copy data")
+	ErrInvalidReadBookData = errors.New("invalid read book data")
+	ErrInvalidLoanData     = errors.New("invalid loan data")
+	ErrInvalidExchangeRate = errors.New("invalid exchange rate")
+	ErrInvalidWishlistData = errors.New("invalid wishlist data")
+	ErrInvalidDisposalData = errors.New("invalid disposal data")
 )
 
+// ValidateBook checks the required fields of a book. It also validates the
+// ISBN checksums and normalizes both fields, filling in the missing form
+// when only one of them is given, and synchronizes the contributors with
+// the author field.
 func ValidateBook(book *domain.Book) error {
+	return validateBook(book, true)
+}
+
+// ValidateImportedBook checks a book read from another catalogue, such as
+// Calibre or MARC, like ValidateBook but accepts an unknown page count.
+func ValidateImportedBook(book *domain.Book) error {
+	return validateBook(book, false)
+}
+
+func validateBook(book *domain.Book, requirePages bool) error {
 	if strings.TrimSpace(book.Title) == "" {
-		return errors.New("title is required")
+		return fmt.Errorf("%w: title is required", ErrInvalidBookData)
+	}
+	if err := validateContributors(book); err != nil {
+		return err
+	}
+	if book.Pages < 0 || (requirePages && book.Pages == 0) {
+		return fmt.Errorf("%w: pages must be greater than zero", ErrInvalidBookData)
+	}
+	if book.SeriesPosition < 0 {
+		return fmt.Errorf("%w: series_position must not be negative", ErrInvalidBookData)
+	}
+	book.Tags = domain.NormalizeTags(book.Tags)
+
+	return normalizeISBN(book)
+}
+
+// validateContributors checks the roles and names of the contributors and
+// requires at least one author, either in the list or in the author field.
+func validateContributors(book *domain.Book) error {
+	for i, c := range book.Contributors {
+		if strings.TrimSpace(c.Name) == "" {
+			return fmt.Errorf("%w: contributor name is required", ErrInvalidBookData)
+		}
+		if c.Role == "" {
+			book.Contributors[i].Role = domain.RoleAuthor
+		} else if !domain.IsContributorRole(c.Role) {
+			return fmt.Errorf("%w: contributor role must be one of %s", ErrInvalidBookData, strings.Join(domain.ContributorRoles, ", "))
+		}
+	}
+
+	book.SyncContributors()
+	if len(book.AuthorNames()) == 0 {
+		return fmt.Errorf("%w: at least one author is required", ErrInvalidBookData)
+	}
+	return nil
+}
+
+// ValidateAuthor checks the name and life years of an author and tidies
+// the alternate spellings and aliases, dropping blanks and repeated names.
+func ValidateAuthor(author *domain.Author) error {
+	author.Name = strings.Join(strings.Fields(author.Name), " ")
+	if author.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidAuthorData)
+	}
+	if author.BirthYear < 0 || author.DeathYear < 0 {
+		return fmt.Errorf("%w: years must not be negative", ErrInvalidAuthorData)
+	}
+	if author.BirthYear > 0 && author.DeathYear > 0 && author.DeathYear < author.BirthYear {
+		return fmt.Errorf("%w: death_year must not be before birth_year", ErrInvalidAuthorData)
+	}
+
+	seen := map[string]bool{strings.ToLower(author.Name): true}
+	author.AlternateSpellings = uniqueNames(author.AlternateSpellings, seen)
+	author.Aliases = uniqueNames(author.Aliases, seen)
+	return nil
+}
+
+// ValidateSeries checks the name of a series.
+func ValidateSeries(series *domain.Series) error {
+	series.Name = strings.Join(strings.Fields(series.Name), " ")
+	if series.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSeriesData)
+	}
+	return nil
+}
+
+// ValidateShelf checks the name of a shelf.
+func ValidateShelf(shelf *domain.Shelf) error {
+	shelf.Name = strings.Join(strings.Fields(shelf.Name), " ")
+	if shelf.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidShelfData)
+	}
+	return nil
+}
+
+// ValidateLocation checks the name and kind of a location.
+func ValidateLocation(location *domain.Location) error {
+	location.Name = strings.Join(strings.Fields(location.Name), " ")
+	if location.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidLocationData)
+	}
+	if domain.LocationDepth(location.Kind) < 0 {
+		return fmt.Errorf("%w: kind must be one of %s", ErrInvalidLocationData, strings.Join(domain.LocationKinds, ", "))
+	}
+	return nil
+}
+
+// ValidateWork checks the title, author and rating of a work.
+func ValidateWork(work *domain.Work) error {
+	work.Title = strings.TrimSpace(work.Title)
+	if work.Title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidWorkData)
+	}
+	work.Author = strings.TrimSpace(work.Author)
+	if work.Author == "" {
+		return fmt.Errorf("%w: author is required", ErrInvalidWorkData)
+	}
+	if work.Rating != nil && (*work.Rating < 1 || *work.Rating > 5) {
+		return fmt.Errorf("%w: rating must be between 1 and 5", ErrInvalidWorkData)
+	}
+	return nil
+}
+
+// ValidateCopy checks the condition and acquisition of a copy.
+func ValidateCopy(c *domain.Copy) error {
+	if c.Condition != "" && !domain.IsCopyCondition(c.Condition) {
+		return fmt.Errorf("%w: condition must be one of %s", ErrInvalidCopyData, strings.Join(domain.CopyConditions, ", "))
+	}
+	c.Notes = strings.TrimSpace(c.Notes)
+	if c.Acquisition != nil {
+		return validateAcquisition(c.Acquisition)
 	}
-	if strings.TrimSpace(book.Author) == "" {
-		return errors.New("author is required")
+	return nil
+}
+
+// validateAcquisition checks the method and price of an acquisition. A
+// price needs a currency, which is normalized to upper case.
+func validateAcquisition(a *domain.Acquisition) error {
+	if !domain.IsAcquisitionMethod(a.Method) {
+		return fmt.Errorf("%w: acquisition method must be one of %s", ErrInvalidCopyData, strings.Join(domain.AcquisitionMethods, ", "))
+	}
+	a.Store = strings.Join(strings.Fields(a.Store), " ")
+	if a.Price < 0 {
+		return fmt.Errorf("%w: price must not be negative", ErrInvalidCopyData)
+	}
+	a.Currency = strings.ToUpper(strings.TrimSpace(a.Currency))
+	if a.Price > 0 && a.Currency == "" {
+		return fmt.Errorf("%w: currency is required with a price", ErrInvalidCopyData)
+	}
+	if a.Currency != "" && !isCurrencyCode(a.Currency) {
+		return fmt.Errorf("%w: currency must be a three-letter code", ErrInvalidCopyData)
+	}
+	return nil
+}
+
+// ValidateDisposal checks that a book leaves the library as sold, donated,
+// lost or discarded, and that only sales have a price, which needs a
+// currency normalized to upper case.
+func ValidateDisposal(status string, d *domain.Disposal) error {
+	if !domain.IsDisposalStatus(status) {
+		return fmt.Errorf("%w: status must be one of %s", ErrInvalidDisposalData, strings.Join(domain.DisposalStatuses, ", "))
+	}
+	d.Recipient = strings.Join(strings.Fields(d.Recipient), " ")
+	d.Notes = strings.TrimSpace(d.Notes)
+	if d.Price < 0 {
+		return fmt.Errorf("%w: price must not be negative", ErrInvalidDisposalData)
+	}
+	if d.Price > 0 && status != domain.BookSold {
+		return fmt.Errorf("%w: only sold books have a price", ErrInvalidDisposalData)
+	}
+	d.Currency = strings.ToUpper(strings.TrimSpace(d.Currency))
+	if d.Price > 0 && d.Currency == "" {
+		return fmt.Errorf("%w: currency is required with a price", ErrInvalidDisposalData)
+	}
+	if d.Currency != "" && !isCurrencyCode(d.Currency) {
+		return fmt.Errorf("%w: currency must be a three-letter code", ErrInvalidDisposalData)
+	}
+	return nil
+}
+
+// ValidateExchangeRate checks the currency and value of an exchange rate,
+// normalizing the currency to upper case.
+func ValidateExchangeRate(rate *domain.ExchangeRate) error {
+	rate.Currency = strings.ToUpper(strings.TrimSpace(rate.Currency))
+	if !isCurrencyCode(rate.Currency) {
+		return fmt.Errorf("%w: currency must be a three-letter code", ErrInvalidExchangeRate)
+	}
+	if rate.Rate <= 0 {
+		return fmt.Errorf("%w: rate must be greater than zero", ErrInvalidExchangeRate)
+	}
+	return nil
+}
+
+// ValidateWishlistItem checks the title, priority, format and ISBN of a
+// wishlist item. The priority defaults to medium and the ISBN is stored as
+// an ISBN-13.
+func ValidateWishlistItem(item *domain.WishlistItem) error {
+	item.Title = strings.TrimSpace(item.Title)
+	if item.Title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidWishlistData)
+	}
+	item.Author = strings.TrimSpace(item.Author)
+	if item.Priority == "" {
+		item.Priority = domain.PriorityMedium
+	}
+	if domain.PriorityRank(item.Priority) < 0 {
+		return fmt.Errorf("%w: priority must be one of %s", ErrInvalidWishlistData, strings.Join(domain.WishlistPriorities, ", "))
+	}
+	if item.Format != "" && !domain.IsBookFormat(item.Format) {
+		return fmt.Errorf("%w: format must be one of %s", ErrInvalidWishlistData, strings.Join(domain.BookFormats, ", "))
+	}
+	if item.ISBN != "" {
+		_, isbn13, err := isbn.Parse(item.ISBN)
+		if err != nil {
+			return fmt.Errorf("%w: isbn is not a valid ISBN", ErrInvalidWishlistData)
+		}
+		item.ISBN = isbn13
+	}
+	item.Edition = strings.TrimSpace(item.Edition)
+	item.Notes = strings.TrimSpace(item.Notes)
+	return nil
+}
+
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateLoan checks the borrower and dates of a loan.
+func ValidateLoan(loan *domain.Loan) error {
+	loan.Borrower = strings.Join(strings.Fields(loan.Borrower), " ")
+	if loan.Borrower == "" {
+		return fmt.Errorf("%w: borrower is required", ErrInvalidLoanData)
+	}
+	if loan.DueAt.IsZero() {
+		return fmt.Errorf("%w: due_at is required", ErrInvalidLoanData)
+	}
+	if loan.DueAt.Before(loan.LentAt) {
+		return fmt.Errorf("%w: due_at must not be before lent_at", ErrInvalidLoanData)
+	}
+	loan.Notes = strings.TrimSpace(loan.Notes)
+	return nil
+}
+
+// uniqueNames returns the non-blank names not yet in seen, marking them as seen.
+func uniqueNames(names []string, seen map[string]bool) []string {
+	var unique []string
+	for _, name := range names {
+		name = strings.Join(strings.Fields(name), " ")
+		if key := strings.ToLower(name); name != "" && !seen[key] {
+			seen[key] = true
+			unique = append(unique, name)
+		}
+	}
+	return unique
+}
+
+func normalizeISBN(book *domain.Book) error {
+	var isbn10, isbn13 string
+	if book.ISBN10 != "" {
+		n := isbn.Normalize(book.ISBN10)
+		if !isbn.IsValid10(n) {
+			return fmt.Errorf("%w: isbn10 is not a valid ISBN-10", ErrInvalidBookData)
+		}
+		isbn10 = n
+		isbn13, _ = isbn.To13(n)
 	}
-	if book.Pages <= 0 {
-		return errors.New("pages must be greater than zero")
+	if book.ISBN13 != "" {
+		n := isbn.Normalize(book.ISBN13)
+		if !isbn.IsValid13(n) {
+			return fmt.Errorf("%w: isbn13 is not a valid ISBN-13", ErrInvalidBookData)
+		}
+		if isbn13 != "" && isbn13 != n {
+			return fmt.Errorf("%w: isbn10 and isbn13 refer to different books", ErrInvalidBookData)
+		}
+		isbn13 = n
+		if isbn10 == "" {
+			isbn10, _ = isbn.To10(n)
+		}
 	}
 
+	book.ISBN10, book.ISBN13 = isbn10, isbn13
 	return nil
 }
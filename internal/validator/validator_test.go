@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rfulgencio3/go-personal-library/internal/domain"
+)
+
+func TestValidateBookContributors(t *testing.T) {
+	tests := []struct {
+		name             string
+		author           string
+		contributors     []domain.Contributor
+		wantErr          error
+		wantAuthor       string
+		wantContributors []domain.Contributor
+	}{
+		{
+			name:       "author field only",
+			author:     "Machado de Assis",
+			wantAuthor: "Machado de Assis",
+			wantContributors: []domain.Contributor{
+				{Name: "Machado de Assis", Role: domain.RoleAuthor, Order: 1},
+			},
+		},
+		{
+			name:         "author field and a translator",
+			author:       "Gabriel García Márquez",
+			contributors: []domain.Contributor{{Name: "Eric  Nepomuceno", Role: domain.RoleTranslator, Order: 1}},
+			wantAuthor:   "Gabriel García Márquez",
+			wantContributors: []domain.Contributor{
+				{Name: "Gabriel García Márquez", Role: domain.RoleAuthor, Order: 1},
+				{Name: "Eric Nepomuceno", Role: domain.RoleTranslator, Order: 2},
+			},
+		},
+		{
+			name:   "list authors replace the author field",
+			author: "Outro Nome",
+			contributors: []domain.Contributor{
+				{Name: "Neil Gaiman", Order: 2},
+				{Name: "Terry Pratchett", Role: domain.RoleAuthor, Order: 1},
+			},
+			wantAuthor: "Terry Pratchett, Neil Gaiman",
+			wantContributors: []domain.Contributor{
+				{Name: "Terry Pratchett", Role: domain.RoleAuthor, Order: 1},
+				{Name: "Neil Gaiman", Role: domain.RoleAuthor, Order: 2},
+			},
+		},
+		{
+			name:         "translator without any author",
+			contributors: []domain.Contributor{{Name: "Eric Nepomuceno", Role: domain.RoleTranslator}},
+			wantErr:      ErrInvalidBookData,
+		},
+		{
+			name:         "unknown role",
+			author:       "Machado de Assis",
+			contributors: []domain.Contributor{{Name: "Fulano", Role: "narrator"}},
+			wantErr:      ErrInvalidBookData,
+		},
+		{
+			name:         "blank contributor name",
+			author:       "Machado de Assis",
+			contributors: []domain.Contributor{{Name: " ", Role: domain.RoleEditor}},
+			wantErr:      ErrInvalidBookData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := &domain.Book{Title: "Livro", Pages: 100, Author: tt.author, Contributors: tt.contributors}
+
+			err := ValidateBook(book)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateBook error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if book.Author != tt.wantAuthor {
+				t.Errorf("author = %q, want %q", book.Author, tt.wantAuthor)
+			}
+			if !reflect.DeepEqual(book.Contributors, tt.wantContributors) {
+				t.Errorf("contributors = %+v, want %+v", book.Contributors, tt.wantContributors)
+			}
+		})
+	}
+}
+
+func TestValidatePages(t *testing.T) {
+	tests := []struct {
+		pages        int
+		wantErr      error
+		wantImported error
+	}{
+		{pages: 256},
+		// Catálogos importados podem não trazer o número de páginas.
+		{pages: 0, wantErr: ErrInvalidBookData},
+		{pages: -1, wantErr: ErrInvalidBookData, wantImported: ErrInvalidBookData},
+	}
+	for _, tt := range tests {
+		book := &domain.Book{Title: "Dom Casmurro", Author: "Machado de Assis", Pages: tt.pages}
+		if err := ValidateBook(book); !errors.Is(err, tt.wantErr) {
+			t.Errorf("ValidateBook(pages %d) error = %v, want %v", tt.pages, err, tt.wantErr)
+		}
+		if err := ValidateImportedBook(book); !errors.Is(err, tt.wantImported) {
+			t.Errorf("ValidateImportedBook(pages %d) error = %v, want %v", tt.pages, err, tt.wantImported)
+		}
+	}
+}